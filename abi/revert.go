@@ -2,11 +2,17 @@ package abi
 
 import (
 	"bytes"
+	"encoding/hex"
 	"fmt"
+	"math/big"
+	"strings"
 )
 
 var revertId = []byte{0x8, 0xC3, 0x79, 0xA0}
 
+// panicId is the id of the Panic(uint256) error
+var panicId = []byte{0x4e, 0x48, 0x7b, 0x71}
+
 func UnpackRevertError(b []byte) (string, error) {
 	if !bytes.HasPrefix(b, revertId) {
 		return "", fmt.Errorf("revert error prefix not found")
@@ -21,3 +27,121 @@ func UnpackRevertError(b []byte) (string, error) {
 	revVal := vals.(map[string]interface{})["0"].(string)
 	return revVal, nil
 }
+
+// Sig returns the signature of the error
+func (e *Error) Sig() string {
+	return buildSignature(e.Name, e.Inputs)
+}
+
+// ID returns the id of the error used in the revert data
+func (e *Error) ID() []byte {
+	k := acquireKeccak()
+	k.Write([]byte(e.Sig()))
+	dst := k.Sum(nil)[:4]
+	releaseKeccak(k)
+	return dst
+}
+
+// Decode decodes the arguments of the error from the revert data
+func (e *Error) Decode(data []byte) (map[string]interface{}, error) {
+	if !bytes.HasPrefix(data, e.ID()) {
+		return nil, fmt.Errorf("error id of %s not found", e.Name)
+	}
+	respInterface, err := Decode(e.Inputs, data[4:])
+	if err != nil {
+		return nil, err
+	}
+	return respInterface.(map[string]interface{}), nil
+}
+
+// panicReasons are the reasons of the panic codes of solidity
+var panicReasons = map[uint64]string{
+	0x00: "generic panic",
+	0x01: "assert failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array encoding",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to zero-initialized internal function",
+}
+
+// PanicReason returns the reason of a solidity panic code
+func PanicReason(code *big.Int) string {
+	if code.IsUint64() {
+		if reason, ok := panicReasons[code.Uint64()]; ok {
+			return reason
+		}
+	}
+	return "unknown panic code"
+}
+
+// Revert is the decoded data of a reverted execution. Only one of
+// Reason, Panic or CustomError is set.
+type Revert struct {
+	// Reason is the message of an Error(string) revert (i.e. require)
+	Reason string
+
+	// Panic is the code of a Panic(uint256) revert (i.e. assert)
+	Panic *big.Int
+
+	// CustomError is the custom error of the revert and Args its arguments
+	CustomError *Error
+	Args        map[string]interface{}
+}
+
+// String implements the stringer interface
+func (r *Revert) String() string {
+	if r.Panic != nil {
+		return fmt.Sprintf("panic: %s (0x%x)", PanicReason(r.Panic), r.Panic)
+	}
+	if r.CustomError != nil {
+		args := []string{}
+		for _, elem := range r.CustomError.Inputs.TupleElems() {
+			args = append(args, fmt.Sprintf("%v", r.Args[elem.Name]))
+		}
+		return fmt.Sprintf("%s(%s)", r.CustomError.Name, strings.Join(args, ", "))
+	}
+	return r.Reason
+}
+
+// DecodeRevert decodes the data of a reverted execution either as an
+// Error(string), a Panic(uint256) or a custom error of any of the abis
+func DecodeRevert(data []byte, abis ...*ABI) (*Revert, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("revert data too short")
+	}
+
+	if bytes.HasPrefix(data, revertId) {
+		reason, err := UnpackRevertError(data)
+		if err != nil {
+			return nil, err
+		}
+		return &Revert{Reason: reason}, nil
+	}
+
+	if bytes.HasPrefix(data, panicId) {
+		vals, err := MustNewType("tuple(uint256)").Decode(data[4:])
+		if err != nil {
+			return nil, err
+		}
+		code := vals.(map[string]interface{})["0"].(*big.Int)
+		return &Revert{Panic: code}, nil
+	}
+
+	for _, a := range abis {
+		for _, e := range a.Errors {
+			if !bytes.HasPrefix(data, e.ID()) {
+				continue
+			}
+			args, err := e.Decode(data)
+			if err != nil {
+				return nil, err
+			}
+			return &Revert{CustomError: e, Args: args}, nil
+		}
+	}
+	return nil, fmt.Errorf("revert error 0x%s not found", hex.EncodeToString(data[:4]))
+}
@@ -1,6 +1,7 @@
 package abi
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -16,3 +17,57 @@ func TestUnpackRevertError(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "revert reason", reason)
 }
+
+func TestDecodeRevert(t *testing.T) {
+	// Error(string)
+	raw, err := decodeHex("08c379a00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000d72657665727420726561736f6e00000000000000000000000000000000000000")
+	assert.NoError(t, err)
+
+	revert, err := DecodeRevert(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, "revert reason", revert.Reason)
+	assert.Equal(t, "revert reason", revert.String())
+
+	// Panic(uint256)
+	raw, err = decodeHex("4e487b710000000000000000000000000000000000000000000000000000000000000011")
+	assert.NoError(t, err)
+
+	revert, err = DecodeRevert(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(0x11), revert.Panic)
+	assert.Equal(t, "panic: arithmetic overflow or underflow (0x11)", revert.String())
+
+	// custom error
+	a, err := NewABIFromList([]string{
+		"error InsufficientBalance(uint256 available, uint256 required)",
+	})
+	assert.NoError(t, err)
+
+	customErr := a.Errors["InsufficientBalance"]
+	assert.Equal(t, "InsufficientBalance(uint256,uint256)", customErr.Sig())
+
+	args, err := Encode(map[string]interface{}{
+		"available": big.NewInt(1),
+		"required":  big.NewInt(2),
+	}, customErr.Inputs)
+	assert.NoError(t, err)
+	raw = append(customErr.ID(), args...)
+
+	revert, err = DecodeRevert(raw, a)
+	assert.NoError(t, err)
+	assert.Equal(t, customErr, revert.CustomError)
+	assert.Equal(t, big.NewInt(2), revert.Args["required"])
+	assert.Equal(t, "InsufficientBalance(1, 2)", revert.String())
+
+	// unknown custom error
+	_, err = DecodeRevert(raw)
+	assert.Error(t, err)
+
+	_, err = DecodeRevert([]byte{0x1})
+	assert.Error(t, err)
+}
+
+func TestPanicReason(t *testing.T) {
+	assert.Equal(t, "assert failed", PanicReason(big.NewInt(1)))
+	assert.Equal(t, "unknown panic code", PanicReason(big.NewInt(0x99)))
+}
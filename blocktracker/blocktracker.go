@@ -3,6 +3,8 @@ package blocktracker
 import (
 	"context"
 	"fmt"
+	"io"
+	"log/slog"
 	"sync"
 	"time"
 
@@ -20,6 +22,14 @@ const (
 	defaultMaxBlockBacklog = 10
 )
 
+const (
+	// MetricHead is the gauge of the last block tracked
+	MetricHead = "blocktracker_head"
+
+	// MetricReorgs is the counter of reorgs found by the block tracker
+	MetricReorgs = "blocktracker_reorgs_total"
+)
+
 // BlockTracker is an interface to track new blocks on the chain
 type BlockTracker struct {
 	config       *Config
@@ -36,11 +46,15 @@ type BlockTracker struct {
 type Config struct {
 	Tracker         BlockTrackerInterface
 	MaxBlockBacklog uint64
+	Logger          *slog.Logger
+	Metrics         jsonrpc.Metrics
 }
 
 func DefaultConfig() *Config {
 	return &Config{
 		MaxBlockBacklog: defaultMaxBlockBacklog,
+		Logger:          slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Metrics:         jsonrpc.NoopMetrics{},
 	}
 }
 
@@ -58,6 +72,18 @@ func WithTracker(b BlockTrackerInterface) ConfigOption {
 	}
 }
 
+func WithLogger(l *slog.Logger) ConfigOption {
+	return func(c *Config) {
+		c.Logger = l
+	}
+}
+
+func WithMetrics(m jsonrpc.Metrics) ConfigOption {
+	return func(c *Config) {
+		c.Metrics = m
+	}
+}
+
 func NewBlockTracker(provider BlockProvider, opts ...ConfigOption) *BlockTracker {
 	config := DefaultConfig()
 	for _, opt := range opts {
@@ -296,6 +322,15 @@ func (t *BlockTracker) HandleReconcile(block *ethgo.Block) error {
 		return nil
 	}
 
+	head := blockEvnt.Added[len(blockEvnt.Added)-1]
+	if len(blockEvnt.Removed) != 0 {
+		t.config.Logger.Info("reorg", "removed", len(blockEvnt.Removed), "added", len(blockEvnt.Added), "number", head.Number, "hash", head.Hash)
+		t.config.Metrics.IncCounter(MetricReorgs, 1)
+	} else {
+		t.config.Logger.Debug("new block", "number", head.Number, "hash", head.Hash)
+	}
+	t.config.Metrics.SetGauge(MetricHead, float64(head.Number))
+
 	t.blockChsLock.Lock()
 	for _, ch := range t.blockChs {
 		select {
@@ -378,39 +413,23 @@ func NewSubscriptionBlockTracker(client *jsonrpc.Client) (*SubscriptionBlockTrac
 
 // Track implements the BlockTracker interface
 // This can take a long time so should be run concurrently.
-// Note that the error return variable must be named so that subscription cancellation errors can be returned in defer.
-func (s *SubscriptionBlockTracker) Track(ctx context.Context, handle func(block *ethgo.Block) error) (err error) {
-	data := make(chan []byte)
-	defer close(data)
-
-	// Subscribe with a callback. This callback must be unsubscribed
-	// before the data channel is closed, otherwise panic (write to closed channel)
-	callback := func(b []byte) {
-		data <- b
-	}
-	unsubscribe, err := s.client.Subscribe("newHeads", callback)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		if cerr := unsubscribe(); cerr != nil {
-			// Ensure subscription cancellation errors are returned via named return var
-			if err == nil {
-				err = cerr
-				return
-			}
-			err = fmt.Errorf("failed to cancel: %s, after error %w", cerr.Error(), err)
-		}
-	}()
+func (s *SubscriptionBlockTracker) Track(ctx context.Context, handle func(block *ethgo.Block) error) error {
+	// The subscription is re-established if the connection is lost. The blocks
+	// missed in between (or dropped if the handler is slow) are backfilled with
+	// the parents of the next block.
+	blockCh, sub := s.client.Eth().SubscribeNewHeads(ctx, jsonrpc.WithSlowConsumerPolicy(jsonrpc.SlowConsumerDrop))
+	defer sub.Unsubscribe()
 
 	for {
 		select {
-		case buf := <-data:
-			var block ethgo.Block
-			if err := block.UnmarshalJSON(buf); err != nil {
-				return err
+		case block, ok := <-blockCh:
+			if !ok {
+				if err := <-sub.Err(); err != nil {
+					return err
+				}
+				return ctx.Err()
 			}
-			if err := handle(&block); err != nil {
+			if err := handle(block); err != nil {
 				return err
 			}
 
@@ -379,3 +379,38 @@ func TestBlockTracker_Events(t *testing.T) {
 		})
 	}
 }
+
+type mockMetrics struct {
+	counters map[string]float64
+	gauges   map[string]float64
+}
+
+func (m *mockMetrics) IncCounter(name string, value float64, labels ...string) {
+	m.counters[name] += value
+}
+
+func (m *mockMetrics) SetGauge(name string, value float64, labels ...string) {
+	m.gauges[name] = value
+}
+
+func (m *mockMetrics) ObserveHistogram(name string, value float64, labels ...string) {
+}
+
+func TestBlockTracker_Metrics(t *testing.T) {
+	metrics := &mockMetrics{counters: map[string]float64{}, gauges: map[string]float64{}}
+
+	tt := NewBlockTracker(&testutil.MockClient{}, WithMetrics(metrics))
+	history := testutil.MockList{testutil.Mock(0x1), testutil.Mock(0x2), testutil.Mock(0x3)}
+	for _, b := range history.ToBlocks() {
+		assert.NoError(t, tt.AddBlockLocked(b))
+	}
+
+	assert.NoError(t, tt.HandleReconcile(testutil.Mock(0x4).Block()))
+	assert.Equal(t, float64(4), metrics.gauges[MetricHead])
+	assert.Equal(t, float64(0), metrics.counters[MetricReorgs])
+
+	// reorg
+	assert.NoError(t, tt.HandleReconcile(testutil.Mock(0x30).Parent(0x2).Block()))
+	assert.Equal(t, float64(3), metrics.gauges[MetricHead])
+	assert.Equal(t, float64(1), metrics.counters[MetricReorgs])
+}
@@ -0,0 +1,90 @@
+package ethgo
+
+import "encoding/hex"
+
+// BloomByteLength is the length in bytes of a logs bloom filter
+const BloomByteLength = 256
+
+// Bloom is the 2048 bits bloom filter of the addresses and topics
+// of the logs in a block or a receipt
+type Bloom [BloomByteLength]byte
+
+// CreateBloom returns the bloom filter of a list of logs
+func CreateBloom(logs []*Log) Bloom {
+	var b Bloom
+	for _, log := range logs {
+		b.Add(log.Address[:])
+		for _, topic := range log.Topics {
+			b.Add(topic[:])
+		}
+	}
+	return b
+}
+
+// BytesToBloom converts bytes to a bloom filter
+func BytesToBloom(b []byte) Bloom {
+	var bloom Bloom
+
+	n := min(len(b), BloomByteLength)
+	copy(bloom[BloomByteLength-n:], b[len(b)-n:])
+	return bloom
+}
+
+// Add adds an item to the bloom filter
+func (b *Bloom) Add(data []byte) {
+	for _, i := range bloomBits(data) {
+		b[BloomByteLength-1-i/8] |= 1 << (i % 8)
+	}
+}
+
+// Test checks whether an item might be in the bloom filter. A false result
+// means the item is not in the set of items used to build the filter.
+func (b Bloom) Test(data []byte) bool {
+	for _, i := range bloomBits(data) {
+		if b[BloomByteLength-1-i/8]&(1<<(i%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// TestAddress checks whether the address of a log might be in the bloom filter
+func (b Bloom) TestAddress(addr Address) bool {
+	return b.Test(addr[:])
+}
+
+// TestTopic checks whether the topic of a log might be in the bloom filter
+func (b Bloom) TestTopic(topic Hash) bool {
+	return b.Test(topic[:])
+}
+
+// Bytes returns the bytes of the Bloom
+func (b Bloom) Bytes() []byte {
+	return b[:]
+}
+
+// UnmarshalText implements the unmarshal interface
+func (b *Bloom) UnmarshalText(input []byte) error {
+	return unmarshalTextByte(b[:], input, BloomByteLength)
+}
+
+// MarshalText implements the marshal interface
+func (b Bloom) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+func (b Bloom) String() string {
+	return "0x" + hex.EncodeToString(b[:])
+}
+
+// bloomBits returns the three bits set in the filter for an item. Each of them
+// is taken from the low 11 bits of the first three pairs of bytes of its hash.
+func bloomBits(data []byte) [3]uint {
+	h := Keccak256(data)
+
+	var bits [3]uint
+	for i := 0; i < 3; i++ {
+		bits[i] = (uint(h[2*i])<<8 | uint(h[2*i+1])) & 2047
+	}
+	return bits
+}
@@ -0,0 +1,77 @@
+package ethgo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBloom_CreateBloom(t *testing.T) {
+	c := readTestsuite(t, "./testsuite/logs-bloom.json")[0]
+
+	var fixture struct {
+		Logs []struct {
+			Address Address
+			Topics  []Hash
+		}
+		Bloom Bloom
+	}
+	require.NoError(t, json.Unmarshal(c.content, &fixture))
+
+	logs := []*Log{}
+	for _, log := range fixture.Logs {
+		logs = append(logs, &Log{Address: log.Address, Topics: log.Topics})
+	}
+
+	bloom := CreateBloom(logs)
+	require.Equal(t, fixture.Bloom, bloom)
+
+	for _, log := range logs {
+		require.True(t, bloom.TestAddress(log.Address))
+		for _, topic := range log.Topics {
+			require.True(t, bloom.TestTopic(topic))
+		}
+	}
+	require.False(t, bloom.TestAddress(Address{0x1}))
+	require.False(t, bloom.TestTopic(Hash{0x1}))
+}
+
+func TestBloom_Encoding(t *testing.T) {
+	var b Bloom
+	b.Add([]byte{0x1, 0x2})
+
+	data, err := b.MarshalText()
+	require.NoError(t, err)
+
+	var b2 Bloom
+	require.NoError(t, b2.UnmarshalText(data))
+	require.Equal(t, b, b2)
+	require.Equal(t, b, BytesToBloom(b.Bytes()))
+}
+
+func TestLogFilter_MatchBloom(t *testing.T) {
+	topic0, topic1 := Hash{0x1}, Hash{0x2}
+
+	bloom := CreateBloom([]*Log{
+		{Address: Address{0x1}, Topics: []Hash{topic0, topic1}},
+	})
+
+	cases := []struct {
+		filter *LogFilter
+		match  bool
+	}{
+		{&LogFilter{}, true},
+		{&LogFilter{Address: []Address{{0x1}}}, true},
+		{&LogFilter{Address: []Address{{0x2}, {0x1}}}, true},
+		{&LogFilter{Address: []Address{{0x2}}}, false},
+		{&LogFilter{Topics: [][]*Hash{{&topic0}}}, true},
+		{&LogFilter{Topics: [][]*Hash{nil, {&topic1}}}, true},
+		{&LogFilter{Topics: [][]*Hash{{&Hash{0x3}}}}, false},
+		{&LogFilter{Topics: [][]*Hash{{&Hash{0x3}, &topic1}}}, true},
+		{&LogFilter{Address: []Address{{0x2}}, Topics: [][]*Hash{{&topic0}}}, false},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.match, c.filter.MatchBloom(bloom))
+	}
+}
@@ -2,8 +2,10 @@ package contract
 
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/umbracle/ethgo"
 	"github.com/umbracle/ethgo/abi"
@@ -18,8 +20,9 @@ type Provider interface {
 }
 
 type jsonRPCNodeProvider struct {
-	client  *jsonrpc.Eth
-	eip1559 bool
+	client     *jsonrpc.Eth
+	eip1559    bool
+	accessList bool
 }
 
 func (j *jsonRPCNodeProvider) Call(addr ethgo.Address, input []byte, opts *CallOpts) ([]byte, error) {
@@ -43,26 +46,28 @@ func (j *jsonRPCNodeProvider) Call(addr ethgo.Address, input []byte, opts *CallO
 
 func (j *jsonRPCNodeProvider) Txn(addr ethgo.Address, key ethgo.Key, input []byte) (Txn, error) {
 	txn := &jsonrpcTransaction{
-		opts:    &TxnOpts{},
-		input:   input,
-		client:  j.client,
-		key:     key,
-		to:      addr,
-		eip1559: j.eip1559,
+		opts:       &TxnOpts{},
+		input:      input,
+		client:     j.client,
+		key:        key,
+		to:         addr,
+		eip1559:    j.eip1559,
+		accessList: j.accessList,
 	}
 	return txn, nil
 }
 
 type jsonrpcTransaction struct {
-	to      ethgo.Address
-	input   []byte
-	hash    ethgo.Hash
-	opts    *TxnOpts
-	key     ethgo.Key
-	client  *jsonrpc.Eth
-	txn     *ethgo.Transaction
-	txnRaw  []byte
-	eip1559 bool
+	to         ethgo.Address
+	input      []byte
+	hash       ethgo.Hash
+	opts       *TxnOpts
+	key        ethgo.Key
+	client     *jsonrpc.Eth
+	txn        *ethgo.Transaction
+	txnRaw     []byte
+	eip1559    bool
+	accessList bool
 }
 
 func (j *jsonrpcTransaction) Hash() ethgo.Hash {
@@ -84,18 +89,31 @@ func (j *jsonrpcTransaction) Build() error {
 			return err
 		}
 	}
-	// estimate gas limit
-	if j.opts.GasLimit == 0 {
-		msg := &ethgo.CallMsg{
-			From:     from,
-			To:       nil,
-			Data:     j.input,
-			Value:    j.opts.Value,
-			GasPrice: j.opts.GasPrice,
+	msg := &ethgo.CallMsg{
+		From:     from,
+		To:       nil,
+		Data:     j.input,
+		Value:    j.opts.Value,
+		GasPrice: j.opts.GasPrice,
+	}
+	if j.to != ethgo.ZeroAddress {
+		msg.To = &j.to
+	}
+	// create the access list
+	var accessList ethgo.AccessList
+	if j.accessList {
+		res, err := j.client.CreateAccessList(msg, ethgo.Latest)
+		if err != nil {
+			return fmt.Errorf("failed to create access list: %v", err)
 		}
-		if j.to != ethgo.ZeroAddress {
-			msg.To = &j.to
+		if res.Error != "" {
+			return fmt.Errorf("failed to create access list: %s", res.Error)
 		}
+		accessList = res.AccessList
+		msg.AccessList = accessList
+	}
+	// estimate gas limit
+	if j.opts.GasLimit == 0 {
 		j.opts.GasLimit, err = j.client.EstimateGas(msg)
 		if err != nil {
 			return err
@@ -127,6 +145,10 @@ func (j *jsonrpcTransaction) Build() error {
 	if j.to != ethgo.ZeroAddress {
 		rawTxn.To = &j.to
 	}
+	if j.accessList {
+		rawTxn.Type = ethgo.TransactionAccessList
+		rawTxn.AccessList = accessList
+	}
 
 	if j.eip1559 {
 		rawTxn.Type = ethgo.TransactionDynamicFee
@@ -170,21 +192,36 @@ func (j *jsonrpcTransaction) Do() error {
 	return nil
 }
 
+const (
+	// minWaitDelay is the delay before the first poll of the receipt
+	minWaitDelay = 50 * time.Millisecond
+
+	// maxWaitDelay is the maximum delay between the polls of the receipt
+	maxWaitDelay = 2 * time.Second
+)
+
+// Wait polls the receipt of the transaction until it is mined
 func (j *jsonrpcTransaction) Wait() (*ethgo.Receipt, error) {
 	if (j.hash == ethgo.Hash{}) {
 		panic("transaction not executed")
 	}
 
+	delay := minWaitDelay
 	for {
 		receipt, err := j.client.GetTransactionReceipt(j.hash)
 		if err != nil {
-			if err.Error() != "not found" {
+			if !errors.Is(err, jsonrpc.ErrNotFound) {
 				return nil, err
 			}
 		}
 		if receipt != nil {
 			return receipt, nil
 		}
+
+		time.Sleep(delay)
+		if delay *= 2; delay > maxWaitDelay {
+			delay = maxWaitDelay
+		}
 	}
 }
 
@@ -202,6 +239,7 @@ type Opts struct {
 	Provider        Provider
 	Sender          ethgo.Key
 	EIP1559         bool
+	AccessList      bool
 }
 
 type ContractOption func(*Opts)
@@ -236,6 +274,14 @@ func WithEIP1559() ContractOption {
 	}
 }
 
+// WithAccessList attaches to the transactions the access list (EIP-2930)
+// created by the node with eth_createAccessList
+func WithAccessList() ContractOption {
+	return func(o *Opts) {
+		o.AccessList = true
+	}
+}
+
 func DeployContract(abi *abi.ABI, bin []byte, args []interface{}, opts ...ContractOption) (Txn, error) {
 	a := NewContract(ethgo.Address{}, abi, opts...)
 	a.bin = bin
@@ -254,10 +300,10 @@ func NewContract(addr ethgo.Address, abi *abi.ABI, opts ...ContractOption) *Cont
 	if opt.Provider != nil {
 		provider = opt.Provider
 	} else if opt.JsonRPCClient != nil {
-		provider = &jsonRPCNodeProvider{client: opt.JsonRPCClient, eip1559: opt.EIP1559}
+		provider = &jsonRPCNodeProvider{client: opt.JsonRPCClient, eip1559: opt.EIP1559, accessList: opt.AccessList}
 	} else {
 		client, _ := jsonrpc.NewClient(opt.JsonRPCEndpoint)
-		provider = &jsonRPCNodeProvider{client: client.Eth(), eip1559: opt.EIP1559}
+		provider = &jsonRPCNodeProvider{client: client.Eth(), eip1559: opt.EIP1559, accessList: opt.AccessList}
 	}
 
 	a := &Contract{
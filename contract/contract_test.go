@@ -299,3 +299,37 @@ func TestContract_EIP1559(t *testing.T) {
 	assert.NotZero(t, txnObj.MaxFeePerGas)
 	assert.NotZero(t, txnObj.MaxPriorityFeePerGas)
 }
+
+func TestContract_AccessList(t *testing.T) {
+	s := testutil.NewTestServer(t)
+
+	key, _ := wallet.GenerateKey()
+	s.Fund(key.Address())
+
+	cc := &testutil.Contract{}
+	cc.AddOutputCaller("example")
+
+	artifact, addr, err := s.DeployContract(cc)
+	require.NoError(t, err)
+
+	abi, err := abi.NewABI(artifact.Abi)
+	assert.NoError(t, err)
+
+	client, _ := jsonrpc.NewClient(s.HTTPAddr())
+	contract := NewContract(addr, abi, WithJsonRPC(client.Eth()), WithSender(key), WithAccessList())
+
+	txn, err := contract.Txn("example")
+	assert.NoError(t, err)
+
+	err = txn.Do()
+	assert.NoError(t, err)
+
+	_, err = txn.Wait()
+	assert.NoError(t, err)
+
+	txnObj, err := client.Eth().GetTransactionByHash(txn.Hash())
+	assert.NoError(t, err)
+
+	assert.Equal(t, ethgo.TransactionAccessList, txnObj.Type)
+	assert.NotNil(t, txnObj.AccessList)
+}
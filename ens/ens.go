@@ -70,11 +70,11 @@ func NewENS(opts ...EnsOption) (*ENS, error) {
 		if err != nil {
 			return nil, err
 		}
-		addr, ok := builtinEnsAddr[chainID.Uint64()]
-		if !ok {
+		chain, ok := ethgo.Network(chainID.Uint64()).Chain()
+		if !ok || chain.EnsRegistry == ethgo.ZeroAddress {
 			return nil, fmt.Errorf("no builtin Ens resolver found for chain %s", chainID)
 		}
-		config.Resolver = addr
+		config.Resolver = chain.EnsRegistry
 	}
 	ens := &ENS{
 		config: config,
@@ -3,41 +3,35 @@ package etherscan
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/umbracle/ethgo"
 	"github.com/umbracle/ethgo/jsonrpc/codec"
-	"github.com/valyala/fasthttp"
 )
 
 // Etherscan is a provider using the Etherscan api
 type Etherscan struct {
-	client fasthttp.Client
-	url    string
-	apiKey string
+	client  http.Client
+	url     string
+	apiKey  string
+	chainID uint64
 }
 
 // NewEtherscanFromNetwork creates a new client from the network id
 func NewEtherscanFromNetwork(n ethgo.Network, apiKey string) (*Etherscan, error) {
-	var url string
-	switch n {
-	case ethgo.Mainnet:
-		url = "https://api.etherscan.io"
-
-	case ethgo.Ropsten:
-		url = "https://ropsten.etherscan.io"
-
-	case ethgo.Rinkeby:
-		url = "https://rinkeby.etherscan.io"
-
-	case ethgo.Goerli:
-		url = "https://goerli.etherscan.io"
-
-	default:
-		return nil, fmt.Errorf("unknwon network id %d", n)
+	chain, ok := n.Chain()
+	if !ok {
+		return nil, fmt.Errorf("unknown network id %d", n)
+	}
+	if chain.EtherscanAPI == "" {
+		return nil, fmt.Errorf("no etherscan api for network %s", chain.Name)
 	}
-	return NewEtherscan(url, apiKey), nil
+	e := NewEtherscan(chain.EtherscanAPI, apiKey)
+	e.chainID = uint64(chain.ChainID)
+	return e, nil
 }
 
 // NewEtherscan creates a new Etherscan service from a url
@@ -54,6 +48,10 @@ type proxyResponse struct {
 // Query sends a query to Etherscan
 func (e *Etherscan) Query(module, action string, out interface{}, params map[string]string) error {
 	url := fmt.Sprintf("%s/api?module=%s&action=%s", e.url, module, action)
+	if e.chainID != 0 {
+		// the chain of the Etherscan V2 api
+		url += "&chainid=" + strconv.FormatUint(e.chainID, 10)
+	}
 	if len(params) != 0 {
 		res := []string{}
 		for k, v := range params {
@@ -65,16 +63,14 @@ func (e *Etherscan) Query(module, action string, out interface{}, params map[str
 		url = url + "&apikey=" + e.apiKey
 	}
 
-	req := fasthttp.AcquireRequest()
-	res := fasthttp.AcquireResponse()
-
-	defer fasthttp.ReleaseRequest(req)
-	defer fasthttp.ReleaseResponse(res)
-
-	req.SetRequestURI(url)
-	req.Header.SetMethod("GET")
+	res, err := e.client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
 
-	if err := e.client.Do(req, res); err != nil {
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
 		return err
 	}
 
@@ -83,7 +79,7 @@ func (e *Etherscan) Query(module, action string, out interface{}, params map[str
 
 	if module == "proxy" {
 		var response codec.Response
-		if err := json.Unmarshal(res.Body(), &response); err != nil {
+		if err := json.Unmarshal(body, &response); err != nil {
 			return err
 		}
 		if response.Error != nil {
@@ -92,7 +88,7 @@ func (e *Etherscan) Query(module, action string, out interface{}, params map[str
 		result = response.Result
 	} else {
 		var response proxyResponse
-		if err := json.Unmarshal(res.Body(), &response); err != nil {
+		if err := json.Unmarshal(body, &response); err != nil {
 			return err
 		}
 		result = response.Result
@@ -13,7 +13,9 @@ func testEtherscanMainnet(t *testing.T) *Etherscan {
 	if apiKey == "" {
 		t.Skip("Etherscan APIKey not specified")
 	}
-	return &Etherscan{url: "https://api.etherscan.io", apiKey: apiKey}
+	e, err := NewEtherscanFromNetwork(ethgo.Mainnet, apiKey)
+	assert.NoError(t, err)
+	return e
 }
 
 func TestNewEtherscan(t *testing.T) {
@@ -24,6 +26,20 @@ func TestNewEtherscan(t *testing.T) {
 	assert.Equal(t, wantApiKey, e.apiKey)
 }
 
+func TestNewEtherscanFromNetwork(t *testing.T) {
+	e, err := NewEtherscanFromNetwork(ethgo.Sepolia, "abc123")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://api.etherscan.io/v2", e.url)
+	assert.Equal(t, uint64(ethgo.Sepolia), e.chainID)
+
+	_, err = NewEtherscanFromNetwork(ethgo.Network(123456789), "abc123")
+	assert.Error(t, err)
+
+	// the chain has been shut down
+	_, err = NewEtherscanFromNetwork(ethgo.Goerli, "abc123")
+	assert.Error(t, err)
+}
+
 func TestBlockByNumber(t *testing.T) {
 	e := testEtherscanMainnet(t)
 	n, err := e.BlockNumber()
@@ -0,0 +1,94 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Admin is the admin namespace
+type Admin struct {
+	c *Client
+}
+
+// Admin returns the reference to the admin namespace
+func (c *Client) Admin() *Admin {
+	return c.endpoints.a
+}
+
+// WithContext returns the admin namespace of a client that uses the context for all its requests
+func (a *Admin) WithContext(ctx context.Context) *Admin {
+	return a.c.WithContext(ctx).Admin()
+}
+
+// NodeInfo is the information of the node
+type NodeInfo struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Enode      string `json:"enode"`
+	ENR        string `json:"enr"`
+	IP         string `json:"ip"`
+	ListenAddr string `json:"listenAddr"`
+	Ports      struct {
+		Discovery int `json:"discovery"`
+		Listener  int `json:"listener"`
+	} `json:"ports"`
+
+	// Protocols is the information of the protocols
+	// run by the node (i.e. eth or snap) by name
+	Protocols map[string]json.RawMessage `json:"protocols"`
+}
+
+// NodeInfo returns the information of the node
+func (a *Admin) NodeInfo() (*NodeInfo, error) {
+	var out *NodeInfo
+	if err := a.c.Call("admin_nodeInfo", &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PeerInfo is the information of a peer connected to the node
+type PeerInfo struct {
+	ID    string   `json:"id"`
+	Name  string   `json:"name"`
+	Enode string   `json:"enode"`
+	ENR   string   `json:"enr"`
+	Caps  []string `json:"caps"`
+
+	Network struct {
+		LocalAddress  string `json:"localAddress"`
+		RemoteAddress string `json:"remoteAddress"`
+		Inbound       bool   `json:"inbound"`
+		Trusted       bool   `json:"trusted"`
+		Static        bool   `json:"static"`
+	} `json:"network"`
+
+	// Protocols is the information of the protocols
+	// run with the peer (i.e. eth or snap) by name
+	Protocols map[string]json.RawMessage `json:"protocols"`
+}
+
+// Peers returns the peers connected to the node
+func (a *Admin) Peers() ([]*PeerInfo, error) {
+	var out []*PeerInfo
+	if err := a.c.Call("admin_peers", &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AddPeer connects to the peer with the enode url and keeps
+// the connection. It returns whether the peer was added.
+func (a *Admin) AddPeer(enode string) (bool, error) {
+	var out bool
+	err := a.c.Call("admin_addPeer", &out, enode)
+	return out, err
+}
+
+// RemovePeer disconnects from the peer with the enode url.
+// It returns whether the peer was removed.
+func (a *Admin) RemovePeer(enode string) (bool, error) {
+	var out bool
+	err := a.c.Call("admin_removePeer", &out, enode)
+	return out, err
+}
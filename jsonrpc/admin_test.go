@@ -0,0 +1,61 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdmin(t *testing.T) {
+	enode := "enode://6f8a80d14311c39f35f516fa664deaaaa13e85b2f7493f37f6144d86991ec012937307647bd3b9a82abe2974e1407241d54947bbb39763a4cac9f77166ad92a0@10.3.58.6:30303"
+
+	c, srv := newMockClient(t, map[string]string{
+		"admin_nodeInfo": `{
+			"enode": "` + enode + `",
+			"id": "05cbd5",
+			"ip": "10.3.58.6",
+			"listenAddr": "[::]:30303",
+			"name": "Geth/v1.13.0",
+			"ports": {"discovery": 30303, "listener": 30304},
+			"protocols": {"eth": {"network": 1}}
+		}`,
+		"admin_peers": `[{
+			"caps": ["eth/68", "snap/1"],
+			"enode": "` + enode + `",
+			"id": "6f8a80",
+			"name": "Geth/v1.13.0",
+			"network": {"inbound": true, "localAddress": "10.3.58.7:30303", "remoteAddress": "10.3.58.6:52000", "static": false, "trusted": true},
+			"protocols": {"eth": {"version": 68}}
+		}]`,
+		"admin_addPeer":    "true",
+		"admin_removePeer": "false",
+	})
+
+	info, err := c.Admin().NodeInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, enode, info.Enode)
+	assert.Equal(t, 30304, info.Ports.Listener)
+
+	var eth struct {
+		Network uint64 `json:"network"`
+	}
+	assert.NoError(t, json.Unmarshal(info.Protocols["eth"], &eth))
+	assert.Equal(t, uint64(1), eth.Network)
+
+	peers, err := c.Admin().Peers()
+	assert.NoError(t, err)
+	assert.Len(t, peers, 1)
+	assert.Equal(t, []string{"eth/68", "snap/1"}, peers[0].Caps)
+	assert.True(t, peers[0].Network.Inbound)
+	assert.True(t, peers[0].Network.Trusted)
+
+	added, err := c.Admin().AddPeer(enode)
+	assert.NoError(t, err)
+	assert.True(t, added)
+	assert.Equal(t, `["`+enode+`"]`, string(srv.params["admin_addPeer"]))
+
+	removed, err := c.Admin().RemovePeer(enode)
+	assert.NoError(t, err)
+	assert.False(t, removed)
+}
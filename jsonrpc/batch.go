@@ -0,0 +1,99 @@
+package jsonrpc
+
+import (
+	"context"
+
+	"github.com/umbracle/ethgo/jsonrpc/transport"
+)
+
+// defaultBatchSize is the default maximum number of requests in a batch
+const defaultBatchSize = 100
+
+// BatchElem is a single request in a batch
+type BatchElem = transport.BatchElem
+
+// Batch is a list of jsonrpc calls sent together in batch requests
+type Batch struct {
+	c     *Client
+	elems []BatchElem
+}
+
+// NewBatch creates a new empty batch of calls
+func (c *Client) NewBatch() *Batch {
+	return &Batch{c: c}
+}
+
+// Add queues a jsonrpc call in the batch. The result of the call is decoded in out
+// and the returned index can be used to read the error of the call after the batch is sent.
+func (b *Batch) Add(method string, out interface{}, params ...interface{}) int {
+	b.elems = append(b.elems, BatchElem{
+		Method: method,
+		Params: params,
+		Result: out,
+	})
+	return len(b.elems) - 1
+}
+
+// Len returns the number of calls in the batch
+func (b *Batch) Len() int {
+	return len(b.elems)
+}
+
+// Error returns the error of the call with the given index
+func (b *Batch) Error(indx int) error {
+	return b.elems[indx].Error
+}
+
+// Errors returns the errors of all the calls in the batch
+func (b *Batch) Errors() []error {
+	errs := make([]error, len(b.elems))
+	for indx, elem := range b.elems {
+		errs[indx] = elem.Error
+	}
+	return errs
+}
+
+// Send sends all the calls of the batch
+func (b *Batch) Send() error {
+	return b.c.BatchCallContext(b.c.ctx, b.elems)
+}
+
+// SendContext sends all the calls of the batch and aborts if the context is done
+func (b *Batch) SendContext(ctx context.Context) error {
+	return b.c.BatchCallContext(ctx, b.elems)
+}
+
+// BatchCall sends the calls in batch requests
+func (c *Client) BatchCall(elems []BatchElem) error {
+	return c.BatchCallContext(c.ctx, elems)
+}
+
+// BatchCallContext sends the calls in batch requests of at most the batch size
+// of the client. It only returns an error if any of the requests fails, the errors
+// of the individual calls are set in each element. If the transport does not
+// support batches, the calls are made one by one.
+func (c *Client) BatchCallContext(ctx context.Context, elems []BatchElem) error {
+	batchTransport, ok := c.transport.(transport.BatchTransport)
+	if !ok {
+		for indx := range elems {
+			elem := &elems[indx]
+			elem.Error = transport.CallContext(ctx, c.transport, elem.Method, elem.Result, elem.Params...)
+		}
+		return nil
+	}
+
+	size := c.config.batchSize
+	if size <= 0 {
+		size = len(elems)
+	}
+	for len(elems) != 0 {
+		if size > len(elems) {
+			size = len(elems)
+		}
+		if err := batchTransport.BatchCallContext(ctx, elems[:size]); err != nil {
+			return err
+		}
+		elems = elems[size:]
+	}
+	return nil
+}
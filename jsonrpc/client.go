@@ -1,6 +1,8 @@
 package jsonrpc
 
 import (
+	"context"
+
 	"github.com/umbracle/ethgo/jsonrpc/transport"
 )
 
@@ -8,6 +10,13 @@ import (
 type Client struct {
 	transport transport.Transport
 	endpoints endpoints
+	config    *Config
+
+	// ctx is the context used by the requests of the client
+	ctx context.Context
+
+	// handler is the chain of interceptors if any
+	handler Handler
 }
 
 type endpoints struct {
@@ -15,10 +24,24 @@ type endpoints struct {
 	e *Eth
 	n *Net
 	d *Debug
+	g *Engine
+	t *Trace
+	p *TxPool
+	a *Admin
 }
 
 type Config struct {
-	headers map[string]string
+	headers   map[string]string
+	batchSize int
+	retry     *transport.RetryConfig
+	rateLimit float64
+	burst     int
+	reconnect *transport.ReconnectConfig
+
+	jwtSecret     []byte
+	jwtSecretFile string
+
+	interceptors []Interceptor
 }
 
 type ConfigOption func(*Config)
@@ -31,24 +54,128 @@ func WithHeaders(headers map[string]string) ConfigOption {
 	}
 }
 
-func NewClient(addr string, opts ...ConfigOption) (*Client, error) {
-	config := &Config{headers: map[string]string{}}
+// WithBatchSize sets the maximum number of requests sent in a single batch.
+// Larger batches are split in several requests.
+func WithBatchSize(size int) ConfigOption {
+	return func(c *Config) {
+		c.batchSize = size
+	}
+}
+
+// WithRetry retries the requests that fail with a retryable error
+// (i.e. network errors or http 429). If config is nil the default
+// configuration is used.
+func WithRetry(config *transport.RetryConfig) ConfigOption {
+	return func(c *Config) {
+		if config == nil {
+			config = transport.DefaultRetryConfig()
+		}
+		c.retry = config
+	}
+}
+
+// WithRateLimit limits the number of requests per second sent
+// by the client with bursts of up to burst requests
+func WithRateLimit(rate float64, burst int) ConfigOption {
+	return func(c *Config) {
+		c.rateLimit = rate
+		c.burst = burst
+	}
+}
+
+// WithReconnect sets how the websocket and ipc transports reconnect
+// when the connection is lost. By default the transports reconnect
+// with the transport.DefaultReconnectConfig configuration.
+func WithReconnect(config *transport.ReconnectConfig) ConfigOption {
+	return func(c *Config) {
+		c.reconnect = config
+	}
+}
+
+// WithJWTSecret authenticates the requests with an HS256 token signed
+// with the secret, as required by the engine api of the execution clients
+func WithJWTSecret(secret []byte) ConfigOption {
+	return func(c *Config) {
+		c.jwtSecret = secret
+	}
+}
+
+// WithJWTSecretFile authenticates the requests with an HS256 token signed
+// with the hex encoded secret stored in the file (i.e. jwt.hex)
+func WithJWTSecretFile(path string) ConfigOption {
+	return func(c *Config) {
+		c.jwtSecretFile = path
+	}
+}
+
+func newConfig(opts []ConfigOption) *Config {
+	config := &Config{headers: map[string]string{}, batchSize: defaultBatchSize}
 	for _, opt := range opts {
 		opt(config)
 	}
+	return config
+}
+
+func NewClient(addr string, opts ...ConfigOption) (*Client, error) {
+	config := newConfig(opts)
+
+	jwtSecret := config.jwtSecret
+	if config.jwtSecretFile != "" {
+		secret, err := transport.ReadJWTSecret(config.jwtSecretFile)
+		if err != nil {
+			return nil, err
+		}
+		jwtSecret = secret
+	}
+
+	t, err := transport.NewTransportWithConfig(addr, &transport.Config{
+		Headers:   config.headers,
+		JWTSecret: jwtSecret,
+		Reconnect: config.reconnect,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newClientWithConfig(t, config), nil
+}
+
+// NewClientWithTransport creates a client that uses the given transport
+// (i.e. a multi endpoint transport created with transport.NewMulti)
+func NewClientWithTransport(t transport.Transport, opts ...ConfigOption) *Client {
+	return newClientWithConfig(t, newConfig(opts))
+}
+
+func newClientWithConfig(t transport.Transport, config *Config) *Client {
+	if config.rateLimit > 0 {
+		t = transport.NewRateLimit(t, config.rateLimit, config.burst)
+	}
+	if config.retry != nil {
+		// retries go through the rate limit too
+		t = transport.NewRetry(t, config.retry)
+	}
+	return newClient(t, config, context.Background())
+}
 
-	c := &Client{}
+func newClient(t transport.Transport, config *Config, ctx context.Context) *Client {
+	c := &Client{transport: t, config: config, ctx: ctx}
+	if len(config.interceptors) != 0 {
+		c.handler = chainInterceptors(config.interceptors, c.transportHandler)
+	}
 	c.endpoints.w = &Web3{c}
 	c.endpoints.e = &Eth{c}
 	c.endpoints.n = &Net{c}
 	c.endpoints.d = &Debug{c}
+	c.endpoints.g = &Engine{c}
+	c.endpoints.t = &Trace{c}
+	c.endpoints.p = &TxPool{c}
+	c.endpoints.a = &Admin{c}
+	return c
+}
 
-	t, err := transport.NewTransport(addr, config.headers)
-	if err != nil {
-		return nil, err
-	}
-	c.transport = t
-	return c, nil
+// WithContext returns a client that shares the transport but uses
+// the context for all its requests, including the namespaces
+func (c *Client) WithContext(ctx context.Context) *Client {
+	return newClient(c.transport, c.config, ctx)
 }
 
 // Close closes the transport
@@ -58,7 +185,20 @@ func (c *Client) Close() error {
 
 // Call makes a jsonrpc call
 func (c *Client) Call(method string, out interface{}, params ...interface{}) error {
-	return c.transport.Call(method, out, params...)
+	return c.CallContext(c.ctx, method, out, params...)
+}
+
+// CallContext makes a jsonrpc call that is aborted if the context is done
+func (c *Client) CallContext(ctx context.Context, method string, out interface{}, params ...interface{}) error {
+	if c.handler == nil {
+		return transport.CallContext(ctx, c.transport, method, out, params...)
+	}
+	req := &Request{
+		Method: method,
+		Params: params,
+		Result: out,
+	}
+	return c.handler(ctx, req)
 }
 
 // SetMaxConnsLimit sets the maximum number of connections that can be established with a host
@@ -0,0 +1,240 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/ethgo/jsonrpc/codec"
+)
+
+// mockServer replies to the requests with the results or the errors
+// of the method and stores the params of the requests
+type mockServer struct {
+	t       *testing.T
+	results map[string]string
+	errors  map[string]*codec.ErrorObject
+	params  map[string]json.RawMessage
+	auth    []string
+}
+
+func (m *mockServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.auth = append(m.auth, r.Header.Get("Authorization"))
+
+	data, err := io.ReadAll(r.Body)
+	assert.NoError(m.t, err)
+
+	var req codec.Request
+	assert.NoError(m.t, json.Unmarshal(data, &req))
+	m.params[req.Method] = req.Params
+
+	resp := codec.Response{ID: req.ID}
+	if errObj, ok := m.errors[req.Method]; ok {
+		resp.Error = errObj
+	} else {
+		resp.Result = json.RawMessage(m.results[req.Method])
+	}
+	res, err := json.Marshal(resp)
+	assert.NoError(m.t, err)
+	w.Write(res)
+}
+
+// newMockClient returns a client connected to a mock server
+// that replies with the results of the methods
+func newMockClient(t *testing.T, results map[string]string) (*Client, *mockServer) {
+	srv := &mockServer{
+		t:       t,
+		results: results,
+		errors:  map[string]*codec.ErrorObject{},
+		params:  map[string]json.RawMessage{},
+	}
+	httpSrv := httptest.NewServer(srv)
+	t.Cleanup(httpSrv.Close)
+
+	c, err := NewClient(httpSrv.URL)
+	assert.NoError(t, err)
+	return c, srv
+}
+
+func TestClient_WithContext(t *testing.T) {
+	doneCh := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// never reply
+		<-doneCh
+	}))
+	defer srv.Close()
+	defer close(doneCh)
+
+	c, err := NewClient(srv.URL)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err = c.Eth().WithContext(ctx).BlockNumber()
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	var out string
+	err = c.CallContext(ctx, "web3_clientVersion", &out)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClient_Batch(t *testing.T) {
+	var sizes []int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqs []codec.Request
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&reqs))
+		sizes = append(sizes, len(reqs))
+
+		resps := []codec.Response{}
+		for _, req := range reqs {
+			resps = append(resps, codec.Response{ID: req.ID, Result: req.Params[1 : len(req.Params)-1]})
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(resps))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, WithBatchSize(2))
+	assert.NoError(t, err)
+
+	batch := c.NewBatch()
+
+	res := make([]uint64, 5)
+	for i := range res {
+		batch.Add("echo", &res[i], i)
+	}
+	assert.NoError(t, batch.Send())
+
+	assert.Equal(t, []int{2, 2, 1}, sizes)
+	assert.Equal(t, []uint64{0, 1, 2, 3, 4}, res)
+	assert.Equal(t, make([]error, 5), batch.Errors())
+}
+
+type mockMetrics struct {
+	counters   map[string]float64
+	histograms map[string][]float64
+}
+
+func (m *mockMetrics) IncCounter(name string, value float64, labels ...string) {
+	m.counters[name+strings.Join(labels, ",")] += value
+}
+
+func (m *mockMetrics) SetGauge(name string, value float64, labels ...string) {
+}
+
+func (m *mockMetrics) ObserveHistogram(name string, value float64, labels ...string) {
+	m.histograms[name] = append(m.histograms[name], value)
+}
+
+type mockSpan struct {
+	name  string
+	attrs map[string]interface{}
+	err   error
+	ended bool
+}
+
+func (s *mockSpan) SetAttribute(key string, value interface{}) {
+	s.attrs[key] = value
+}
+
+func (s *mockSpan) RecordError(err error) {
+	s.err = err
+}
+
+func (s *mockSpan) End() {
+	s.ended = true
+}
+
+type mockTracer struct {
+	spans []*mockSpan
+}
+
+func (m *mockTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	span := &mockSpan{name: name, attrs: map[string]interface{}{}}
+	m.spans = append(m.spans, span)
+	return ctx, span
+}
+
+func TestClient_Interceptors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req codec.Request
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		if req.Method == "eth_chainId" {
+			w.Write([]byte(`{"jsonrpc":"2.0","id":0,"result":"0x1"}`))
+		} else {
+			w.Write([]byte(`{"jsonrpc":"2.0","id":0,"error":{"code":-32601,"message":"method not found"}}`))
+		}
+	}))
+	defer srv.Close()
+
+	var order []string
+	orderInterceptor := func(name string) Interceptor {
+		return func(ctx context.Context, req *Request, next Handler) error {
+			order = append(order, name)
+			return next(ctx, req)
+		}
+	}
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	metrics := &mockMetrics{counters: map[string]float64{}, histograms: map[string][]float64{}}
+	tracer := &mockTracer{}
+
+	c, err := NewClient(srv.URL, WithInterceptors(
+		orderInterceptor("a"),
+		orderInterceptor("b"),
+		NewLoggingInterceptor(logger),
+		NewMetricsInterceptor(metrics),
+		NewTracingInterceptor(tracer),
+	))
+	assert.NoError(t, err)
+
+	var out string
+	assert.NoError(t, c.Call("eth_chainId", &out))
+	assert.Equal(t, "0x1", out)
+
+	assert.Error(t, c.Call("eth_unknown", &out))
+
+	// interceptors are called in order
+	assert.Equal(t, []string{"a", "b", "a", "b"}, order)
+
+	// logs
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	assert.Len(t, lines, 2)
+
+	var record map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &record))
+	assert.Equal(t, "DEBUG", record["level"])
+	assert.Equal(t, "eth_chainId", record["method"])
+	assert.Equal(t, float64(5), record["size"])
+
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &record))
+	assert.Equal(t, "WARN", record["level"])
+	assert.Equal(t, "eth_unknown", record["method"])
+
+	// metrics
+	assert.Equal(t, float64(1), metrics.counters[MetricRequests+"method,eth_chainId,status,ok"])
+	assert.Equal(t, float64(1), metrics.counters[MetricRequests+"method,eth_unknown,status,error"])
+	assert.Len(t, metrics.histograms[MetricRequestDuration], 2)
+	assert.Equal(t, []float64{5}, metrics.histograms[MetricResponseSize])
+
+	// spans
+	assert.Len(t, tracer.spans, 2)
+	assert.Equal(t, "eth_chainId", tracer.spans[0].name)
+	assert.True(t, tracer.spans[0].ended)
+	assert.NoError(t, tracer.spans[0].err)
+	assert.Error(t, tracer.spans[1].err)
+	assert.Equal(t, -32601, tracer.spans[1].attrs["rpc.jsonrpc.error_code"])
+}
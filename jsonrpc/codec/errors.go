@@ -0,0 +1,139 @@
+package codec
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrExecutionReverted is the error of a call or transaction that reverted
+	ErrExecutionReverted = errors.New("execution reverted")
+
+	// ErrNonceTooLow is the error of a transaction with a nonce already used
+	ErrNonceTooLow = errors.New("nonce too low")
+
+	// ErrInsufficientFunds is the error of a transaction whose sender
+	// cannot pay for the value and the gas
+	ErrInsufficientFunds = errors.New("insufficient funds")
+
+	// ErrReplacementUnderpriced is the error of a transaction that replaces another one
+	// in the pool with the same nonce without enough increase in the gas price
+	ErrReplacementUnderpriced = errors.New("replacement transaction underpriced")
+
+	// ErrLimitExceeded is the error of a request that exceeds a limit of the
+	// node (i.e. the maximum number of logs in a response)
+	ErrLimitExceeded = errors.New("limit exceeded")
+
+	// ErrNotFound is the error of a request for a resource that
+	// does not exist (i.e. a receipt of a pending transaction)
+	ErrNotFound = errors.New("not found")
+)
+
+const (
+	// codeExecutionReverted is the code of the reverted errors in geth
+	codeExecutionReverted = 3
+
+	// codeLimitExceeded is the code of the limit exceeded errors (eip-1474)
+	codeLimitExceeded = -32005
+
+	// codeResourceNotFound is the code of the resource not found errors (eip-1474)
+	codeResourceNotFound = -32001
+)
+
+// RevertError is the error of a call or transaction that reverted. It matches
+// ErrExecutionReverted and it is returned by errors.As for any jsonrpc error
+// of a reverted execution.
+type RevertError struct {
+	// Message is the message of the jsonrpc error
+	Message string
+
+	// Data is the data returned by the reverted execution if any
+	// (i.e. the abi encoded Error(string) or a custom error)
+	Data []byte
+}
+
+// Error implements the error interface
+func (r *RevertError) Error() string {
+	if r.Message == "" {
+		return ErrExecutionReverted.Error()
+	}
+	return r.Message
+}
+
+// Is implements the errors.Is interface
+func (r *RevertError) Is(target error) bool {
+	return target == ErrExecutionReverted
+}
+
+// Is implements the errors.Is interface. It matches the jsonrpc
+// error with the sentinel errors by code and message since each
+// client uses its own codes and messages.
+func (e *ErrorObject) Is(target error) bool {
+	msg := strings.ToLower(e.Message)
+
+	switch target {
+	case ErrExecutionReverted:
+		return e.isReverted()
+	case ErrNonceTooLow:
+		return strings.Contains(msg, "nonce too low")
+	case ErrInsufficientFunds:
+		return strings.Contains(msg, "insufficient funds")
+	case ErrReplacementUnderpriced:
+		return strings.Contains(msg, "replacement transaction underpriced") ||
+			strings.Contains(msg, "replacement underpriced")
+	case ErrLimitExceeded:
+		return e.Code == codeLimitExceeded ||
+			strings.Contains(msg, "limit exceeded") ||
+			strings.Contains(msg, "query returned more than")
+	case ErrNotFound:
+		return e.Code == codeResourceNotFound || msg == "not found"
+	}
+	return false
+}
+
+// As implements the errors.As interface. It sets a *RevertError
+// target if the jsonrpc error is of a reverted execution.
+func (e *ErrorObject) As(target interface{}) bool {
+	revertErr, ok := target.(**RevertError)
+	if !ok || !e.isReverted() {
+		return false
+	}
+	*revertErr = &RevertError{
+		Message: e.Message,
+		Data:    e.revertData(),
+	}
+	return true
+}
+
+func (e *ErrorObject) isReverted() bool {
+	if e.Code == codeExecutionReverted {
+		return true
+	}
+	msg := strings.ToLower(e.Message)
+	if strings.HasPrefix(msg, "execution reverted") || strings.HasPrefix(msg, "reverted") {
+		return true
+	}
+	// some clients only include the revert in the data
+	data, ok := e.Data.(string)
+	return ok && strings.HasPrefix(strings.ToLower(data), "reverted")
+}
+
+// revertData returns the data of a reverted execution. The data is
+// a hex string, some clients prefix it with the 'Reverted' message.
+func (e *ErrorObject) revertData() []byte {
+	str, ok := e.Data.(string)
+	if !ok {
+		return nil
+	}
+	if indx := strings.Index(str, "0x"); indx != -1 {
+		str = str[indx+2:]
+	} else {
+		return nil
+	}
+	buf, err := hex.DecodeString(str)
+	if err != nil {
+		return nil
+	}
+	return buf
+}
@@ -1,6 +1,13 @@
 package jsonrpc
 
-import "github.com/umbracle/ethgo"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/umbracle/ethgo"
+)
 
 type Debug struct {
 	c *Client
@@ -11,6 +18,11 @@ func (c *Client) Debug() *Debug {
 	return c.endpoints.d
 }
 
+// WithContext returns the debug namespace of a client that uses the context for all its requests
+func (d *Debug) WithContext(ctx context.Context) *Debug {
+	return d.c.WithContext(ctx).Debug()
+}
+
 type TraceTransactionOptions struct {
 	EnableMemory     bool                   `json:"enableMemory"`
 	DisableStack     bool                   `json:"disableStack"`
@@ -43,3 +55,311 @@ func (d *Debug) TraceTransaction(hash ethgo.Hash, opts TraceTransactionOptions)
 	err := d.c.Call("debug_traceTransaction", &res, hash, opts)
 	return res, err
 }
+
+// TraceCall returns the struct logs of the execution of a call at the given block
+func (d *Debug) TraceCall(msg *ethgo.CallMsg, block ethgo.BlockNumberOrHash, opts TraceTransactionOptions) (*TransactionTrace, error) {
+	var res *TransactionTrace
+	err := d.c.Call("debug_traceCall", &res, msg, block.Location(), opts)
+	return res, err
+}
+
+// TraceBlockByNumber returns the struct logs of the transactions of a block by block number
+func (d *Debug) TraceBlockByNumber(block ethgo.BlockNumber, opts TraceTransactionOptions) ([]*TransactionTrace, error) {
+	return traceBlock[*TransactionTrace](d, "debug_traceBlockByNumber", block.String(), opts)
+}
+
+// TraceBlockByHash returns the struct logs of the transactions of a block by hash
+func (d *Debug) TraceBlockByHash(hash ethgo.Hash, opts TraceTransactionOptions) ([]*TransactionTrace, error) {
+	return traceBlock[*TransactionTrace](d, "debug_traceBlockByHash", hash, opts)
+}
+
+// blockTraceResult is the trace of a transaction in a block
+type blockTraceResult[T any] struct {
+	TxHash *ethgo.Hash `json:"txHash"`
+	Result T           `json:"result"`
+	Error  string      `json:"error"`
+}
+
+// traceBlock traces all the transactions of a block. The results
+// are in the same order as the transactions in the block.
+func traceBlock[T any](d *Debug, method string, block interface{}, opts interface{}) ([]T, error) {
+	var out []*blockTraceResult[T]
+	if err := d.c.Call(method, &out, block, opts); err != nil {
+		return nil, err
+	}
+	res := make([]T, 0, len(out))
+	for indx, elem := range out {
+		if elem.Error != "" {
+			return nil, fmt.Errorf("failed to trace transaction %d: %s", indx, elem.Error)
+		}
+		res = append(res, elem.Result)
+	}
+	return res, nil
+}
+
+// tracerOptions are the options of the built-in tracers
+type tracerOptions struct {
+	Tracer       string      `json:"tracer"`
+	Timeout      string      `json:"timeout,omitempty"`
+	TracerConfig interface{} `json:"tracerConfig,omitempty"`
+}
+
+// CallTracerConfig is the configuration of the built-in callTracer
+type CallTracerConfig struct {
+	// OnlyTopCall does not trace the inner calls
+	OnlyTopCall bool `json:"onlyTopCall,omitempty"`
+
+	// WithLog includes the logs emitted by the calls
+	WithLog bool `json:"withLog,omitempty"`
+
+	// Timeout is the timeout of the trace (i.e. 10s)
+	Timeout string `json:"-"`
+}
+
+// CallFrame is a call in the trace of the callTracer
+type CallFrame struct {
+	// Type is the type of the call (i.e. CALL, DELEGATECALL, CREATE or SELFDESTRUCT)
+	Type    string
+	From    ethgo.Address
+	To      *ethgo.Address
+	Value   *big.Int
+	Gas     uint64
+	GasUsed uint64
+	Input   []byte
+	Output  []byte
+
+	// Error is the error of the call if it failed and RevertReason
+	// the decoded revert message if the call reverted
+	Error        string
+	RevertReason string
+
+	Calls []*CallFrame
+	Logs  []*CallLog
+}
+
+// CallLog is a log emitted by a call in the trace of the callTracer
+type CallLog struct {
+	Address ethgo.Address
+	Topics  []ethgo.Hash
+	Data    []byte
+
+	// Position is the number of inner calls of the frame made before the log
+	Position uint64
+}
+
+func (c *CallFrame) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Type         string          `json:"type"`
+		From         ethgo.Address   `json:"from"`
+		To           *ethgo.Address  `json:"to"`
+		Value        *ethgo.ArgBig   `json:"value"`
+		Gas          ethgo.ArgUint64 `json:"gas"`
+		GasUsed      ethgo.ArgUint64 `json:"gasUsed"`
+		Input        ethgo.ArgBytes  `json:"input"`
+		Output       ethgo.ArgBytes  `json:"output"`
+		Error        string          `json:"error"`
+		RevertReason string          `json:"revertReason"`
+		Calls        []*CallFrame    `json:"calls"`
+		Logs         []struct {
+			Address  ethgo.Address   `json:"address"`
+			Topics   []ethgo.Hash    `json:"topics"`
+			Data     ethgo.ArgBytes  `json:"data"`
+			Position ethgo.ArgUint64 `json:"position"`
+		} `json:"logs"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	c.Type = raw.Type
+	c.From = raw.From
+	c.To = raw.To
+	c.Value = nil
+	if raw.Value != nil {
+		c.Value = (*big.Int)(raw.Value)
+	}
+	c.Gas = raw.Gas.Uint64()
+	c.GasUsed = raw.GasUsed.Uint64()
+	c.Input = raw.Input
+	c.Output = raw.Output
+	c.Error = raw.Error
+	c.RevertReason = raw.RevertReason
+	c.Calls = raw.Calls
+	c.Logs = nil
+	for _, log := range raw.Logs {
+		c.Logs = append(c.Logs, &CallLog{
+			Address:  log.Address,
+			Topics:   log.Topics,
+			Data:     log.Data,
+			Position: log.Position.Uint64(),
+		})
+	}
+	return nil
+}
+
+// CallTracer traces the calls with the built-in callTracer
+type CallTracer struct {
+	d    *Debug
+	opts *tracerOptions
+}
+
+// CallTracer returns the tracer that traces the calls of a transaction.
+// If config is nil the default configuration is used.
+func (d *Debug) CallTracer(config *CallTracerConfig) *CallTracer {
+	if config == nil {
+		config = &CallTracerConfig{}
+	}
+	return &CallTracer{
+		d: d,
+		opts: &tracerOptions{
+			Tracer:       "callTracer",
+			Timeout:      config.Timeout,
+			TracerConfig: config,
+		},
+	}
+}
+
+// TraceTransaction returns the calls of a transaction
+func (c *CallTracer) TraceTransaction(hash ethgo.Hash) (*CallFrame, error) {
+	var res *CallFrame
+	err := c.d.c.Call("debug_traceTransaction", &res, hash, c.opts)
+	return res, err
+}
+
+// TraceCall returns the calls of the execution of a call at the given block
+func (c *CallTracer) TraceCall(msg *ethgo.CallMsg, block ethgo.BlockNumberOrHash) (*CallFrame, error) {
+	var res *CallFrame
+	err := c.d.c.Call("debug_traceCall", &res, msg, block.Location(), c.opts)
+	return res, err
+}
+
+// TraceBlockByNumber returns the calls of the transactions of a block by block number
+func (c *CallTracer) TraceBlockByNumber(block ethgo.BlockNumber) ([]*CallFrame, error) {
+	return traceBlock[*CallFrame](c.d, "debug_traceBlockByNumber", block.String(), c.opts)
+}
+
+// TraceBlockByHash returns the calls of the transactions of a block by hash
+func (c *CallTracer) TraceBlockByHash(hash ethgo.Hash) ([]*CallFrame, error) {
+	return traceBlock[*CallFrame](c.d, "debug_traceBlockByHash", hash, c.opts)
+}
+
+// PrestateTracerConfig is the configuration of the built-in prestateTracer
+type PrestateTracerConfig struct {
+	// DiffMode returns the state of the modified accounts
+	// before and after the execution
+	DiffMode bool `json:"diffMode,omitempty"`
+
+	DisableCode    bool `json:"disableCode,omitempty"`
+	DisableStorage bool `json:"disableStorage,omitempty"`
+
+	// Timeout is the timeout of the trace (i.e. 10s)
+	Timeout string `json:"-"`
+}
+
+// PrestateAccount is the state of an account in the trace of the prestateTracer.
+// Only the storage slots accessed by the execution are included.
+type PrestateAccount struct {
+	Balance *big.Int
+	Nonce   uint64
+	Code    []byte
+	Storage map[ethgo.Hash]ethgo.Hash
+}
+
+func (p *PrestateAccount) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Balance *ethgo.ArgBig             `json:"balance"`
+		Nonce   uint64                    `json:"nonce"`
+		Code    ethgo.ArgBytes            `json:"code"`
+		Storage map[ethgo.Hash]ethgo.Hash `json:"storage"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	p.Balance = nil
+	if raw.Balance != nil {
+		p.Balance = (*big.Int)(raw.Balance)
+	}
+	p.Nonce = raw.Nonce
+	p.Code = raw.Code
+	p.Storage = raw.Storage
+	return nil
+}
+
+// PrestateTrace is the result of the prestateTracer
+type PrestateTrace struct {
+	// Pre is the state of the accounts touched by the execution before it runs.
+	// In diff mode, it only includes the accounts modified by the execution.
+	Pre map[ethgo.Address]*PrestateAccount
+
+	// Post is the state of the modified accounts after the execution. It is
+	// only set in diff mode and it only includes the modified fields.
+	Post map[ethgo.Address]*PrestateAccount
+}
+
+func (p *PrestateTrace) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	_, hasPre := raw["pre"]
+	_, hasPost := raw["post"]
+	if hasPre && hasPost && len(raw) == 2 {
+		// diff mode
+		var diff struct {
+			Pre  map[ethgo.Address]*PrestateAccount `json:"pre"`
+			Post map[ethgo.Address]*PrestateAccount `json:"post"`
+		}
+		if err := json.Unmarshal(data, &diff); err != nil {
+			return err
+		}
+		p.Pre, p.Post = diff.Pre, diff.Post
+		return nil
+	}
+	p.Post = nil
+	return json.Unmarshal(data, &p.Pre)
+}
+
+// PrestateTracer traces the state touched by a transaction with the built-in prestateTracer
+type PrestateTracer struct {
+	d    *Debug
+	opts *tracerOptions
+}
+
+// PrestateTracer returns the tracer that traces the state touched by a transaction.
+// If config is nil the default configuration is used.
+func (d *Debug) PrestateTracer(config *PrestateTracerConfig) *PrestateTracer {
+	if config == nil {
+		config = &PrestateTracerConfig{}
+	}
+	return &PrestateTracer{
+		d: d,
+		opts: &tracerOptions{
+			Tracer:       "prestateTracer",
+			Timeout:      config.Timeout,
+			TracerConfig: config,
+		},
+	}
+}
+
+// TraceTransaction returns the state touched by a transaction
+func (p *PrestateTracer) TraceTransaction(hash ethgo.Hash) (*PrestateTrace, error) {
+	var res *PrestateTrace
+	err := p.d.c.Call("debug_traceTransaction", &res, hash, p.opts)
+	return res, err
+}
+
+// TraceCall returns the state touched by the execution of a call at the given block
+func (p *PrestateTracer) TraceCall(msg *ethgo.CallMsg, block ethgo.BlockNumberOrHash) (*PrestateTrace, error) {
+	var res *PrestateTrace
+	err := p.d.c.Call("debug_traceCall", &res, msg, block.Location(), p.opts)
+	return res, err
+}
+
+// TraceBlockByNumber returns the state touched by the transactions of a block by block number
+func (p *PrestateTracer) TraceBlockByNumber(block ethgo.BlockNumber) ([]*PrestateTrace, error) {
+	return traceBlock[*PrestateTrace](p.d, "debug_traceBlockByNumber", block.String(), p.opts)
+}
+
+// TraceBlockByHash returns the state touched by the transactions of a block by hash
+func (p *PrestateTracer) TraceBlockByHash(hash ethgo.Hash) ([]*PrestateTrace, error) {
+	return traceBlock[*PrestateTrace](p.d, "debug_traceBlockByHash", hash, p.opts)
+}
@@ -1,10 +1,12 @@
 package jsonrpc
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
 	"github.com/umbracle/ethgo/testutil"
 )
 
@@ -27,3 +29,108 @@ func TestDebug_TraceTransaction(t *testing.T) {
 	assert.Greater(t, trace.Gas, uint64(20000))
 	assert.NotEmpty(t, trace.StructLogs)
 }
+
+func TestDebug_CallTracer(t *testing.T) {
+	frame := `{
+		"type": "CALL",
+		"from": "0x0100000000000000000000000000000000000000",
+		"to": "0x0200000000000000000000000000000000000000",
+		"value": "0xa",
+		"gas": "0x7a120",
+		"gasUsed": "0x5208",
+		"input": "0x01",
+		"output": "0x",
+		"error": "execution reverted",
+		"revertReason": "not allowed",
+		"calls": [{
+			"type": "CREATE",
+			"from": "0x0200000000000000000000000000000000000000",
+			"to": "0x0300000000000000000000000000000000000000",
+			"gas": "0x1",
+			"gasUsed": "0x1",
+			"input": "0x",
+			"logs": [{"address": "0x0300000000000000000000000000000000000000", "topics": ["0x0100000000000000000000000000000000000000000000000000000000000000"], "data": "0x02", "position": "0x0"}]
+		}]
+	}`
+
+	c, srv := newMockClient(t, map[string]string{
+		"debug_traceTransaction":   frame,
+		"debug_traceCall":          frame,
+		"debug_traceBlockByNumber": `[{"txHash": "0x0100000000000000000000000000000000000000000000000000000000000000", "result": ` + frame + `}]`,
+		"debug_traceBlockByHash":   `[{"txHash": "0x0100000000000000000000000000000000000000000000000000000000000000", "error": "execution timeout"}]`,
+	})
+
+	tracer := c.Debug().CallTracer(&CallTracerConfig{WithLog: true, Timeout: "10s"})
+
+	res, err := tracer.TraceTransaction(ethgo.Hash{0x1})
+	assert.NoError(t, err)
+	assert.Equal(t, `["0x0100000000000000000000000000000000000000000000000000000000000000",{"tracer":"callTracer","timeout":"10s","tracerConfig":{"withLog":true}}]`, string(srv.params["debug_traceTransaction"]))
+
+	assert.Equal(t, "CALL", res.Type)
+	assert.Equal(t, ethgo.Address{0x2}, *res.To)
+	assert.Equal(t, big.NewInt(10), res.Value)
+	assert.Equal(t, uint64(500000), res.Gas)
+	assert.Equal(t, uint64(21000), res.GasUsed)
+	assert.Equal(t, []byte{0x1}, res.Input)
+	assert.Equal(t, "not allowed", res.RevertReason)
+	assert.Len(t, res.Calls, 1)
+	assert.Nil(t, res.Calls[0].Value)
+	assert.Equal(t, &CallLog{Address: ethgo.Address{0x3}, Topics: []ethgo.Hash{{0x1}}, Data: []byte{0x2}}, res.Calls[0].Logs[0])
+
+	_, err = tracer.TraceCall(&ethgo.CallMsg{To: &addr0}, ethgo.Latest)
+	assert.NoError(t, err)
+	assert.Contains(t, string(srv.params["debug_traceCall"]), `"latest",{"tracer":"callTracer"`)
+
+	frames, err := tracer.TraceBlockByNumber(ethgo.BlockNumber(1))
+	assert.NoError(t, err)
+	assert.Len(t, frames, 1)
+	assert.Equal(t, "CALL", frames[0].Type)
+
+	_, err = tracer.TraceBlockByHash(ethgo.Hash{0x1})
+	assert.Error(t, err)
+}
+
+func TestDebug_PrestateTracer(t *testing.T) {
+	c, srv := newMockClient(t, map[string]string{
+		"debug_traceTransaction": `{
+			"0x0100000000000000000000000000000000000000": {"balance": "0x10", "nonce": 1, "code": "0x6000", "storage": {"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000002"}}
+		}`,
+	})
+
+	res, err := c.Debug().PrestateTracer(nil).TraceTransaction(ethgo.Hash{0x1})
+	assert.NoError(t, err)
+	assert.Equal(t, `["0x0100000000000000000000000000000000000000000000000000000000000000",{"tracer":"prestateTracer","tracerConfig":{}}]`, string(srv.params["debug_traceTransaction"]))
+	assert.Nil(t, res.Post)
+
+	acct := res.Pre[ethgo.Address{0x1}]
+	assert.Equal(t, big.NewInt(16), acct.Balance)
+	assert.Equal(t, uint64(1), acct.Nonce)
+	assert.Equal(t, []byte{0x60, 0x0}, acct.Code)
+	assert.Equal(t, ethgo.HexToHash("0x2"), acct.Storage[ethgo.HexToHash("0x1")])
+
+	// diff mode
+	srv.results["debug_traceBlockByNumber"] = `[{"result": {
+		"pre": {"0x0100000000000000000000000000000000000000": {"balance": "0x10", "nonce": 1}},
+		"post": {"0x0100000000000000000000000000000000000000": {"balance": "0x8", "nonce": 2}}
+	}}]`
+
+	traces, err := c.Debug().PrestateTracer(&PrestateTracerConfig{DiffMode: true}).TraceBlockByNumber(ethgo.Latest)
+	assert.NoError(t, err)
+	assert.Contains(t, string(srv.params["debug_traceBlockByNumber"]), `"tracerConfig":{"diffMode":true}`)
+	assert.Len(t, traces, 1)
+	assert.Equal(t, big.NewInt(16), traces[0].Pre[ethgo.Address{0x1}].Balance)
+	assert.Equal(t, uint64(2), traces[0].Post[ethgo.Address{0x1}].Nonce)
+}
+
+func TestDebug_TraceBlock(t *testing.T) {
+	c, srv := newMockClient(t, map[string]string{
+		"debug_traceBlockByHash": `[{"result": {"gas": 21000, "returnValue": "", "structLogs": [{"op": "STOP"}]}}]`,
+	})
+
+	traces, err := c.Debug().TraceBlockByHash(ethgo.Hash{0x1}, TraceTransactionOptions{DisableStorage: true})
+	assert.NoError(t, err)
+	assert.Len(t, traces, 1)
+	assert.Equal(t, uint64(21000), traces[0].Gas)
+	assert.Equal(t, "STOP", traces[0].StructLogs[0].Op)
+	assert.Contains(t, string(srv.params["debug_traceBlockByHash"]), `"disableStorage":true`)
+}
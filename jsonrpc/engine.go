@@ -0,0 +1,349 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/umbracle/ethgo"
+)
+
+// Engine is the engine namespace used by the consensus clients to drive
+// the execution clients. The endpoint requires jwt authentication
+// (see WithJWTSecretFile).
+type Engine struct {
+	c *Client
+}
+
+// Engine returns the reference to the engine namespace
+func (c *Client) Engine() *Engine {
+	return c.endpoints.g
+}
+
+// WithContext returns the engine namespace of a client that uses the context for all its requests
+func (e *Engine) WithContext(ctx context.Context) *Engine {
+	return e.c.WithContext(ctx).Engine()
+}
+
+// PayloadID identifies a payload that is being built by the execution client
+type PayloadID [8]byte
+
+// String implements the stringer interface
+func (p PayloadID) String() string {
+	return "0x" + hex.EncodeToString(p[:])
+}
+
+// MarshalText implements the encoding.TextMarshaler interface
+func (p PayloadID) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+func (p *PayloadID) UnmarshalText(input []byte) error {
+	buf, err := parseHexBytes(string(input))
+	if err != nil {
+		return err
+	}
+	if len(buf) != len(p) {
+		return fmt.Errorf("payload id expects %d bytes but found %d", len(p), len(buf))
+	}
+	copy(p[:], buf)
+	return nil
+}
+
+// PayloadStatus is the status of a payload in the execution client
+type PayloadStatus string
+
+const (
+	PayloadStatusValid            PayloadStatus = "VALID"
+	PayloadStatusInvalid          PayloadStatus = "INVALID"
+	PayloadStatusSyncing          PayloadStatus = "SYNCING"
+	PayloadStatusAccepted         PayloadStatus = "ACCEPTED"
+	PayloadStatusInvalidBlockHash PayloadStatus = "INVALID_BLOCK_HASH"
+)
+
+// PayloadStatusV1 is the result of the validation of a payload
+type PayloadStatusV1 struct {
+	Status          PayloadStatus `json:"status"`
+	LatestValidHash *ethgo.Hash   `json:"latestValidHash"`
+	ValidationError *string       `json:"validationError"`
+}
+
+// ForkchoiceStateV1 is the head, safe and finalized blocks of the chain
+type ForkchoiceStateV1 struct {
+	HeadBlockHash      ethgo.Hash `json:"headBlockHash"`
+	SafeBlockHash      ethgo.Hash `json:"safeBlockHash"`
+	FinalizedBlockHash ethgo.Hash `json:"finalizedBlockHash"`
+}
+
+// ForkchoiceUpdatedResult is the result of the engine_forkchoiceUpdated endpoints.
+// PayloadID is set if the execution client started to build a payload.
+type ForkchoiceUpdatedResult struct {
+	PayloadStatus PayloadStatusV1 `json:"payloadStatus"`
+	PayloadID     *PayloadID      `json:"payloadId"`
+}
+
+// engineWithdrawal is the encoding of a withdrawal in the engine api
+type engineWithdrawal struct {
+	Index          ethgo.ArgUint64 `json:"index"`
+	ValidatorIndex ethgo.ArgUint64 `json:"validatorIndex"`
+	Address        ethgo.Address   `json:"address"`
+	Amount         ethgo.ArgUint64 `json:"amount"`
+}
+
+func encodeWithdrawals(withdrawals []*ethgo.Withdrawal) []*engineWithdrawal {
+	res := []*engineWithdrawal{}
+	for _, w := range withdrawals {
+		res = append(res, &engineWithdrawal{
+			Index:          ethgo.ArgUint64(w.Index),
+			ValidatorIndex: ethgo.ArgUint64(w.ValidatorIndex),
+			Address:        w.Address,
+			Amount:         ethgo.ArgUint64(w.Amount),
+		})
+	}
+	return res
+}
+
+func decodeWithdrawals(withdrawals []*engineWithdrawal) []*ethgo.Withdrawal {
+	res := []*ethgo.Withdrawal{}
+	for _, w := range withdrawals {
+		res = append(res, &ethgo.Withdrawal{
+			Index:          w.Index.Uint64(),
+			ValidatorIndex: w.ValidatorIndex.Uint64(),
+			Address:        w.Address,
+			Amount:         w.Amount.Uint64(),
+		})
+	}
+	return res
+}
+
+// PayloadAttributesV3 are the attributes of the payload the execution
+// client builds on top of the new head of the chain
+type PayloadAttributesV3 struct {
+	Timestamp             uint64
+	PrevRandao            ethgo.Hash
+	SuggestedFeeRecipient ethgo.Address
+	Withdrawals           []*ethgo.Withdrawal
+	ParentBeaconBlockRoot ethgo.Hash
+}
+
+type payloadAttributesV3 struct {
+	Timestamp             ethgo.ArgUint64     `json:"timestamp"`
+	PrevRandao            ethgo.Hash          `json:"prevRandao"`
+	SuggestedFeeRecipient ethgo.Address       `json:"suggestedFeeRecipient"`
+	Withdrawals           []*engineWithdrawal `json:"withdrawals"`
+	ParentBeaconBlockRoot ethgo.Hash          `json:"parentBeaconBlockRoot"`
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (p *PayloadAttributesV3) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&payloadAttributesV3{
+		Timestamp:             ethgo.ArgUint64(p.Timestamp),
+		PrevRandao:            p.PrevRandao,
+		SuggestedFeeRecipient: p.SuggestedFeeRecipient,
+		Withdrawals:           encodeWithdrawals(p.Withdrawals),
+		ParentBeaconBlockRoot: p.ParentBeaconBlockRoot,
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (p *PayloadAttributesV3) UnmarshalJSON(data []byte) error {
+	var raw payloadAttributesV3
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	p.Timestamp = raw.Timestamp.Uint64()
+	p.PrevRandao = raw.PrevRandao
+	p.SuggestedFeeRecipient = raw.SuggestedFeeRecipient
+	p.Withdrawals = decodeWithdrawals(raw.Withdrawals)
+	p.ParentBeaconBlockRoot = raw.ParentBeaconBlockRoot
+	return nil
+}
+
+// ExecutionPayloadV3 is the execution payload of a block since the cancun fork
+type ExecutionPayloadV3 struct {
+	ParentHash    ethgo.Hash
+	FeeRecipient  ethgo.Address
+	StateRoot     ethgo.Hash
+	ReceiptsRoot  ethgo.Hash
+	LogsBloom     []byte
+	PrevRandao    ethgo.Hash
+	BlockNumber   uint64
+	GasLimit      uint64
+	GasUsed       uint64
+	Timestamp     uint64
+	ExtraData     []byte
+	BaseFeePerGas *big.Int
+	BlockHash     ethgo.Hash
+	// Transactions are the rlp encoded transactions of the block
+	Transactions  [][]byte
+	Withdrawals   []*ethgo.Withdrawal
+	BlobGasUsed   uint64
+	ExcessBlobGas uint64
+}
+
+type executionPayloadV3 struct {
+	ParentHash    ethgo.Hash          `json:"parentHash"`
+	FeeRecipient  ethgo.Address       `json:"feeRecipient"`
+	StateRoot     ethgo.Hash          `json:"stateRoot"`
+	ReceiptsRoot  ethgo.Hash          `json:"receiptsRoot"`
+	LogsBloom     ethgo.ArgBytes      `json:"logsBloom"`
+	PrevRandao    ethgo.Hash          `json:"prevRandao"`
+	BlockNumber   ethgo.ArgUint64     `json:"blockNumber"`
+	GasLimit      ethgo.ArgUint64     `json:"gasLimit"`
+	GasUsed       ethgo.ArgUint64     `json:"gasUsed"`
+	Timestamp     ethgo.ArgUint64     `json:"timestamp"`
+	ExtraData     ethgo.ArgBytes      `json:"extraData"`
+	BaseFeePerGas *ethgo.ArgBig       `json:"baseFeePerGas"`
+	BlockHash     ethgo.Hash          `json:"blockHash"`
+	Transactions  []ethgo.ArgBytes    `json:"transactions"`
+	Withdrawals   []*engineWithdrawal `json:"withdrawals"`
+	BlobGasUsed   ethgo.ArgUint64     `json:"blobGasUsed"`
+	ExcessBlobGas ethgo.ArgUint64     `json:"excessBlobGas"`
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (p *ExecutionPayloadV3) MarshalJSON() ([]byte, error) {
+	raw := &executionPayloadV3{
+		ParentHash:    p.ParentHash,
+		FeeRecipient:  p.FeeRecipient,
+		StateRoot:     p.StateRoot,
+		ReceiptsRoot:  p.ReceiptsRoot,
+		LogsBloom:     p.LogsBloom,
+		PrevRandao:    p.PrevRandao,
+		BlockNumber:   ethgo.ArgUint64(p.BlockNumber),
+		GasLimit:      ethgo.ArgUint64(p.GasLimit),
+		GasUsed:       ethgo.ArgUint64(p.GasUsed),
+		Timestamp:     ethgo.ArgUint64(p.Timestamp),
+		ExtraData:     p.ExtraData,
+		BaseFeePerGas: (*ethgo.ArgBig)(p.BaseFeePerGas),
+		BlockHash:     p.BlockHash,
+		Transactions:  []ethgo.ArgBytes{},
+		Withdrawals:   encodeWithdrawals(p.Withdrawals),
+		BlobGasUsed:   ethgo.ArgUint64(p.BlobGasUsed),
+		ExcessBlobGas: ethgo.ArgUint64(p.ExcessBlobGas),
+	}
+	if raw.BaseFeePerGas == nil {
+		raw.BaseFeePerGas = new(ethgo.ArgBig)
+	}
+	for _, txn := range p.Transactions {
+		raw.Transactions = append(raw.Transactions, txn)
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (p *ExecutionPayloadV3) UnmarshalJSON(data []byte) error {
+	var raw executionPayloadV3
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	p.ParentHash = raw.ParentHash
+	p.FeeRecipient = raw.FeeRecipient
+	p.StateRoot = raw.StateRoot
+	p.ReceiptsRoot = raw.ReceiptsRoot
+	p.LogsBloom = raw.LogsBloom
+	p.PrevRandao = raw.PrevRandao
+	p.BlockNumber = raw.BlockNumber.Uint64()
+	p.GasLimit = raw.GasLimit.Uint64()
+	p.GasUsed = raw.GasUsed.Uint64()
+	p.Timestamp = raw.Timestamp.Uint64()
+	p.ExtraData = raw.ExtraData
+	p.BaseFeePerGas = nil
+	if raw.BaseFeePerGas != nil {
+		p.BaseFeePerGas = (*big.Int)(raw.BaseFeePerGas)
+	}
+	p.BlockHash = raw.BlockHash
+	p.Transactions = [][]byte{}
+	for _, txn := range raw.Transactions {
+		p.Transactions = append(p.Transactions, txn)
+	}
+	p.Withdrawals = decodeWithdrawals(raw.Withdrawals)
+	p.BlobGasUsed = raw.BlobGasUsed.Uint64()
+	p.ExcessBlobGas = raw.ExcessBlobGas.Uint64()
+	return nil
+}
+
+// BlobsBundleV1 are the blobs of the transactions of a payload
+type BlobsBundleV1 struct {
+	Commitments []ethgo.KZGCommitment `json:"commitments"`
+	Proofs      []ethgo.KZGProof      `json:"proofs"`
+	Blobs       []ethgo.Blob          `json:"blobs"`
+}
+
+// GetPayloadV3Response is the result of the engine_getPayloadV3 endpoint
+type GetPayloadV3Response struct {
+	ExecutionPayload      *ExecutionPayloadV3
+	BlockValue            *big.Int
+	BlobsBundle           *BlobsBundleV1
+	ShouldOverrideBuilder bool
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (g *GetPayloadV3Response) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		ExecutionPayload      *ExecutionPayloadV3 `json:"executionPayload"`
+		BlockValue            *ArgBig             `json:"blockValue"`
+		BlobsBundle           *BlobsBundleV1      `json:"blobsBundle"`
+		ShouldOverrideBuilder bool                `json:"shouldOverrideBuilder"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	g.ExecutionPayload = raw.ExecutionPayload
+	g.BlockValue = nil
+	if raw.BlockValue != nil {
+		g.BlockValue = raw.BlockValue.Big()
+	}
+	g.BlobsBundle = raw.BlobsBundle
+	g.ShouldOverrideBuilder = raw.ShouldOverrideBuilder
+	return nil
+}
+
+// ExchangeCapabilities returns the engine endpoints supported by the execution client
+// from the list of endpoints supported by the consensus client
+func (e *Engine) ExchangeCapabilities(capabilities []string) ([]string, error) {
+	if capabilities == nil {
+		capabilities = []string{}
+	}
+	var out []string
+	if err := e.c.Call("engine_exchangeCapabilities", &out, capabilities); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ForkchoiceUpdatedV3 updates the head of the chain and, if the attributes
+// are not nil, starts to build a payload on top of it
+func (e *Engine) ForkchoiceUpdatedV3(state *ForkchoiceStateV1, attributes *PayloadAttributesV3) (*ForkchoiceUpdatedResult, error) {
+	var out *ForkchoiceUpdatedResult
+	if err := e.c.Call("engine_forkchoiceUpdatedV3", &out, state, attributes); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetPayloadV3 returns the payload that is being built with the id
+func (e *Engine) GetPayloadV3(id PayloadID) (*GetPayloadV3Response, error) {
+	var out *GetPayloadV3Response
+	if err := e.c.Call("engine_getPayloadV3", &out, id); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NewPayloadV3 sends a new payload to the execution client to be validated.
+// versionedHashes are the versioned hashes of the blobs of the payload
+// in the order of the transactions.
+func (e *Engine) NewPayloadV3(payload *ExecutionPayloadV3, versionedHashes []ethgo.Hash, parentBeaconBlockRoot ethgo.Hash) (*PayloadStatusV1, error) {
+	if versionedHashes == nil {
+		versionedHashes = []ethgo.Hash{}
+	}
+	var out *PayloadStatusV1
+	if err := e.c.Call("engine_newPayloadV3", &out, payload, versionedHashes, parentBeaconBlockRoot); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
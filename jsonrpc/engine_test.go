@@ -0,0 +1,141 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc/codec"
+)
+
+// mockEngineServer replies to the engine requests with the results
+// and stores the params of the requests
+type mockEngineServer struct {
+	t       *testing.T
+	results map[string]string
+	params  map[string]json.RawMessage
+	auth    []string
+}
+
+func (m *mockEngineServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.auth = append(m.auth, r.Header.Get("Authorization"))
+
+	data, err := io.ReadAll(r.Body)
+	assert.NoError(m.t, err)
+
+	var req codec.Request
+	assert.NoError(m.t, json.Unmarshal(data, &req))
+	m.params[req.Method] = req.Params
+
+	resp := codec.Response{ID: req.ID, Result: json.RawMessage(m.results[req.Method])}
+	res, err := json.Marshal(resp)
+	assert.NoError(m.t, err)
+	w.Write(res)
+}
+
+func TestEngine(t *testing.T) {
+	payload := `{
+		"parentHash": "0x0100000000000000000000000000000000000000000000000000000000000000",
+		"feeRecipient": "0x0200000000000000000000000000000000000000",
+		"stateRoot": "0x0300000000000000000000000000000000000000000000000000000000000000",
+		"receiptsRoot": "0x0400000000000000000000000000000000000000000000000000000000000000",
+		"logsBloom": "0x00",
+		"prevRandao": "0x0500000000000000000000000000000000000000000000000000000000000000",
+		"blockNumber": "0x10",
+		"gasLimit": "0x1c9c380",
+		"gasUsed": "0x5208",
+		"timestamp": "0x64",
+		"extraData": "0x",
+		"baseFeePerGas": "0x7",
+		"blockHash": "0x0600000000000000000000000000000000000000000000000000000000000000",
+		"transactions": ["0x02f8"],
+		"withdrawals": [{"index": "0x1", "validatorIndex": "0x2", "address": "0x0300000000000000000000000000000000000000", "amount": "0x4"}],
+		"blobGasUsed": "0x20000",
+		"excessBlobGas": "0x0"
+	}`
+
+	srv := &mockEngineServer{
+		t: t,
+		results: map[string]string{
+			"engine_exchangeCapabilities": `["engine_newPayloadV3"]`,
+			"engine_forkchoiceUpdatedV3":  `{"payloadStatus": {"status": "VALID", "latestValidHash": "0x0100000000000000000000000000000000000000000000000000000000000000", "validationError": null}, "payloadId": "0x0102030405060708"}`,
+			"engine_getPayloadV3":         `{"executionPayload": ` + payload + `, "blockValue": "0x10", "blobsBundle": {"commitments": [], "proofs": [], "blobs": []}, "shouldOverrideBuilder": true}`,
+			"engine_newPayloadV3":         `{"status": "INVALID", "latestValidHash": null, "validationError": "bad block"}`,
+		},
+		params: map[string]json.RawMessage{},
+	}
+	httpSrv := httptest.NewServer(srv)
+	defer httpSrv.Close()
+
+	path := filepath.Join(t.TempDir(), "jwt.hex")
+	assert.NoError(t, os.WriteFile(path, []byte(strings.Repeat("01", 32)), 0600))
+
+	c, err := NewClient(httpSrv.URL, WithJWTSecretFile(path))
+	assert.NoError(t, err)
+
+	capabilities, err := c.Engine().ExchangeCapabilities([]string{"engine_newPayloadV3", "engine_getPayloadV3"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"engine_newPayloadV3"}, capabilities)
+
+	attrs := &PayloadAttributesV3{
+		Timestamp:   100,
+		Withdrawals: []*ethgo.Withdrawal{{Index: 1, Amount: 10}},
+	}
+	res, err := c.Engine().ForkchoiceUpdatedV3(&ForkchoiceStateV1{HeadBlockHash: ethgo.Hash{0x1}}, attrs)
+	assert.NoError(t, err)
+	assert.Equal(t, PayloadStatusValid, res.PayloadStatus.Status)
+	assert.Equal(t, ethgo.Hash{0x1}, *res.PayloadStatus.LatestValidHash)
+	assert.Equal(t, PayloadID{1, 2, 3, 4, 5, 6, 7, 8}, *res.PayloadID)
+	assert.Contains(t, string(srv.params["engine_forkchoiceUpdatedV3"]), `"timestamp":"0x64"`)
+	assert.Contains(t, string(srv.params["engine_forkchoiceUpdatedV3"]), `"amount":"0xa"`)
+
+	// no payload is built without attributes
+	_, err = c.Engine().ForkchoiceUpdatedV3(&ForkchoiceStateV1{}, nil)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(srv.params["engine_forkchoiceUpdatedV3"]), `,null]`))
+
+	getRes, err := c.Engine().GetPayloadV3(*res.PayloadID)
+	assert.NoError(t, err)
+	assert.Equal(t, `["0x0102030405060708"]`, string(srv.params["engine_getPayloadV3"]))
+	assert.Equal(t, big.NewInt(16), getRes.BlockValue)
+	assert.True(t, getRes.ShouldOverrideBuilder)
+
+	p := getRes.ExecutionPayload
+	assert.Equal(t, uint64(16), p.BlockNumber)
+	assert.Equal(t, uint64(21000), p.GasUsed)
+	assert.Equal(t, big.NewInt(7), p.BaseFeePerGas)
+	assert.Equal(t, [][]byte{{0x02, 0xf8}}, p.Transactions)
+	assert.Equal(t, &ethgo.Withdrawal{Index: 1, ValidatorIndex: 2, Address: ethgo.Address{0x3}, Amount: 4}, p.Withdrawals[0])
+	assert.Equal(t, uint64(0x20000), p.BlobGasUsed)
+
+	status, err := c.Engine().NewPayloadV3(p, nil, ethgo.Hash{0x9})
+	assert.NoError(t, err)
+	assert.Equal(t, PayloadStatusInvalid, status.Status)
+	assert.Nil(t, status.LatestValidHash)
+	assert.Equal(t, "bad block", *status.ValidationError)
+
+	// the payload is encoded back in the same format
+	var params []json.RawMessage
+	assert.NoError(t, json.Unmarshal(srv.params["engine_newPayloadV3"], &params))
+	assert.Len(t, params, 3)
+	assert.JSONEq(t, payload, string(params[0]))
+	assert.Equal(t, `[]`, string(params[1]))
+
+	// all the requests are authenticated
+	for _, auth := range srv.auth {
+		assert.True(t, strings.HasPrefix(auth, "Bearer "))
+	}
+}
+
+func TestEngine_InvalidJWTSecretFile(t *testing.T) {
+	_, err := NewClient("http://localhost:8551", WithJWTSecretFile(filepath.Join(t.TempDir(), "missing")))
+	assert.Error(t, err)
+}
@@ -0,0 +1,71 @@
+package jsonrpc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc/codec"
+)
+
+// The errors returned by the nodes can be matched with errors.Is
+// against these sentinel errors
+var (
+	ErrExecutionReverted      = codec.ErrExecutionReverted
+	ErrNonceTooLow            = codec.ErrNonceTooLow
+	ErrInsufficientFunds      = codec.ErrInsufficientFunds
+	ErrReplacementUnderpriced = codec.ErrReplacementUnderpriced
+	ErrLimitExceeded          = codec.ErrLimitExceeded
+	ErrNotFound               = codec.ErrNotFound
+)
+
+// RevertError is the error of a call or transaction that reverted. It is
+// returned by errors.As and its data can be decoded with abi.DecodeRevert.
+type RevertError = codec.RevertError
+
+// GetRevertReason executes again a mined transaction that failed and returns
+// the revert error with the data returned by the transaction. The transaction
+// is executed with the state at the end of its block, which might not be the
+// same state it found if other transactions of the block modified it.
+func (e *Eth) GetRevertReason(hash ethgo.Hash) (*RevertError, error) {
+	receipt, err := e.GetTransactionReceipt(hash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt == nil {
+		return nil, fmt.Errorf("receipt for transaction %s not found", hash)
+	}
+	if receipt.Status != 0 {
+		return nil, fmt.Errorf("transaction %s did not fail", hash)
+	}
+
+	txn, err := e.GetTransactionByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	if txn == nil {
+		return nil, fmt.Errorf("transaction %s not found", hash)
+	}
+
+	msg := &ethgo.CallMsg{
+		From:       txn.From,
+		To:         txn.To,
+		Data:       txn.Input,
+		Value:      txn.Value,
+		AccessList: txn.AccessList,
+	}
+	if txn.Gas != 0 {
+		msg.Gas = new(big.Int).SetUint64(txn.Gas)
+	}
+
+	_, err = e.Call(msg, ethgo.BlockNumber(receipt.BlockNumber))
+	if err == nil {
+		return nil, fmt.Errorf("transaction %s did not revert when executed again", hash)
+	}
+	var revertErr *RevertError
+	if errors.As(err, &revertErr) {
+		return revertErr, nil
+	}
+	return nil, err
+}
@@ -0,0 +1,117 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc/codec"
+)
+
+func TestErrors_Is(t *testing.T) {
+	cases := []struct {
+		err    *codec.ErrorObject
+		target error
+	}{
+		{&codec.ErrorObject{Code: 3, Message: "execution reverted: not allowed"}, ErrExecutionReverted},
+		{&codec.ErrorObject{Code: -32000, Message: "execution reverted"}, ErrExecutionReverted},
+		{&codec.ErrorObject{Code: -32015, Message: "Reverted"}, ErrExecutionReverted},
+		{&codec.ErrorObject{Code: -32000, Message: "nonce too low: address 0x01, tx: 1 state: 2"}, ErrNonceTooLow},
+		{&codec.ErrorObject{Code: -32000, Message: "insufficient funds for gas * price + value"}, ErrInsufficientFunds},
+		{&codec.ErrorObject{Code: -32000, Message: "replacement transaction underpriced"}, ErrReplacementUnderpriced},
+		{&codec.ErrorObject{Code: -32005, Message: "query returned more than 10000 results"}, ErrLimitExceeded},
+		{&codec.ErrorObject{Code: -32600, Message: "block range limit exceeded"}, ErrLimitExceeded},
+		{&codec.ErrorObject{Code: -32000, Message: "not found"}, ErrNotFound},
+		{&codec.ErrorObject{Code: -32001, Message: "resource not found"}, ErrNotFound},
+		{&codec.ErrorObject{Code: -32000, Message: "header not found"}, nil},
+	}
+
+	sentinels := []error{
+		ErrExecutionReverted,
+		ErrNonceTooLow,
+		ErrInsufficientFunds,
+		ErrReplacementUnderpriced,
+		ErrLimitExceeded,
+		ErrNotFound,
+	}
+	for _, c := range cases {
+		// the error can be wrapped
+		err := fmt.Errorf("failed: %w", c.err)
+
+		for _, sentinel := range sentinels {
+			assert.Equal(t, sentinel == c.target, errors.Is(err, sentinel), c.err.Message)
+		}
+	}
+}
+
+func TestErrors_RevertData(t *testing.T) {
+	c, srv := newMockClient(t, map[string]string{})
+	srv.errors["eth_call"] = &codec.ErrorObject{
+		Code:    3,
+		Message: "execution reverted: revert reason",
+		Data:    "0x08c379a0",
+	}
+
+	_, err := c.Eth().Call(&ethgo.CallMsg{To: &addr0}, ethgo.Latest)
+	assert.ErrorIs(t, err, ErrExecutionReverted)
+
+	var revertErr *RevertError
+	assert.ErrorAs(t, err, &revertErr)
+	assert.Equal(t, []byte{0x08, 0xc3, 0x79, 0xa0}, revertErr.Data)
+	assert.Equal(t, "execution reverted: revert reason", revertErr.Error())
+	assert.ErrorIs(t, revertErr, ErrExecutionReverted)
+
+	// the jsonrpc error is still available
+	var errObj *codec.ErrorObject
+	assert.ErrorAs(t, err, &errObj)
+	assert.Equal(t, 3, errObj.Code)
+
+	// other errors are not reverts
+	srv.errors["eth_call"] = &codec.ErrorObject{Code: -32000, Message: "nonce too low"}
+
+	_, err = c.Eth().Call(&ethgo.CallMsg{To: &addr0}, ethgo.Latest)
+	assert.False(t, errors.As(err, &revertErr))
+
+	// nethermind adds the data to the message
+	srv.errors["eth_call"] = &codec.ErrorObject{Code: -32015, Message: "VM execution error.", Data: "Reverted 0x0102"}
+
+	_, err = c.Eth().Call(&ethgo.CallMsg{To: &addr0}, ethgo.Latest)
+	assert.ErrorAs(t, err, &revertErr)
+	assert.Equal(t, []byte{0x1, 0x2}, revertErr.Data)
+}
+
+func TestErrors_GetRevertReason(t *testing.T) {
+	var receipts []json.RawMessage
+	assert.NoError(t, json.Unmarshal([]byte(readTestsuite(t, "receipts.json")), &receipts))
+
+	receipt := string(receipts[1])
+	failedReceipt := strings.Replace(receipt, `"status": "0x1"`, `"status": "0x0"`, 1)
+
+	c, srv := newMockClient(t, map[string]string{
+		"eth_getTransactionReceipt": failedReceipt,
+		"eth_getTransactionByHash":  readTestsuite(t, "transaction-call.json"),
+	})
+	srv.errors["eth_call"] = &codec.ErrorObject{Code: 3, Message: "execution reverted", Data: "0x0102"}
+
+	revertErr, err := c.Eth().GetRevertReason(ethgo.Hash{0x1})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x1, 0x2}, revertErr.Data)
+	assert.Contains(t, string(srv.params["eth_call"]), `"0xee76d0"]`)
+
+	// the transaction does not revert again
+	delete(srv.errors, "eth_call")
+	srv.results["eth_call"] = `"0x"`
+
+	_, err = c.Eth().GetRevertReason(ethgo.Hash{0x1})
+	assert.Error(t, err)
+
+	// the transaction did not fail
+	srv.results["eth_getTransactionReceipt"] = receipt
+
+	_, err = c.Eth().GetRevertReason(ethgo.Hash{0x1})
+	assert.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package jsonrpc
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -19,6 +20,11 @@ func (c *Client) Eth() *Eth {
 	return c.endpoints.e
 }
 
+// WithContext returns the eth namespace of a client that uses the context for all its requests
+func (e *Eth) WithContext(ctx context.Context) *Eth {
+	return e.c.WithContext(ctx).Eth()
+}
+
 // GetCode returns the code of a contract
 func (e *Eth) GetCode(addr ethgo.Address, block ethgo.BlockNumberOrHash) (string, error) {
 	var res string
@@ -44,6 +50,18 @@ func (e *Eth) GetStorageAt(addr ethgo.Address, slot ethgo.Hash, block ethgo.Bloc
 	return hash, err
 }
 
+// GetProof returns the merkle proof of an account and some of its storage slots
+func (e *Eth) GetProof(addr ethgo.Address, slots []ethgo.Hash, block ethgo.BlockNumberOrHash) (*ethgo.AccountProof, error) {
+	if slots == nil {
+		slots = []ethgo.Hash{}
+	}
+	var proof *ethgo.AccountProof
+	if err := e.c.Call("eth_getProof", &proof, addr, slots, block.Location()); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
 // BlockNumber returns the number of most recent block.
 func (e *Eth) BlockNumber() (uint64, error) {
 	var out string
@@ -71,6 +89,43 @@ func (e *Eth) GetBlockByHash(hash ethgo.Hash, full bool) (*ethgo.Block, error) {
 	return b, nil
 }
 
+// errBlockNotFound is returned by the endpoints that return null for an unknown block.
+// It matches ErrNotFound with errors.Is
+var errBlockNotFound = fmt.Errorf("block %w", ErrNotFound)
+
+// GetBlockTransactionCountByNumber returns the number of transactions in a block by block number.
+func (e *Eth) GetBlockTransactionCountByNumber(i ethgo.BlockNumber) (uint64, error) {
+	var out *ethgo.ArgUint64
+	if err := e.c.Call("eth_getBlockTransactionCountByNumber", &out, i.String()); err != nil {
+		return 0, err
+	}
+	if out == nil {
+		return 0, errBlockNotFound
+	}
+	return out.Uint64(), nil
+}
+
+// GetBlockTransactionCountByHash returns the number of transactions in a block by hash.
+func (e *Eth) GetBlockTransactionCountByHash(hash ethgo.Hash) (uint64, error) {
+	var out *ethgo.ArgUint64
+	if err := e.c.Call("eth_getBlockTransactionCountByHash", &out, hash); err != nil {
+		return 0, err
+	}
+	if out == nil {
+		return 0, errBlockNotFound
+	}
+	return out.Uint64(), nil
+}
+
+// GetUncleByBlockHashAndIndex returns information about an uncle of a block by hash and uncle index.
+func (e *Eth) GetUncleByBlockHashAndIndex(hash ethgo.Hash, index uint64) (*ethgo.Block, error) {
+	var b *ethgo.Block
+	if err := e.c.Call("eth_getUncleByBlockHashAndIndex", &b, hash, encodeUintToHex(index)); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
 // GetFilterChanges returns the filter changes for log filters
 func (e *Eth) GetFilterChanges(id string) ([]*ethgo.Log, error) {
 	var logs []*ethgo.Log
@@ -87,6 +142,20 @@ func (e *Eth) GetTransactionByHash(hash ethgo.Hash) (*ethgo.Transaction, error)
 	return txn, err
 }
 
+// GetTransactionByBlockNumberAndIndex returns a transaction by block number and transaction index.
+func (e *Eth) GetTransactionByBlockNumberAndIndex(i ethgo.BlockNumber, index uint64) (*ethgo.Transaction, error) {
+	var txn *ethgo.Transaction
+	err := e.c.Call("eth_getTransactionByBlockNumberAndIndex", &txn, i.String(), encodeUintToHex(index))
+	return txn, err
+}
+
+// GetTransactionByBlockHashAndIndex returns a transaction by block hash and transaction index.
+func (e *Eth) GetTransactionByBlockHashAndIndex(hash ethgo.Hash, index uint64) (*ethgo.Transaction, error) {
+	var txn *ethgo.Transaction
+	err := e.c.Call("eth_getTransactionByBlockHashAndIndex", &txn, hash, encodeUintToHex(index))
+	return txn, err
+}
+
 // GetFilterChangesBlock returns the filter changes for block filters
 func (e *Eth) GetFilterChangesBlock(id string) ([]ethgo.Hash, error) {
 	var hashes []ethgo.Hash
@@ -139,6 +208,15 @@ func (e *Eth) GetTransactionReceipt(hash ethgo.Hash) (*ethgo.Receipt, error) {
 	return receipt, err
 }
 
+// GetBlockReceipts returns the receipts of all the transactions in a block.
+func (e *Eth) GetBlockReceipts(block ethgo.BlockNumberOrHash) ([]*ethgo.Receipt, error) {
+	var receipts []*ethgo.Receipt
+	if err := e.c.Call("eth_getBlockReceipts", &receipts, block.Location()); err != nil {
+		return nil, err
+	}
+	return receipts, nil
+}
+
 // GetNonce returns the nonce of the account
 func (e *Eth) GetNonce(addr ethgo.Address, blockNumber ethgo.BlockNumberOrHash) (uint64, error) {
 	var nonce string
@@ -148,6 +226,11 @@ func (e *Eth) GetNonce(addr ethgo.Address, blockNumber ethgo.BlockNumberOrHash)
 	return parseUint64orHex(nonce)
 }
 
+// GetPendingNonce returns the nonce of the account including the transactions in the pool
+func (e *Eth) GetPendingNonce(addr ethgo.Address) (uint64, error) {
+	return e.GetNonce(addr, ethgo.Pending)
+}
+
 // GetBalance returns the balance of the account of given address.
 func (e *Eth) GetBalance(addr ethgo.Address, blockNumber ethgo.BlockNumberOrHash) (*big.Int, error) {
 	var out string
@@ -206,6 +289,62 @@ func (e *Eth) EstimateGas(msg *ethgo.CallMsg) (uint64, error) {
 	return parseUint64orHex(out)
 }
 
+// EstimateGasAt estimates the gas of the transaction at the given block with an optional state override.
+// At most one state override can be passed.
+func (e *Eth) EstimateGasAt(msg *ethgo.CallMsg, block ethgo.BlockNumberOrHash, override ...*ethgo.StateOverride) (uint64, error) {
+	if len(override) > 1 {
+		return 0, fmt.Errorf("expected at most one state override but %d were passed", len(override))
+	}
+	var out string
+	if len(override) == 1 && override[0] != nil {
+		if err := e.c.Call("eth_estimateGas", &out, msg, block.Location(), override[0]); err != nil {
+			return 0, err
+		}
+	} else {
+		if err := e.c.Call("eth_estimateGas", &out, msg, block.Location()); err != nil {
+			return 0, err
+		}
+	}
+	return parseUint64orHex(out)
+}
+
+// AccessListResult is the result of the eth_createAccessList endpoint
+type AccessListResult struct {
+	AccessList ethgo.AccessList
+	GasUsed    uint64
+
+	// Error is the error of the execution of the transaction, if any
+	Error string
+}
+
+func (a *AccessListResult) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		AccessList ethgo.AccessList `json:"accessList"`
+		GasUsed    ethgo.ArgUint64  `json:"gasUsed"`
+		Error      string           `json:"error,omitempty"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	a.AccessList = raw.AccessList
+	if a.AccessList == nil {
+		a.AccessList = ethgo.AccessList{}
+	}
+	a.GasUsed = raw.GasUsed.Uint64()
+	a.Error = raw.Error
+	return nil
+}
+
+// CreateAccessList returns the access list of the transaction (EIP-2930) at the given block
+// and the gas used by the transaction with the access list.
+func (e *Eth) CreateAccessList(msg *ethgo.CallMsg, block ethgo.BlockNumberOrHash) (*AccessListResult, error) {
+	var out *AccessListResult
+	if err := e.c.Call("eth_createAccessList", &out, msg, block.Location()); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GetLogs returns an array of all logs matching a given filter object
 func (e *Eth) GetLogs(filter *ethgo.LogFilter) ([]*ethgo.Log, error) {
 	var out []*ethgo.Log
@@ -284,3 +423,50 @@ func (e *Eth) MaxPriorityFeePerGas() (*big.Int, error) {
 
 	return parseBigInt(out), nil
 }
+
+// BlobBaseFee returns the base fee per blob gas in wei (EIP-4844).
+func (e *Eth) BlobBaseFee() (*big.Int, error) {
+	var out string
+	if err := e.c.Call("eth_blobBaseFee", &out); err != nil {
+		return nil, err
+	}
+	return parseBigInt(out), nil
+}
+
+// SyncProgress is the progress of the synchronization of the node
+type SyncProgress struct {
+	StartingBlock uint64
+	CurrentBlock  uint64
+	HighestBlock  uint64
+}
+
+func (s *SyncProgress) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		StartingBlock ethgo.ArgUint64 `json:"startingBlock"`
+		CurrentBlock  ethgo.ArgUint64 `json:"currentBlock"`
+		HighestBlock  ethgo.ArgUint64 `json:"highestBlock"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	s.StartingBlock = raw.StartingBlock.Uint64()
+	s.CurrentBlock = raw.CurrentBlock.Uint64()
+	s.HighestBlock = raw.HighestBlock.Uint64()
+	return nil
+}
+
+// Syncing returns the progress of the synchronization or nil if the node is not syncing.
+func (e *Eth) Syncing() (*SyncProgress, error) {
+	var out json.RawMessage
+	if err := e.c.Call("eth_syncing", &out); err != nil {
+		return nil, err
+	}
+	if string(out) == "false" {
+		return nil, nil
+	}
+	var progress *SyncProgress
+	if err := json.Unmarshal(out, &progress); err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
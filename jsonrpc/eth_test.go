@@ -3,7 +3,10 @@ package jsonrpc
 import (
 	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"math/big"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -12,6 +15,7 @@ import (
 	"github.com/umbracle/ethgo"
 	"github.com/umbracle/ethgo/abi"
 	"github.com/umbracle/ethgo/testutil"
+	"github.com/umbracle/ethgo/trie"
 )
 
 var (
@@ -388,6 +392,47 @@ func TestEthGetStorageAt(t *testing.T) {
 	}
 }
 
+func TestEthGetProof(t *testing.T) {
+	s := testutil.NewTestServer(t)
+
+	c, _ := NewClient(s.HTTPAddr())
+
+	cc := &testutil.Contract{}
+	cc.AddCallback(func() string {
+		return "uint256 val;"
+	})
+	cc.AddCallback(func() string {
+		return `function setValue() public payable {
+			val = 10;
+		}`
+	})
+
+	_, addr, err := s.DeployContract(cc)
+	require.NoError(t, err)
+
+	receipt, err := s.TxnTo(addr, "setValue")
+	require.NoError(t, err)
+
+	block, err := c.Eth().GetBlockByHash(receipt.BlockHash, false)
+	require.NoError(t, err)
+
+	slots := []ethgo.Hash{{}, {0x1}}
+	proof, err := c.Eth().GetProof(addr, slots, receipt.BlockHash)
+	require.NoError(t, err)
+
+	require.Equal(t, addr, proof.Address)
+	require.Len(t, proof.StorageProof, 2)
+	require.Equal(t, big.NewInt(10), proof.StorageProof[0].Value)
+	require.Equal(t, int64(0), proof.StorageProof[1].Value.Int64())
+	require.NoError(t, trie.VerifyAccountProof(block.StateRoot, proof))
+
+	// account not in the state
+	proof, err = c.Eth().GetProof(ethgo.Address{0x99}, nil, ethgo.BlockNumber(receipt.BlockNumber))
+	require.NoError(t, err)
+	require.Zero(t, proof.Nonce)
+	require.NoError(t, trie.VerifyAccountProof(block.StateRoot, proof))
+}
+
 func TestEthFeeHistory(t *testing.T) {
 	c, _ := NewClient(testutil.TestInfuraEndpoint(t))
 
@@ -437,3 +482,154 @@ func TestEthMaxPriorityFeePerGas(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, initialMaxPriorityFee.Cmp(newMaxPriorityFee) <= 0)
 }
+
+// readTestsuite reads a fixture from the testsuite folder
+func readTestsuite(t *testing.T, name string) string {
+	data, err := os.ReadFile(filepath.Join("..", "testsuite", name))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestEthGetBlockReceipts(t *testing.T) {
+	c, srv := newMockClient(t, map[string]string{
+		"eth_getBlockReceipts": readTestsuite(t, "receipts.json"),
+	})
+
+	receipts, err := c.Eth().GetBlockReceipts(ethgo.Latest)
+	assert.NoError(t, err)
+	assert.Len(t, receipts, 3)
+	assert.Equal(t, uint64(0x10482a), receipts[0].BlockNumber)
+	assert.Equal(t, `["latest"]`, string(srv.params["eth_getBlockReceipts"]))
+
+	_, err = c.Eth().GetBlockReceipts(ethgo.Hash{0x1})
+	assert.NoError(t, err)
+	assert.Equal(t, `["0x0100000000000000000000000000000000000000000000000000000000000000"]`, string(srv.params["eth_getBlockReceipts"]))
+}
+
+func TestEthGetTransactionByBlockAndIndex(t *testing.T) {
+	c, srv := newMockClient(t, map[string]string{
+		"eth_getTransactionByBlockNumberAndIndex": readTestsuite(t, "transaction-eip1159.json"),
+		"eth_getTransactionByBlockHashAndIndex":   "null",
+	})
+
+	txn, err := c.Eth().GetTransactionByBlockNumberAndIndex(ethgo.BlockNumber(10), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, ethgo.TransactionDynamicFee, txn.Type)
+	assert.Equal(t, `["0xa","0x2"]`, string(srv.params["eth_getTransactionByBlockNumberAndIndex"]))
+
+	txn, err = c.Eth().GetTransactionByBlockHashAndIndex(ethgo.Hash{0x1}, 0)
+	assert.NoError(t, err)
+	assert.Nil(t, txn)
+}
+
+func TestEthGetBlockTransactionCount(t *testing.T) {
+	c, _ := newMockClient(t, map[string]string{
+		"eth_getBlockTransactionCountByNumber": `"0x10"`,
+		"eth_getBlockTransactionCountByHash":   "null",
+	})
+
+	num, err := c.Eth().GetBlockTransactionCountByNumber(ethgo.Latest)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(16), num)
+
+	_, err = c.Eth().GetBlockTransactionCountByHash(ethgo.Hash{0x1})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestEthGetUncleByBlockHashAndIndex(t *testing.T) {
+	c, srv := newMockClient(t, map[string]string{
+		"eth_getUncleByBlockHashAndIndex": readTestsuite(t, "header-london.json"),
+	})
+
+	uncle, err := c.Eth().GetUncleByBlockHashAndIndex(ethgo.Hash{0x1}, 1)
+	assert.NoError(t, err)
+	assert.NotNil(t, uncle.BaseFee)
+	assert.Contains(t, string(srv.params["eth_getUncleByBlockHashAndIndex"]), `"0x1"]`)
+}
+
+func TestEthCreateAccessList(t *testing.T) {
+	c, srv := newMockClient(t, map[string]string{
+		"eth_createAccessList": `{
+			"accessList": [{"address": "0x0100000000000000000000000000000000000000", "storageKeys": ["0x0200000000000000000000000000000000000000000000000000000000000000"]}],
+			"gasUsed": "0x5208"
+		}`,
+	})
+
+	to := ethgo.Address{0x1}
+	res, err := c.Eth().CreateAccessList(&ethgo.CallMsg{To: &to}, ethgo.Pending)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(21000), res.GasUsed)
+	assert.Empty(t, res.Error)
+	assert.Equal(t, ethgo.AccessList{{Address: ethgo.Address{0x1}, Storage: []ethgo.Hash{{0x2}}}}, res.AccessList)
+	assert.Contains(t, string(srv.params["eth_createAccessList"]), `"pending"]`)
+}
+
+func TestEthSyncing(t *testing.T) {
+	c, srv := newMockClient(t, map[string]string{
+		"eth_syncing": "false",
+	})
+
+	progress, err := c.Eth().Syncing()
+	assert.NoError(t, err)
+	assert.Nil(t, progress)
+
+	srv.results["eth_syncing"] = `{"startingBlock": "0x1", "currentBlock": "0x2", "highestBlock": "0x3"}`
+
+	progress, err = c.Eth().Syncing()
+	assert.NoError(t, err)
+	assert.Equal(t, &SyncProgress{StartingBlock: 1, CurrentBlock: 2, HighestBlock: 3}, progress)
+}
+
+func TestEthGetPendingNonce(t *testing.T) {
+	c, srv := newMockClient(t, map[string]string{
+		"eth_getTransactionCount": `"0x5"`,
+	})
+
+	nonce, err := c.Eth().GetPendingNonce(addr0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), nonce)
+	assert.Contains(t, string(srv.params["eth_getTransactionCount"]), `"pending"]`)
+}
+
+func TestEthBlobBaseFee(t *testing.T) {
+	c, _ := newMockClient(t, map[string]string{
+		"eth_blobBaseFee": `"0x3b9aca00"`,
+	})
+
+	fee, err := c.Eth().BlobBaseFee()
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(1000000000), fee)
+}
+
+func TestEthEstimateGasAt(t *testing.T) {
+	c, srv := newMockClient(t, map[string]string{
+		"eth_estimateGas": `"0x5208"`,
+	})
+
+	msg := &ethgo.CallMsg{
+		To:         &addr1,
+		AccessList: ethgo.AccessList{{Address: addr1, Storage: []ethgo.Hash{}}},
+	}
+	gas, err := c.Eth().EstimateGasAt(msg, ethgo.BlockNumber(1))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(21000), gas)
+	assert.Contains(t, string(srv.params["eth_estimateGas"]), `"accessList":[{"address":"0x0200000000000000000000000000000000000000","storageKeys":[]}]`)
+	assert.Contains(t, string(srv.params["eth_estimateGas"]), `"0x1"]`)
+
+	nonce := uint64(1)
+	override := &ethgo.StateOverride{
+		addr0: ethgo.OverrideAccount{Nonce: &nonce},
+	}
+	_, err = c.Eth().EstimateGasAt(msg, ethgo.Latest, override)
+	assert.NoError(t, err)
+
+	var params []json.RawMessage
+	assert.NoError(t, json.Unmarshal(srv.params["eth_estimateGas"], &params))
+	assert.Len(t, params, 3)
+	assert.Equal(t, `"latest"`, string(params[1]))
+	assert.Contains(t, string(params[2]), `"nonce":"0x1"`)
+
+	// the extra overrides are not dropped
+	_, err = c.Eth().EstimateGasAt(msg, ethgo.Latest, override, override)
+	assert.Error(t, err)
+}
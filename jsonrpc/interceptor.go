@@ -0,0 +1,185 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/umbracle/ethgo/jsonrpc/codec"
+	"github.com/umbracle/ethgo/jsonrpc/transport"
+)
+
+// Request is a jsonrpc call seen by the interceptors
+type Request struct {
+	// Method is the jsonrpc method
+	Method string
+
+	// Params are the arguments of the method
+	Params []interface{}
+
+	// Result is the object where the result is decoded
+	Result interface{}
+
+	// ResponseSize is the size in bytes of the result. It is
+	// set once the call returns.
+	ResponseSize int
+}
+
+// Handler makes a jsonrpc call
+type Handler func(ctx context.Context, req *Request) error
+
+// Interceptor wraps a jsonrpc call. It must call next to continue with the call.
+type Interceptor func(ctx context.Context, req *Request, next Handler) error
+
+// WithInterceptors adds interceptors around the calls of the client. The first
+// interceptor is the outermost one. Batch requests are not intercepted.
+func WithInterceptors(interceptors ...Interceptor) ConfigOption {
+	return func(c *Config) {
+		c.interceptors = append(c.interceptors, interceptors...)
+	}
+}
+
+// chainInterceptors returns the handler that calls the interceptors in order
+func chainInterceptors(interceptors []Interceptor, handler Handler) Handler {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], handler
+		handler = func(ctx context.Context, req *Request) error {
+			return interceptor(ctx, req, next)
+		}
+	}
+	return handler
+}
+
+// transportHandler is the last handler of the chain that sends the
+// request with the transport and records the size of the response
+func (c *Client) transportHandler(ctx context.Context, req *Request) error {
+	var raw json.RawMessage
+	if err := transport.CallContext(ctx, c.transport, req.Method, &raw, req.Params...); err != nil {
+		return err
+	}
+	req.ResponseSize = len(raw)
+	return json.Unmarshal(raw, req.Result)
+}
+
+// NewLoggingInterceptor logs the jsonrpc calls. Successful calls are
+// logged with debug level and failed calls with warn level.
+func NewLoggingInterceptor(logger *slog.Logger) Interceptor {
+	return func(ctx context.Context, req *Request, next Handler) error {
+		now := time.Now()
+		err := next(ctx, req)
+
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.Duration("duration", time.Since(now)),
+			slog.Int("size", req.ResponseSize),
+		}
+		if err != nil {
+			attrs = append(attrs, slog.Any("params", req.Params), slog.String("err", err.Error()))
+			logger.LogAttrs(ctx, slog.LevelWarn, "jsonrpc call failed", attrs...)
+		} else {
+			logger.LogAttrs(ctx, slog.LevelDebug, "jsonrpc call", attrs...)
+		}
+		return err
+	}
+}
+
+// Metrics is a sink of prometheus style metrics. The labels
+// are a list of key and value pairs.
+type Metrics interface {
+	// IncCounter increases a counter by the value
+	IncCounter(name string, value float64, labels ...string)
+
+	// SetGauge sets the value of a gauge
+	SetGauge(name string, value float64, labels ...string)
+
+	// ObserveHistogram adds an observation to a histogram
+	ObserveHistogram(name string, value float64, labels ...string)
+}
+
+// NoopMetrics is a Metrics implementation that discards the metrics
+type NoopMetrics struct{}
+
+// IncCounter implements the Metrics interface
+func (NoopMetrics) IncCounter(name string, value float64, labels ...string) {}
+
+// SetGauge implements the Metrics interface
+func (NoopMetrics) SetGauge(name string, value float64, labels ...string) {}
+
+// ObserveHistogram implements the Metrics interface
+func (NoopMetrics) ObserveHistogram(name string, value float64, labels ...string) {}
+
+const (
+	// MetricRequests is the counter of jsonrpc calls by method and status
+	MetricRequests = "jsonrpc_requests_total"
+
+	// MetricRequestDuration is the histogram of the duration in seconds of the jsonrpc calls by method
+	MetricRequestDuration = "jsonrpc_request_duration_seconds"
+
+	// MetricResponseSize is the histogram of the size in bytes of the jsonrpc results by method
+	MetricResponseSize = "jsonrpc_response_size_bytes"
+)
+
+// NewMetricsInterceptor reports the number, the duration and the
+// size of the response of the jsonrpc calls
+func NewMetricsInterceptor(metrics Metrics) Interceptor {
+	return func(ctx context.Context, req *Request, next Handler) error {
+		now := time.Now()
+		err := next(ctx, req)
+
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+		metrics.IncCounter(MetricRequests, 1, "method", req.Method, "status", status)
+		metrics.ObserveHistogram(MetricRequestDuration, time.Since(now).Seconds(), "method", req.Method)
+		if err == nil {
+			metrics.ObserveHistogram(MetricResponseSize, float64(req.ResponseSize), "method", req.Method)
+		}
+		return err
+	}
+}
+
+// Tracer starts opentelemetry style spans
+type Tracer interface {
+	// Start starts a span and returns a context that includes it
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a traced operation
+type Span interface {
+	// SetAttribute sets an attribute of the span
+	SetAttribute(key string, value interface{})
+
+	// RecordError records an error of the operation
+	RecordError(err error)
+
+	// End completes the span
+	End()
+}
+
+// NewTracingInterceptor starts a span for each jsonrpc call with
+// the attributes of the opentelemetry rpc semantic conventions
+func NewTracingInterceptor(tracer Tracer) Interceptor {
+	return func(ctx context.Context, req *Request, next Handler) error {
+		ctx, span := tracer.Start(ctx, req.Method)
+		defer span.End()
+
+		span.SetAttribute("rpc.system", "jsonrpc")
+		span.SetAttribute("rpc.method", req.Method)
+
+		err := next(ctx, req)
+		if err != nil {
+			var errObj *codec.ErrorObject
+			if errors.As(err, &errObj) {
+				span.SetAttribute("rpc.jsonrpc.error_code", errObj.Code)
+				span.SetAttribute("rpc.jsonrpc.error_message", errObj.Message)
+			}
+			span.RecordError(err)
+		} else {
+			span.SetAttribute("rpc.response.size", req.ResponseSize)
+		}
+		return err
+	}
+}
@@ -1,5 +1,7 @@
 package jsonrpc
 
+import "context"
+
 // Net is the net namespace
 type Net struct {
 	c *Client
@@ -10,6 +12,11 @@ func (c *Client) Net() *Net {
 	return c.endpoints.n
 }
 
+// WithContext returns the net namespace of a client that uses the context for all its requests
+func (n *Net) WithContext(ctx context.Context) *Net {
+	return n.c.WithContext(ctx).Net()
+}
+
 // Version returns the current network id
 func (n *Net) Version() (uint64, error) {
 	var out string
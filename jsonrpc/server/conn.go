@@ -0,0 +1,111 @@
+package server
+
+import (
+	"context"
+	"sync"
+)
+
+// connCodec reads and writes the messages of a connection with notifications
+type connCodec interface {
+	Read() ([]byte, error)
+	Write([]byte) error
+	Close() error
+}
+
+// connection is a websocket or ipc connection with the client
+type connection struct {
+	srv   *Server
+	codec connCodec
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	writeLock sync.Mutex
+
+	subsLock sync.Mutex
+	subs     map[string]*Subscription
+}
+
+// serveCodec serves the requests of the connection until it is closed
+func (s *Server) serveCodec(codec connCodec) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c := &connection{
+		srv:    s,
+		codec:  codec,
+		ctx:    ctx,
+		cancel: cancel,
+		subs:   map[string]*Subscription{},
+	}
+	if !s.trackConn(c) {
+		c.close()
+		return
+	}
+	defer func() {
+		s.untrackConn(c)
+		c.close()
+	}()
+
+	for {
+		msg, err := codec.Read()
+		if err != nil {
+			return
+		}
+
+		go func() {
+			resp, notifiers := s.handle(ctx, c, msg)
+			if resp == nil {
+				return
+			}
+			if err := c.write(resp); err != nil {
+				s.logger.Debug("failed to write response", "err", err)
+				return
+			}
+			for _, notifier := range notifiers {
+				if err := notifier.activate(); err != nil {
+					s.logger.Debug("failed to write notification", "err", err)
+				}
+			}
+		}()
+	}
+}
+
+func (c *connection) write(msg []byte) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	return c.codec.Write(msg)
+}
+
+func (c *connection) addSubscription(sub *Subscription) {
+	c.subsLock.Lock()
+	defer c.subsLock.Unlock()
+
+	c.subs[sub.ID] = sub
+}
+
+func (c *connection) removeSubscription(id string) bool {
+	c.subsLock.Lock()
+	sub, ok := c.subs[id]
+	delete(c.subs, id)
+	c.subsLock.Unlock()
+
+	if ok {
+		sub.close()
+	}
+	return ok
+}
+
+// close closes the connection and ends its subscriptions
+func (c *connection) close() {
+	c.cancel()
+	c.codec.Close()
+
+	c.subsLock.Lock()
+	defer c.subsLock.Unlock()
+
+	for id, sub := range c.subs {
+		sub.close()
+		delete(c.subs, id)
+	}
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+// ServeHTTP implements the http.Handler interface. The requests with
+// a websocket upgrade are served as websocket connections.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if websocket.IsWebSocketUpgrade(r) {
+		s.serveWebsocket(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, s.config.MaxRequestSize+1))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if int64(len(body)) > s.config.MaxRequestSize {
+		http.Error(w, "request too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	resp, _ := s.handle(r.Context(), nil, body)
+
+	w.Header().Set("Content-Type", "application/json")
+	if resp != nil {
+		w.Write(resp)
+	}
+}
+
+// checkOrigin accepts the websocket requests without an origin (i.e. not
+// from a browser), from the same origin or from one of the allowed origins
+func (s *Server) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range s.config.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, r.Host)
+}
+
+func (s *Server) serveWebsocket(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     s.checkOrigin,
+	}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.logger.Debug("failed to upgrade websocket connection", "err", err)
+		return
+	}
+	conn.SetReadLimit(s.config.MaxRequestSize)
+
+	s.serveCodec(&websocketCodec{conn: conn})
+}
+
+// ServeHTTPListener serves the http and websocket requests of the listener
+// until the server is stopped
+func (s *Server) ServeHTTPListener(l net.Listener) error {
+	srv := &http.Server{Handler: s}
+	if !s.trackListener(srv) {
+		return net.ErrClosed
+	}
+	defer s.untrackListener(srv)
+
+	if err := srv.Serve(l); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+type websocketCodec struct {
+	conn *websocket.Conn
+}
+
+func (w *websocketCodec) Read() ([]byte, error) {
+	_, msg, err := w.conn.ReadMessage()
+	return msg, err
+}
+
+func (w *websocketCodec) Write(b []byte) error {
+	return w.conn.WriteMessage(websocket.TextMessage, b)
+}
+
+func (w *websocketCodec) Close() error {
+	return w.conn.Close()
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+)
+
+// ServeIPC serves the connections of the listener (i.e. a unix
+// socket) until the server is stopped. The connections that send
+// a request larger than MaxRequestSize are closed.
+func (s *Server) ServeIPC(l net.Listener) error {
+	if !s.trackListener(l) {
+		return net.ErrClosed
+	}
+	defer s.untrackListener(l)
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go s.serveCodec(newIPCCodec(conn, s.config.MaxRequestSize))
+	}
+}
+
+func (s *Server) trackListener(l io.Closer) bool {
+	s.connsLock.Lock()
+	defer s.connsLock.Unlock()
+
+	if s.closed {
+		return false
+	}
+	s.listeners[l] = struct{}{}
+	return true
+}
+
+func (s *Server) untrackListener(l io.Closer) {
+	s.connsLock.Lock()
+	defer s.connsLock.Unlock()
+
+	delete(s.listeners, l)
+}
+
+// errRequestTooLarge is returned when a request is larger than MaxRequestSize
+var errRequestTooLarge = errors.New("request too large")
+
+// limitReader is a reader that fails once more than n bytes are read
+type limitReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitReader) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		return 0, errRequestTooLarge
+	}
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	return n, err
+}
+
+type ipcCodec struct {
+	conn    net.Conn
+	dec     *json.Decoder
+	limit   *limitReader
+	maxSize int64
+}
+
+func newIPCCodec(conn net.Conn, maxSize int64) *ipcCodec {
+	limit := &limitReader{r: conn}
+	return &ipcCodec{
+		conn:    conn,
+		dec:     json.NewDecoder(limit),
+		limit:   limit,
+		maxSize: maxSize,
+	}
+}
+
+func (i *ipcCodec) Read() ([]byte, error) {
+	// the decoder reads the connection in chunks, the limit applies to
+	// the bytes read from the connection while decoding each request
+	i.limit.n = i.maxSize
+	var msg json.RawMessage
+	if err := i.dec.Decode(&msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func (i *ipcCodec) Write(b []byte) error {
+	_, err := i.conn.Write(b)
+	return err
+}
+
+func (i *ipcCodec) Close() error {
+	return i.conn.Close()
+}
@@ -0,0 +1,448 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/umbracle/ethgo/jsonrpc/codec"
+)
+
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeInternalError  = -32603
+	codeDefault        = -32000
+
+	// codeExecutionReverted is the code used by geth for reverted executions
+	codeExecutionReverted = 3
+)
+
+// Error is an error with a custom jsonrpc code and data
+type Error interface {
+	error
+	ErrorCode() int
+}
+
+// DataError is an error with data in the jsonrpc error object
+type DataError interface {
+	error
+	ErrorData() interface{}
+}
+
+type Config struct {
+	Logger *slog.Logger
+
+	// MaxRequestSize is the maximum size in bytes of a request
+	MaxRequestSize int64
+
+	// AllowedOrigins are the origins of the websocket connections that are
+	// accepted besides the same origin. The origin '*' accepts any origin.
+	AllowedOrigins []string
+}
+
+type ConfigOption func(*Config)
+
+// WithLogger sets the logger of the server
+func WithLogger(l *slog.Logger) ConfigOption {
+	return func(c *Config) {
+		c.Logger = l
+	}
+}
+
+// WithMaxRequestSize sets the maximum size in bytes of a request
+func WithMaxRequestSize(size int64) ConfigOption {
+	return func(c *Config) {
+		c.MaxRequestSize = size
+	}
+}
+
+// WithAllowedOrigins sets the origins of the websocket connections that are
+// accepted besides the same origin (i.e. 'http://localhost:3000' or '*')
+func WithAllowedOrigins(origins ...string) ConfigOption {
+	return func(c *Config) {
+		c.AllowedOrigins = origins
+	}
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		Logger:         slog.New(slog.NewTextHandler(io.Discard, nil)),
+		MaxRequestSize: 5 * 1024 * 1024,
+	}
+}
+
+// Server is a jsonrpc server that dispatches the requests to the
+// methods of the receivers registered by namespace. It serves http,
+// websocket and ipc connections.
+type Server struct {
+	config *Config
+	logger *slog.Logger
+
+	lock     sync.RWMutex
+	services map[string]*service
+
+	connsLock sync.Mutex
+	conns     map[*connection]struct{}
+	listeners map[io.Closer]struct{}
+	closed    bool
+}
+
+// NewServer creates a new jsonrpc server
+func NewServer(opts ...ConfigOption) *Server {
+	config := DefaultConfig()
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return &Server{
+		config:    config,
+		logger:    config.Logger,
+		services:  map[string]*service{},
+		conns:     map[*connection]struct{}{},
+		listeners: map[io.Closer]struct{}{},
+	}
+}
+
+// Register registers the exported methods of the receiver under the namespace.
+// A method Name of the receiver is called as 'namespace_name' (i.e. the method
+// BlockNumber of the eth namespace is eth_blockNumber).
+//
+// The methods can take a context as the first argument and the positional
+// params as the rest of arguments, decoded with encoding/json. They return
+// a result, an error or both. The methods that return a *Subscription and
+// an error are subscriptions created with 'namespace_subscribe'.
+func (s *Server) Register(namespace string, receiver interface{}) error {
+	if namespace == "" {
+		return fmt.Errorf("empty namespace")
+	}
+	srv, err := newService(namespace, receiver)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if current, ok := s.services[namespace]; ok {
+		// merge the methods with the receivers already registered
+		for name, cb := range srv.callbacks {
+			current.callbacks[name] = cb
+		}
+		for name, cb := range srv.subscriptions {
+			current.subscriptions[name] = cb
+		}
+		return nil
+	}
+	s.services[namespace] = srv
+	return nil
+}
+
+// Methods returns the names of the registered methods
+func (s *Server) Methods() []string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	methods := []string{}
+	for namespace, srv := range s.services {
+		for name := range srv.callbacks {
+			methods = append(methods, namespace+"_"+name)
+		}
+	}
+	return methods
+}
+
+// Stop closes the listeners and the open connections
+func (s *Server) Stop() {
+	s.connsLock.Lock()
+	defer s.connsLock.Unlock()
+
+	s.closed = true
+	for l := range s.listeners {
+		l.Close()
+	}
+	for c := range s.conns {
+		c.close()
+	}
+}
+
+func (s *Server) trackConn(c *connection) bool {
+	s.connsLock.Lock()
+	defer s.connsLock.Unlock()
+
+	if s.closed {
+		return false
+	}
+	s.conns[c] = struct{}{}
+	return true
+}
+
+func (s *Server) untrackConn(c *connection) {
+	s.connsLock.Lock()
+	defer s.connsLock.Unlock()
+
+	delete(s.conns, c)
+}
+
+// request is a jsonrpc request received by the server. The id
+// is kept raw since the clients can use numbers or strings.
+type request struct {
+	JsonRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+func (r *request) isNotification() bool {
+	return len(r.ID) == 0
+}
+
+// response is a jsonrpc response of the server
+type response struct {
+	JsonRPC string             `json:"jsonrpc"`
+	ID      json.RawMessage    `json:"id"`
+	Result  json.RawMessage    `json:"result,omitempty"`
+	Error   *codec.ErrorObject `json:"error,omitempty"`
+}
+
+var nullID = json.RawMessage("null")
+
+func errorResponse(id json.RawMessage, err *codec.ErrorObject) *response {
+	if len(id) == 0 {
+		id = nullID
+	}
+	return &response{JsonRPC: "2.0", ID: id, Error: err}
+}
+
+// handle handles a message with a single request or a batch. The connection
+// is nil if the transport does not support notifications. It returns the
+// response (nil if there is nothing to respond) and the notifiers of the
+// subscriptions to activate once the response is written.
+func (s *Server) handle(ctx context.Context, conn *connection, msg []byte) ([]byte, []*Notifier) {
+	msg = bytes.TrimSpace(msg)
+
+	if len(msg) != 0 && msg[0] == '[' {
+		var raws []json.RawMessage
+		if err := json.Unmarshal(msg, &raws); err != nil {
+			return marshalResponse(errorResponse(nil, &codec.ErrorObject{Code: codeParseError, Message: err.Error()})), nil
+		}
+		if len(raws) == 0 {
+			return marshalResponse(errorResponse(nil, &codec.ErrorObject{Code: codeInvalidRequest, Message: "empty batch"})), nil
+		}
+
+		resps := []*response{}
+		notifiers := []*Notifier{}
+		for _, raw := range raws {
+			resp, notifier := s.handleRaw(ctx, conn, raw)
+			if resp != nil {
+				resps = append(resps, resp)
+			}
+			if notifier != nil {
+				notifiers = append(notifiers, notifier)
+			}
+		}
+		if len(resps) == 0 {
+			return nil, notifiers
+		}
+		return marshalResponse(resps), notifiers
+	}
+
+	resp, notifier := s.handleRaw(ctx, conn, msg)
+	if resp == nil {
+		return nil, nil
+	}
+	var notifiers []*Notifier
+	if notifier != nil {
+		notifiers = append(notifiers, notifier)
+	}
+	return marshalResponse(resp), notifiers
+}
+
+func marshalResponse(resp interface{}) []byte {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		// the result is already encoded, this should not happen
+		panic(err)
+	}
+	return data
+}
+
+// handleRaw handles a single request. It returns a nil response for notifications.
+func (s *Server) handleRaw(ctx context.Context, conn *connection, raw json.RawMessage) (*response, *Notifier) {
+	var req request
+	if err := json.Unmarshal(raw, &req); err != nil {
+		if _, ok := err.(*json.SyntaxError); ok {
+			return errorResponse(nil, &codec.ErrorObject{Code: codeParseError, Message: err.Error()}), nil
+		}
+		return errorResponse(nil, &codec.ErrorObject{Code: codeInvalidRequest, Message: err.Error()}), nil
+	}
+	if req.Method == "" {
+		return errorResponse(req.ID, &codec.ErrorObject{Code: codeInvalidRequest, Message: "empty method"}), nil
+	}
+
+	result, notifier, errObj := s.handleRequest(ctx, conn, &req)
+	if req.isNotification() {
+		return nil, nil
+	}
+	if errObj != nil {
+		return errorResponse(req.ID, errObj), nil
+	}
+	return &response{JsonRPC: "2.0", ID: req.ID, Result: result}, notifier
+}
+
+func (s *Server) handleRequest(ctx context.Context, conn *connection, req *request) (json.RawMessage, *Notifier, *codec.ErrorObject) {
+	namespace, name, ok := strings.Cut(req.Method, "_")
+	if !ok {
+		return nil, nil, methodNotFound(req.Method)
+	}
+
+	s.lock.RLock()
+	srv, ok := s.services[namespace]
+	var cb *callback
+	if ok {
+		cb = srv.callbacks[name]
+	}
+	s.lock.RUnlock()
+
+	if !ok {
+		return nil, nil, methodNotFound(req.Method)
+	}
+
+	switch name {
+	case "subscribe":
+		if cb == nil {
+			return s.subscribe(ctx, conn, srv, req)
+		}
+	case "unsubscribe":
+		if cb == nil {
+			return s.unsubscribe(conn, req)
+		}
+	}
+	if cb == nil {
+		return nil, nil, methodNotFound(req.Method)
+	}
+
+	args, err := cb.parseArgs(req.Params)
+	if err != nil {
+		return nil, nil, &codec.ErrorObject{Code: codeInvalidParams, Message: err.Error()}
+	}
+	res, err := cb.call(ctx, args)
+	if err != nil {
+		s.logger.Debug("jsonrpc method failed", "method", req.Method, "err", err)
+		return nil, nil, toErrorObject(err)
+	}
+	result, err := json.Marshal(res)
+	if err != nil {
+		return nil, nil, &codec.ErrorObject{Code: codeInternalError, Message: err.Error()}
+	}
+	return result, nil, nil
+}
+
+// subscribe calls the subscription method with the name in the first param
+func (s *Server) subscribe(ctx context.Context, conn *connection, srv *service, req *request) (json.RawMessage, *Notifier, *codec.ErrorObject) {
+	if conn == nil {
+		return nil, nil, &codec.ErrorObject{Code: codeMethodNotFound, Message: ErrNotificationsUnsupported.Error()}
+	}
+	if req.isNotification() {
+		// the subscription id cannot be sent without a response
+		return nil, nil, &codec.ErrorObject{Code: codeInvalidRequest, Message: "subscription request without id"}
+	}
+
+	var raw []json.RawMessage
+	if err := json.Unmarshal(req.Params, &raw); err != nil || len(raw) == 0 {
+		return nil, nil, &codec.ErrorObject{Code: codeInvalidParams, Message: "expected subscription name as first argument"}
+	}
+	var name string
+	if err := json.Unmarshal(raw[0], &name); err != nil {
+		return nil, nil, &codec.ErrorObject{Code: codeInvalidParams, Message: "expected subscription name as first argument"}
+	}
+
+	s.lock.RLock()
+	cb, ok := srv.subscriptions[name]
+	s.lock.RUnlock()
+
+	if !ok {
+		return nil, nil, &codec.ErrorObject{Code: codeMethodNotFound, Message: fmt.Sprintf("no %q subscription in %s namespace", name, srv.name)}
+	}
+
+	params, err := json.Marshal(raw[1:])
+	if err != nil {
+		return nil, nil, &codec.ErrorObject{Code: codeInternalError, Message: err.Error()}
+	}
+	args, err := cb.parseArgs(params)
+	if err != nil {
+		return nil, nil, &codec.ErrorObject{Code: codeInvalidParams, Message: err.Error()}
+	}
+
+	notifier := &Notifier{conn: conn, namespace: srv.name}
+	res, err := cb.call(context.WithValue(conn.ctx, notifierKey{}, notifier), args)
+	if err != nil {
+		return nil, nil, toErrorObject(err)
+	}
+	sub, ok := res.(*Subscription)
+	if !ok || sub == nil {
+		return nil, nil, &codec.ErrorObject{Code: codeInternalError, Message: "subscription not created"}
+	}
+	conn.addSubscription(sub)
+
+	result, _ := json.Marshal(sub.ID)
+	return result, notifier, nil
+}
+
+// unsubscribe removes the subscription with the id of the first param
+func (s *Server) unsubscribe(conn *connection, req *request) (json.RawMessage, *Notifier, *codec.ErrorObject) {
+	if conn == nil {
+		return nil, nil, &codec.ErrorObject{Code: codeMethodNotFound, Message: ErrNotificationsUnsupported.Error()}
+	}
+
+	var params []string
+	if err := json.Unmarshal(req.Params, &params); err != nil || len(params) != 1 {
+		return nil, nil, &codec.ErrorObject{Code: codeInvalidParams, Message: "expected subscription id as argument"}
+	}
+	if !conn.removeSubscription(params[0]) {
+		return nil, nil, &codec.ErrorObject{Code: codeDefault, Message: ErrSubscriptionNotFound.Error()}
+	}
+	return json.RawMessage("true"), nil, nil
+}
+
+func methodNotFound(method string) *codec.ErrorObject {
+	return &codec.ErrorObject{Code: codeMethodNotFound, Message: fmt.Sprintf("the method %s does not exist/is not available", method)}
+}
+
+// toErrorObject converts the error of a method into a jsonrpc error
+func toErrorObject(err error) *codec.ErrorObject {
+	var obj *codec.ErrorObject
+	if errors.As(err, &obj) {
+		return obj
+	}
+
+	var revertErr *codec.RevertError
+	if errors.As(err, &revertErr) {
+		obj := &codec.ErrorObject{Code: codeExecutionReverted, Message: revertErr.Error()}
+		if len(revertErr.Data) != 0 {
+			obj.Data = "0x" + hex.EncodeToString(revertErr.Data)
+		}
+		return obj
+	}
+
+	obj = &codec.ErrorObject{Code: codeDefault, Message: err.Error()}
+
+	var codeErr Error
+	if errors.As(err, &codeErr) {
+		obj.Code = codeErr.ErrorCode()
+	}
+	var dataErr DataError
+	if errors.As(err, &dataErr) {
+		obj.Data = dataErr.ErrorData()
+	}
+	return obj
+}
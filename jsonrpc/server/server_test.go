@@ -0,0 +1,366 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo/jsonrpc"
+	"github.com/umbracle/ethgo/jsonrpc/codec"
+)
+
+type testService struct {
+	unsubscribed chan struct{}
+}
+
+func (t *testService) Add(a, b uint64) uint64 {
+	return a + b
+}
+
+func (t *testService) Echo(ctx context.Context, str string, suffix *string) (string, error) {
+	if suffix != nil {
+		str += *suffix
+	}
+	return str, nil
+}
+
+func (t *testService) Fail() error {
+	return &testError{}
+}
+
+func (t *testService) Revert() error {
+	return &codec.RevertError{Data: []byte{0x1, 0x2}}
+}
+
+func (t *testService) Counter(ctx context.Context, n uint64) (*Subscription, error) {
+	notifier, ok := NotifierFromContext(ctx)
+	if !ok {
+		return nil, ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+
+	// the notifications sent before the response are buffered
+	for i := uint64(0); i < n; i++ {
+		notifier.Notify(i)
+	}
+	go func() {
+		<-sub.Err()
+		close(t.unsubscribed)
+	}()
+	return sub, nil
+}
+
+func (t *testService) unexported() string {
+	return ""
+}
+
+type testError struct{}
+
+func (t *testError) Error() string {
+	return "test error"
+}
+
+func (t *testError) ErrorCode() int {
+	return 10
+}
+
+func (t *testError) ErrorData() interface{} {
+	return "data"
+}
+
+func newTestServer(t *testing.T) (*Server, *testService) {
+	srv := NewServer()
+	t.Cleanup(srv.Stop)
+
+	svc := &testService{unsubscribed: make(chan struct{})}
+	require.NoError(t, srv.Register("test", svc))
+	require.NoError(t, srv.Register("eth", svc))
+	return srv, svc
+}
+
+func testClientCalls(t *testing.T, c *jsonrpc.Client) {
+	var num uint64
+	require.NoError(t, c.Call("test_add", &num, 1, 2))
+	assert.Equal(t, uint64(3), num)
+
+	var str string
+	require.NoError(t, c.Call("test_echo", &str, "a"))
+	assert.Equal(t, "a", str)
+
+	require.NoError(t, c.Call("test_echo", &str, "a", "b"))
+	assert.Equal(t, "ab", str)
+
+	// missing required argument
+	err := c.Call("test_add", &num, 1)
+	assert.Equal(t, codeInvalidParams, err.(*codec.ErrorObject).Code)
+
+	// too many arguments
+	err = c.Call("test_add", &num, 1, 2, 3)
+	assert.Equal(t, codeInvalidParams, err.(*codec.ErrorObject).Code)
+
+	err = c.Call("test_unexported", &str)
+	assert.Equal(t, codeMethodNotFound, err.(*codec.ErrorObject).Code)
+
+	err = c.Call("test_fail", nil)
+	assert.Equal(t, &codec.ErrorObject{Code: 10, Message: "test error", Data: "data"}, err)
+
+	err = c.Call("test_revert", nil)
+	assert.True(t, errors.Is(err, jsonrpc.ErrExecutionReverted))
+
+	var revertErr *jsonrpc.RevertError
+	assert.True(t, errors.As(err, &revertErr))
+	assert.Equal(t, []byte{0x1, 0x2}, revertErr.Data)
+
+	batch := c.NewBatch()
+	var a, b uint64
+	batch.Add("test_add", &a, 1, 1)
+	batch.Add("test_add", &b, 2, 2)
+	indx := batch.Add("test_missing", nil)
+	require.NoError(t, batch.Send())
+	assert.Equal(t, uint64(2), a)
+	assert.Equal(t, uint64(4), b)
+	assert.Error(t, batch.Error(indx))
+}
+
+func TestServer_HTTP(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	c, err := jsonrpc.NewClient(ts.URL)
+	require.NoError(t, err)
+
+	testClientCalls(t, c)
+
+	// subscriptions are not available in http
+	var id string
+	err = c.Call("eth_subscribe", &id, "counter", 1)
+	assert.Equal(t, codeMethodNotFound, err.(*codec.ErrorObject).Code)
+}
+
+func testClientSubscription(t *testing.T, c *jsonrpc.Client, svc *testService) {
+	eventCh := make(chan string, 10)
+	cancel, err := c.SubscribeWithNotify("counter", []interface{}{3}, func(b []byte) {
+		eventCh <- string(b)
+	}, nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		select {
+		case evnt := <-eventCh:
+			assert.Equal(t, fmt.Sprint(i), evnt)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+		}
+	}
+
+	require.NoError(t, cancel())
+
+	select {
+	case <-svc.unsubscribed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+}
+
+func TestServer_Websocket(t *testing.T) {
+	srv, svc := newTestServer(t)
+
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	c, err := jsonrpc.NewClient(strings.Replace(ts.URL, "http://", "ws://", 1))
+	require.NoError(t, err)
+	defer c.Close()
+
+	testClientCalls(t, c)
+	testClientSubscription(t, c, svc)
+}
+
+func TestServer_WebsocketOrigin(t *testing.T) {
+	dial := func(srv *Server, origin string) error {
+		ts := httptest.NewServer(srv)
+		defer ts.Close()
+
+		header := http.Header{}
+		if origin == "self" {
+			origin = ts.URL
+		}
+		if origin != "" {
+			header.Set("Origin", origin)
+		}
+		conn, _, err := websocket.DefaultDialer.Dial(strings.Replace(ts.URL, "http://", "ws://", 1), header)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+
+	// only the same origin is accepted by default
+	srv := NewServer()
+	t.Cleanup(srv.Stop)
+
+	assert.NoError(t, dial(srv, ""))
+	assert.NoError(t, dial(srv, "self"))
+	assert.Error(t, dial(srv, "http://example.com"))
+
+	srv = NewServer(WithAllowedOrigins("http://example.com"))
+	t.Cleanup(srv.Stop)
+
+	assert.NoError(t, dial(srv, "http://example.com"))
+	assert.Error(t, dial(srv, "http://other.com"))
+
+	srv = NewServer(WithAllowedOrigins("*"))
+	t.Cleanup(srv.Stop)
+
+	assert.NoError(t, dial(srv, "http://other.com"))
+}
+
+func TestServer_IPC(t *testing.T) {
+	srv, svc := newTestServer(t)
+
+	path := filepath.Join(t.TempDir(), "test.ipc")
+	l, err := net.Listen("unix", path)
+	require.NoError(t, err)
+
+	go srv.ServeIPC(l)
+
+	c, err := jsonrpc.NewClient(path)
+	require.NoError(t, err)
+	defer c.Close()
+
+	testClientCalls(t, c)
+	testClientSubscription(t, c, svc)
+}
+
+func TestServer_IPCMaxRequestSize(t *testing.T) {
+	srv := NewServer(WithMaxRequestSize(100))
+	t.Cleanup(srv.Stop)
+	require.NoError(t, srv.Register("test", &testService{}))
+
+	path := filepath.Join(t.TempDir(), "test.ipc")
+	l, err := net.Listen("unix", path)
+	require.NoError(t, err)
+
+	go srv.ServeIPC(l)
+
+	c, err := jsonrpc.NewClient(path)
+	require.NoError(t, err)
+	defer c.Close()
+
+	var out string
+	require.NoError(t, c.Call("test_echo", &out, "a", nil))
+	assert.Equal(t, "a", out)
+
+	// the connection is closed with a request larger than the limit
+	assert.Error(t, c.Call("test_echo", &out, strings.Repeat("a", 200), nil))
+}
+
+func TestServer_Handle(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	cases := []struct {
+		req  string
+		resp string
+	}{
+		{
+			`{"jsonrpc": "2.0", "id": "a", "method": "test_add", "params": [1, 2]}`,
+			`{"jsonrpc":"2.0","id":"a","result":3}`,
+		},
+		{
+			// notifications do not have a response
+			`{"jsonrpc": "2.0", "method": "test_add", "params": [1, 2]}`,
+			``,
+		},
+		{
+			`{"jsonrpc": "2.0", "id": 1, "method": "test_echo", "params": [null]}`,
+			`{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"missing value for required argument 0"}}`,
+		},
+		{
+			`{"jsonrpc": "2.0", "id": 1, "method": "other_add"}`,
+			`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"the method other_add does not exist/is not available"}}`,
+		},
+		{
+			`{"jsonrpc": "2.0", "id": 1`,
+			`{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"unexpected end of JSON input"}}`,
+		},
+		{
+			`[]`,
+			`{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"empty batch"}}`,
+		},
+		{
+			`[{"jsonrpc": "2.0", "id": 1, "method": "test_add", "params": [1, 2]}, {"jsonrpc": "2.0", "method": "test_add"}]`,
+			`[{"jsonrpc":"2.0","id":1,"result":3}]`,
+		},
+	}
+
+	for _, c := range cases {
+		resp, _ := srv.handle(context.Background(), nil, []byte(c.req))
+		assert.Equal(t, c.resp, string(resp))
+	}
+}
+
+func TestServer_SubscribeNotification(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	conn := &connection{srv: srv, ctx: ctx, cancel: cancel, subs: map[string]*Subscription{}}
+
+	reqs := []string{
+		`{"jsonrpc": "2.0", "method": "test_subscribe", "params": ["counter", 0]}`,
+		`[{"jsonrpc": "2.0", "method": "test_subscribe", "params": ["counter", 0]}]`,
+	}
+	for _, req := range reqs {
+		// the subscription is not created without an id
+		resp, notifiers := srv.handle(ctx, conn, []byte(req))
+		assert.Nil(t, resp)
+		assert.Empty(t, notifiers)
+		assert.Empty(t, conn.subs)
+	}
+}
+
+func TestServer_NotifyClosed(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	conn := &connection{srv: srv, ctx: ctx, cancel: cancel, subs: map[string]*Subscription{}}
+	notifier := &Notifier{conn: conn, namespace: "test"}
+
+	// there is no subscription to notify
+	assert.ErrorIs(t, notifier.Notify(1), ErrSubscriptionNotFound)
+
+	sub := notifier.CreateSubscription()
+	assert.NoError(t, notifier.Notify(1))
+	assert.Len(t, notifier.buffer, 1)
+
+	// the notifications are not sent once the subscription is closed
+	sub.close()
+	assert.ErrorIs(t, notifier.Notify(2), ErrSubscriptionClosed)
+	assert.Len(t, notifier.buffer, 1)
+}
+
+func TestServer_Register(t *testing.T) {
+	srv := NewServer()
+
+	assert.Error(t, srv.Register("", &testService{}))
+	assert.Error(t, srv.Register("test", &struct{}{}))
+
+	require.NoError(t, srv.Register("test", &testService{}))
+	assert.Contains(t, srv.Methods(), "test_add")
+	assert.NotContains(t, srv.Methods(), "test_counter")
+}
@@ -0,0 +1,179 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+var (
+	contextType      = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType        = reflect.TypeOf((*error)(nil)).Elem()
+	subscriptionType = reflect.TypeOf((*Subscription)(nil))
+)
+
+// service is the set of methods of a receiver registered under a namespace
+type service struct {
+	name          string
+	callbacks     map[string]*callback
+	subscriptions map[string]*callback
+}
+
+// callback is a method of a receiver that can be called remotely
+type callback struct {
+	fn       reflect.Value
+	rcvr     reflect.Value
+	argTypes []reflect.Type
+	hasCtx   bool
+	errPos   int
+}
+
+// newService returns the service with the suitable methods of the receiver
+func newService(name string, rcvr interface{}) (*service, error) {
+	val := reflect.ValueOf(rcvr)
+	typ := val.Type()
+
+	s := &service{
+		name:          name,
+		callbacks:     map[string]*callback{},
+		subscriptions: map[string]*callback{},
+	}
+	for i := 0; i < typ.NumMethod(); i++ {
+		method := typ.Method(i)
+		if method.PkgPath != "" {
+			// unexported method
+			continue
+		}
+		cb := newCallback(val, method.Func)
+		if cb == nil {
+			continue
+		}
+
+		name := methodName(method.Name)
+		if isSubscription(method.Type) {
+			s.subscriptions[name] = cb
+		} else {
+			s.callbacks[name] = cb
+		}
+	}
+	if len(s.callbacks) == 0 && len(s.subscriptions) == 0 {
+		return nil, fmt.Errorf("receiver %s has no suitable methods", typ)
+	}
+	return s, nil
+}
+
+// newCallback returns the callback of the method or nil if the
+// method cannot be called remotely. The method can take a context
+// as the first argument and returns either a result, an error or
+// both with the error as the last value.
+func newCallback(rcvr, fn reflect.Value) *callback {
+	typ := fn.Type()
+
+	cb := &callback{fn: fn, rcvr: rcvr, errPos: -1}
+
+	// the first input is the receiver
+	firstArg := 1
+	if typ.NumIn() > 1 && typ.In(1) == contextType {
+		cb.hasCtx = true
+		firstArg++
+	}
+	for i := firstArg; i < typ.NumIn(); i++ {
+		cb.argTypes = append(cb.argTypes, typ.In(i))
+	}
+
+	switch typ.NumOut() {
+	case 0:
+	case 1:
+		if typ.Out(0) == errorType {
+			cb.errPos = 0
+		}
+	case 2:
+		if typ.Out(0) == errorType || typ.Out(1) != errorType {
+			return nil
+		}
+		cb.errPos = 1
+	default:
+		return nil
+	}
+	return cb
+}
+
+// isSubscription returns whether the method creates a subscription. A
+// subscription method takes a context (with the notifier) and returns
+// the subscription and an error.
+func isSubscription(typ reflect.Type) bool {
+	return typ.NumIn() > 1 && typ.In(1) == contextType &&
+		typ.NumOut() == 2 && typ.Out(0) == subscriptionType && typ.Out(1) == errorType
+}
+
+// methodName returns the name of the jsonrpc method for the
+// name of the go method (i.e. BlockNumber is blockNumber)
+func methodName(name string) string {
+	r := []rune(name)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+// parseArgs decodes the positional params into the arguments of the callback.
+// The missing trailing arguments are set to their zero value if they are optional
+// (pointers, slices, maps or interfaces).
+func (c *callback) parseArgs(params json.RawMessage) ([]reflect.Value, error) {
+	var raw []json.RawMessage
+	if p := strings.TrimSpace(string(params)); p != "" && p != "null" {
+		if err := json.Unmarshal(params, &raw); err != nil {
+			return nil, fmt.Errorf("non-array params")
+		}
+	}
+	if len(raw) > len(c.argTypes) {
+		return nil, fmt.Errorf("too many arguments, want at most %d", len(c.argTypes))
+	}
+
+	args := make([]reflect.Value, 0, len(c.argTypes))
+	for i, typ := range c.argTypes {
+		if i >= len(raw) || string(raw[i]) == "null" {
+			switch typ.Kind() {
+			case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+			default:
+				return nil, fmt.Errorf("missing value for required argument %d", i)
+			}
+			args = append(args, reflect.Zero(typ))
+			continue
+		}
+
+		val := reflect.New(typ)
+		if err := json.Unmarshal(raw[i], val.Interface()); err != nil {
+			return nil, fmt.Errorf("invalid argument %d: %v", i, err)
+		}
+		args = append(args, val.Elem())
+	}
+	return args, nil
+}
+
+// call invokes the callback with the arguments
+func (c *callback) call(ctx context.Context, args []reflect.Value) (res interface{}, err error) {
+	in := []reflect.Value{c.rcvr}
+	if c.hasCtx {
+		in = append(in, reflect.ValueOf(ctx))
+	}
+	in = append(in, args...)
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("method handler crashed: %v", r)
+		}
+	}()
+
+	out := c.fn.Call(in)
+	if c.errPos != -1 {
+		if e := out[c.errPos]; !e.IsNil() {
+			return nil, e.Interface().(error)
+		}
+	}
+	if len(out) == 0 || c.errPos == 0 {
+		return nil, nil
+	}
+	return out[0].Interface(), nil
+}
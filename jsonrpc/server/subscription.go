@@ -0,0 +1,168 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/umbracle/ethgo/jsonrpc/codec"
+)
+
+var (
+	// ErrNotificationsUnsupported is returned by the subscription methods
+	// called over a transport without notifications (i.e. http)
+	ErrNotificationsUnsupported = errors.New("notifications not supported")
+
+	// ErrSubscriptionNotFound is returned when unsubscribing an unknown subscription
+	// or notifying without a subscription
+	ErrSubscriptionNotFound = errors.New("subscription not found")
+
+	// ErrSubscriptionClosed is returned when notifying a subscription after
+	// the client unsubscribed or the connection was closed
+	ErrSubscriptionClosed = errors.New("subscription closed")
+)
+
+type notifierKey struct{}
+
+// NotifierFromContext returns the notifier of the connection of a subscription
+// method. It is only available for the transports with notifications
+// (websocket and ipc).
+func NotifierFromContext(ctx context.Context) (*Notifier, bool) {
+	n, ok := ctx.Value(notifierKey{}).(*Notifier)
+	return n, ok
+}
+
+// Subscription is a subscription created by a subscription method
+type Subscription struct {
+	ID string
+
+	namespace string
+	errCh     chan error
+	closeOnce sync.Once
+}
+
+// Err returns a channel that is closed when the client unsubscribes
+// or the connection is closed
+func (s *Subscription) Err() <-chan error {
+	return s.errCh
+}
+
+// isClosed returns true if the subscription is closed
+func (s *Subscription) isClosed() bool {
+	select {
+	case <-s.errCh:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *Subscription) close() {
+	s.closeOnce.Do(func() {
+		close(s.errCh)
+	})
+}
+
+// Notifier sends the notifications of a subscription to the client.
+// The notifications sent before the response of the subscribe request
+// is written are buffered so that the client knows the subscription.
+type Notifier struct {
+	conn      *connection
+	namespace string
+
+	lock   sync.Mutex
+	sub    *Subscription
+	active bool
+	buffer [][]byte
+}
+
+// CreateSubscription creates the subscription of the notifier.
+// A notifier only has one subscription.
+func (n *Notifier) CreateSubscription() *Subscription {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.sub == nil {
+		n.sub = &Subscription{
+			ID:        newSubscriptionID(),
+			namespace: n.namespace,
+			errCh:     make(chan error),
+		}
+	}
+	return n.sub
+}
+
+// Notify sends a notification with the data for the subscription of the notifier.
+// It returns ErrSubscriptionClosed once the subscription is closed.
+func (n *Notifier) Notify(data interface{}) error {
+	n.lock.Lock()
+	sub := n.sub
+	n.lock.Unlock()
+
+	if sub == nil {
+		return ErrSubscriptionNotFound
+	}
+
+	result, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	params, err := json.Marshal(&codec.Subscription{ID: sub.ID, Result: result})
+	if err != nil {
+		return err
+	}
+	msg, err := json.Marshal(&notification{
+		JsonRPC: "2.0",
+		Method:  n.namespace + "_subscription",
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if sub.isClosed() {
+		return ErrSubscriptionClosed
+	}
+	if !n.active {
+		n.buffer = append(n.buffer, msg)
+		return nil
+	}
+	return n.conn.write(msg)
+}
+
+// activate sends the buffered notifications once the response of
+// the subscribe request has been written
+func (n *Notifier) activate() error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.active = true
+	for _, msg := range n.buffer {
+		if err := n.conn.write(msg); err != nil {
+			return err
+		}
+	}
+	n.buffer = nil
+	return nil
+}
+
+// notification is the message of a subscription event
+type notification struct {
+	JsonRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+func newSubscriptionID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+	return "0x" + hex.EncodeToString(buf)
+}
@@ -1,8 +1,12 @@
 package jsonrpc
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"sync"
 
+	"github.com/umbracle/ethgo"
 	"github.com/umbracle/ethgo/jsonrpc/transport"
 )
 
@@ -21,3 +25,260 @@ func (c *Client) Subscribe(method string, callback func(b []byte)) (func() error
 	close, err := pub.Subscribe(method, callback)
 	return close, err
 }
+
+// SubscribeWithNotify starts a new subscription with params. If the connection
+// is lost, the transport reconnects and the subscription is re-established. Then,
+// notify is called with a nil error since some events may have been missed, or
+// with the error if the subscription could not be re-established (i.e.
+// transport.ErrConnectionLost if the transport gives up or reconnection is
+// disabled). The notify function is never called if the transport does not
+// support it.
+func (c *Client) SubscribeWithNotify(method string, params []interface{}, callback func(b []byte), notify func(err error)) (func() error, error) {
+	if pub, ok := c.transport.(transport.ResubscribeTransport); ok {
+		return pub.SubscribeWithNotify(method, params, callback, notify)
+	}
+	if len(params) != 0 {
+		return nil, fmt.Errorf("transport does not support subscriptions with params")
+	}
+	// the transport does not support notifications, notify is never called
+	return c.Subscribe(method, callback)
+}
+
+// ErrSlowConsumer is the error of a subscription that ends because
+// the consumer does not read the events as fast as they arrive
+var ErrSlowConsumer = fmt.Errorf("subscription consumer is too slow")
+
+// Subscription is an active typed subscription
+type Subscription interface {
+	// Err returns a channel that receives the error that ends the subscription
+	// (i.e. ErrSlowConsumer). The channel is closed when the subscription ends.
+	Err() <-chan error
+
+	// Reconnected returns a channel that receives a value when the subscription
+	// is re-established after a reconnect, since some events may have been missed
+	Reconnected() <-chan struct{}
+
+	// Unsubscribe ends the subscription and closes the channel of events
+	Unsubscribe()
+}
+
+// SlowConsumerPolicy is the behaviour of a subscription when
+// the buffer of events is full
+type SlowConsumerPolicy int
+
+const (
+	// SlowConsumerError ends the subscription with ErrSlowConsumer
+	SlowConsumerError SlowConsumerPolicy = iota
+
+	// SlowConsumerDrop drops the new events until there is space in the buffer
+	SlowConsumerDrop
+)
+
+const defaultSubscriptionBuffer = 128
+
+type subscriptionConfig struct {
+	buffer int
+	policy SlowConsumerPolicy
+}
+
+// SubscriptionOption is an option of a typed subscription
+type SubscriptionOption func(*subscriptionConfig)
+
+// WithSubscriptionBuffer sets the size of the buffer of the channel of events
+func WithSubscriptionBuffer(size int) SubscriptionOption {
+	return func(c *subscriptionConfig) {
+		c.buffer = size
+	}
+}
+
+// WithSlowConsumerPolicy sets the behaviour of the subscription when the buffer is full
+func WithSlowConsumerPolicy(policy SlowConsumerPolicy) SubscriptionOption {
+	return func(c *subscriptionConfig) {
+		c.policy = policy
+	}
+}
+
+// subscription is a typed subscription that delivers the events in a channel
+type subscription[T any] struct {
+	config *subscriptionConfig
+	decode func(b []byte) (T, error)
+
+	lock          sync.Mutex
+	closed        bool
+	closeCh       chan struct{}
+	eventCh       chan T
+	errCh         chan error
+	reconnectedCh chan struct{}
+	cancel        func() error
+}
+
+// subscribe starts a typed subscription. The subscription ends when the context is done.
+func subscribe[T any](ctx context.Context, c *Client, method string, params []interface{}, decode func(b []byte) (T, error), opts []SubscriptionOption) (<-chan T, Subscription) {
+	config := &subscriptionConfig{
+		buffer: defaultSubscriptionBuffer,
+		policy: SlowConsumerError,
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	sub := &subscription[T]{
+		config:        config,
+		decode:        decode,
+		closeCh:       make(chan struct{}),
+		eventCh:       make(chan T, config.buffer),
+		errCh:         make(chan error, 1),
+		reconnectedCh: make(chan struct{}, 1),
+	}
+
+	cancel, err := c.SubscribeWithNotify(method, params, sub.handleEvent, sub.handleNotify)
+	if err != nil {
+		sub.close(err)
+		return sub.eventCh, sub
+	}
+
+	sub.lock.Lock()
+	sub.cancel = cancel
+	closed := sub.closed
+	sub.lock.Unlock()
+
+	if closed {
+		// the subscription failed before it was set up
+		cancel()
+		return sub.eventCh, sub
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			sub.Unsubscribe()
+		case <-sub.closeCh:
+		}
+	}()
+	return sub.eventCh, sub
+}
+
+func (s *subscription[T]) handleEvent(b []byte) {
+	obj, err := s.decode(b)
+	if err != nil {
+		s.fail(fmt.Errorf("failed to decode event: %w", err))
+		return
+	}
+
+	s.lock.Lock()
+	if s.closed {
+		s.lock.Unlock()
+		return
+	}
+	select {
+	case s.eventCh <- obj:
+		s.lock.Unlock()
+	default:
+		s.lock.Unlock()
+		if s.config.policy == SlowConsumerError {
+			s.fail(ErrSlowConsumer)
+		}
+	}
+}
+
+func (s *subscription[T]) handleNotify(err error) {
+	if err != nil {
+		s.fail(err)
+		return
+	}
+	select {
+	case s.reconnectedCh <- struct{}{}:
+	default:
+	}
+}
+
+// close ends the subscription and returns the function to
+// unsubscribe from the node if it was not closed before
+func (s *subscription[T]) close(err error) func() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	close(s.closeCh)
+
+	if err != nil {
+		s.errCh <- err
+	}
+	close(s.errCh)
+	close(s.eventCh)
+	return s.cancel
+}
+
+// fail ends the subscription with an error
+func (s *subscription[T]) fail(err error) {
+	if cancel := s.close(err); cancel != nil {
+		// unsubscribe in the background since the events
+		// are delivered in the same routine as this call
+		go cancel()
+	}
+}
+
+// Err implements the Subscription interface
+func (s *subscription[T]) Err() <-chan error {
+	return s.errCh
+}
+
+// Reconnected implements the Subscription interface
+func (s *subscription[T]) Reconnected() <-chan struct{} {
+	return s.reconnectedCh
+}
+
+// Unsubscribe implements the Subscription interface
+func (s *subscription[T]) Unsubscribe() {
+	if cancel := s.close(nil); cancel != nil {
+		cancel()
+	}
+}
+
+// SubscribeNewHeads subscribes to the headers of the new blocks. The blocks do not include transactions.
+func (e *Eth) SubscribeNewHeads(ctx context.Context, opts ...SubscriptionOption) (<-chan *ethgo.Block, Subscription) {
+	return subscribe(ctx, e.c, "newHeads", nil, func(b []byte) (*ethgo.Block, error) {
+		block := new(ethgo.Block)
+		if err := block.UnmarshalJSON(b); err != nil {
+			return nil, err
+		}
+		return block, nil
+	}, opts)
+}
+
+// SubscribeLogs subscribes to the new logs that match the address and topics of the filter
+func (e *Eth) SubscribeLogs(ctx context.Context, filter *ethgo.LogFilter, opts ...SubscriptionOption) (<-chan *ethgo.Log, Subscription) {
+	if filter == nil {
+		filter = &ethgo.LogFilter{}
+	}
+	return subscribe(ctx, e.c, "logs", []interface{}{filter}, func(b []byte) (*ethgo.Log, error) {
+		log := new(ethgo.Log)
+		if err := log.UnmarshalJSON(b); err != nil {
+			return nil, err
+		}
+		return log, nil
+	}, opts)
+}
+
+// SubscribeNewPendingTransactions subscribes to the hashes of the transactions added to the pool
+func (e *Eth) SubscribeNewPendingTransactions(ctx context.Context, opts ...SubscriptionOption) (<-chan ethgo.Hash, Subscription) {
+	return subscribe(ctx, e.c, "newPendingTransactions", nil, func(b []byte) (ethgo.Hash, error) {
+		var hash ethgo.Hash
+		err := json.Unmarshal(b, &hash)
+		return hash, err
+	}, opts)
+}
+
+// SubscribeNewPendingTransactionsFull subscribes to the full body of the transactions added to the pool
+func (e *Eth) SubscribeNewPendingTransactionsFull(ctx context.Context, opts ...SubscriptionOption) (<-chan *ethgo.Transaction, Subscription) {
+	return subscribe(ctx, e.c, "newPendingTransactions", []interface{}{true}, func(b []byte) (*ethgo.Transaction, error) {
+		txn := new(ethgo.Transaction)
+		if err := txn.UnmarshalJSON(b); err != nil {
+			return nil, err
+		}
+		return txn, nil
+	}, opts)
+}
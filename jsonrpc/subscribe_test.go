@@ -1,12 +1,20 @@
 package jsonrpc
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc/codec"
 	"github.com/umbracle/ethgo/testutil"
 )
 
@@ -68,3 +76,201 @@ func TestSubscribeNewHead(t *testing.T) {
 		assert.Error(t, cancel())
 	})
 }
+
+// mockSubscriptionServer is a websocket server that accepts any subscription
+// and sends the events pushed by the test
+type mockSubscriptionServer struct {
+	*httptest.Server
+
+	lock   sync.Mutex
+	conn   *websocket.Conn
+	params [][]json.RawMessage
+	unsubs []string
+}
+
+func newMockSubscriptionServer(t *testing.T) *mockSubscriptionServer {
+	m := &mockSubscriptionServer{}
+	upgrader := websocket.Upgrader{}
+
+	m.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		m.lock.Lock()
+		m.conn = conn
+		m.lock.Unlock()
+
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req codec.Request
+			assert.NoError(t, json.Unmarshal(data, &req))
+
+			var params []json.RawMessage
+			assert.NoError(t, json.Unmarshal(req.Params, &params))
+
+			m.lock.Lock()
+			result := `true`
+			if req.Method == "eth_subscribe" {
+				m.params = append(m.params, params)
+				result = `"0x1"`
+			} else {
+				m.unsubs = append(m.unsubs, string(params[0]))
+			}
+			err = conn.WriteJSON(codec.Response{ID: req.ID, Result: json.RawMessage(result)})
+			m.lock.Unlock()
+
+			if err != nil {
+				return
+			}
+		}
+	}))
+	return m
+}
+
+func (m *mockSubscriptionServer) url() string {
+	return "ws" + strings.TrimPrefix(m.URL, "http")
+}
+
+func (m *mockSubscriptionServer) notify(t *testing.T, result string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	params, err := json.Marshal(codec.Subscription{ID: "0x1", Result: json.RawMessage(result)})
+	assert.NoError(t, err)
+	assert.NoError(t, m.conn.WriteJSON(codec.Request{JsonRPC: "2.0", Method: "eth_subscription", Params: params}))
+}
+
+func mockLogEvent(indx uint64) string {
+	return fmt.Sprintf(`{
+		"address": "0x0000000000000000000000000000000000000001",
+		"topics": [],
+		"data": "0x",
+		"blockNumber": "0x1",
+		"transactionHash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+		"transactionIndex": "0x0",
+		"blockHash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+		"logIndex": "0x%x",
+		"removed": false
+	}`, indx)
+}
+
+func TestSubscribeLogs(t *testing.T) {
+	srv := newMockSubscriptionServer(t)
+	defer srv.Close()
+
+	c, err := NewClient(srv.url())
+	assert.NoError(t, err)
+	defer c.Close()
+
+	filter := &ethgo.LogFilter{
+		Address: []ethgo.Address{{0x1}, {0x2}},
+	}
+	logCh, sub := c.Eth().SubscribeLogs(context.Background(), filter)
+
+	// the filter is sent as a param
+	srv.lock.Lock()
+	assert.Equal(t, `"logs"`, string(srv.params[0][0]))
+	assert.Contains(t, string(srv.params[0][1]), ethgo.Address{0x2}.String())
+	srv.lock.Unlock()
+
+	// the events are delivered in order
+	num := uint64(50)
+	for i := uint64(0); i < num; i++ {
+		srv.notify(t, mockLogEvent(i))
+	}
+	for i := uint64(0); i < num; i++ {
+		select {
+		case log := <-logCh:
+			assert.Equal(t, i, log.LogIndex)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+		}
+	}
+
+	sub.Unsubscribe()
+
+	_, ok := <-logCh
+	assert.False(t, ok)
+	_, ok = <-sub.Err()
+	assert.False(t, ok)
+
+	srv.lock.Lock()
+	assert.Equal(t, []string{`"0x1"`}, srv.unsubs)
+	srv.lock.Unlock()
+}
+
+func TestSubscribe_SlowConsumer(t *testing.T) {
+	srv := newMockSubscriptionServer(t)
+	defer srv.Close()
+
+	c, err := NewClient(srv.url())
+	assert.NoError(t, err)
+	defer c.Close()
+
+	// the subscription fails if the buffer is full
+	logCh, sub := c.Eth().SubscribeLogs(context.Background(), nil, WithSubscriptionBuffer(1))
+	srv.notify(t, mockLogEvent(0))
+	srv.notify(t, mockLogEvent(1))
+
+	select {
+	case err := <-sub.Err():
+		assert.ErrorIs(t, err, ErrSlowConsumer)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+
+	// the events in the buffer can still be read
+	log, ok := <-logCh
+	assert.True(t, ok)
+	assert.Equal(t, uint64(0), log.LogIndex)
+
+	_, ok = <-logCh
+	assert.False(t, ok)
+}
+
+func TestSubscribe_SlowConsumerDrop(t *testing.T) {
+	srv := newMockSubscriptionServer(t)
+	defer srv.Close()
+
+	c, err := NewClient(srv.url())
+	assert.NoError(t, err)
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// the new events are dropped if the buffer is full
+	logCh, sub := c.Eth().SubscribeLogs(ctx, nil, WithSubscriptionBuffer(1), WithSlowConsumerPolicy(SlowConsumerDrop))
+	srv.notify(t, mockLogEvent(0))
+	srv.notify(t, mockLogEvent(1))
+	srv.notify(t, mockLogEvent(2))
+
+	log := <-logCh
+	assert.Equal(t, uint64(0), log.LogIndex)
+
+	// the subscription ends when the context is done
+	cancel()
+
+	select {
+	case _, ok := <-sub.Err():
+		assert.False(t, ok)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+}
+
+func TestSubscribe_NotSupported(t *testing.T) {
+	c, err := NewClient("http://127.0.0.1:1")
+	assert.NoError(t, err)
+
+	logCh, sub := c.Eth().SubscribeLogs(context.Background(), &ethgo.LogFilter{})
+
+	assert.Error(t, <-sub.Err())
+	_, ok := <-logCh
+	assert.False(t, ok)
+}
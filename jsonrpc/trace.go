@@ -0,0 +1,571 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/umbracle/ethgo"
+)
+
+// Trace is the parity style trace namespace (i.e. Erigon, Nethermind or Reth)
+type Trace struct {
+	c *Client
+}
+
+// Trace returns the reference to the trace namespace
+func (c *Client) Trace() *Trace {
+	return c.endpoints.t
+}
+
+// WithContext returns the trace namespace of a client that uses the context for all its requests
+func (t *Trace) WithContext(ctx context.Context) *Trace {
+	return t.c.WithContext(ctx).Trace()
+}
+
+// TraceKind is the kind of action of a trace
+type TraceKind string
+
+const (
+	TraceKindCall    TraceKind = "call"
+	TraceKindCreate  TraceKind = "create"
+	TraceKindSuicide TraceKind = "suicide"
+	TraceKindReward  TraceKind = "reward"
+)
+
+// CallAction is the action of a call trace
+type CallAction struct {
+	// CallType is the type of the call (i.e. call, delegatecall or staticcall)
+	CallType string
+	From     ethgo.Address
+	To       ethgo.Address
+	Value    *big.Int
+	Gas      uint64
+	Input    []byte
+}
+
+// CallResult is the result of a call trace
+type CallResult struct {
+	GasUsed uint64
+	Output  []byte
+}
+
+// CreateAction is the action of a create trace
+type CreateAction struct {
+	From  ethgo.Address
+	Value *big.Int
+	Gas   uint64
+	Init  []byte
+}
+
+// CreateResult is the result of a create trace
+type CreateResult struct {
+	Address ethgo.Address
+	Code    []byte
+	GasUsed uint64
+}
+
+// SuicideAction is the action of a suicide (selfdestruct) trace
+type SuicideAction struct {
+	Address       ethgo.Address
+	RefundAddress ethgo.Address
+	Balance       *big.Int
+}
+
+// RewardAction is the action of a reward trace
+type RewardAction struct {
+	Author ethgo.Address
+	Value  *big.Int
+	// RewardType is either block or uncle
+	RewardType string
+}
+
+// LocalizedTrace is a trace of an action executed by a transaction or the
+// reward of a block. Only the action and result of its Type are set.
+type LocalizedTrace struct {
+	Type TraceKind
+
+	Call    *CallAction
+	Create  *CreateAction
+	Suicide *SuicideAction
+	Reward  *RewardAction
+
+	// CallResult or CreateResult are set if the action succeeded
+	CallResult   *CallResult
+	CreateResult *CreateResult
+
+	// Error is the error of the action if it failed (i.e. Reverted)
+	Error string
+
+	// Subtraces is the number of traces of the inner actions and TraceAddress
+	// the position of the trace in the tree of actions of the transaction
+	Subtraces    uint64
+	TraceAddress []uint64
+
+	// BlockHash, BlockNumber, TransactionHash and TransactionPosition are not
+	// set for the traces of trace_call and trace_replayTransaction. The rewards
+	// do not have a transaction either.
+	BlockHash           *ethgo.Hash
+	BlockNumber         uint64
+	TransactionHash     *ethgo.Hash
+	TransactionPosition *uint64
+}
+
+func (l *LocalizedTrace) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Type   TraceKind `json:"type"`
+		Action struct {
+			CallType      string          `json:"callType"`
+			From          ethgo.Address   `json:"from"`
+			To            ethgo.Address   `json:"to"`
+			Value         *ethgo.ArgBig   `json:"value"`
+			Gas           ethgo.ArgUint64 `json:"gas"`
+			Input         ethgo.ArgBytes  `json:"input"`
+			Init          ethgo.ArgBytes  `json:"init"`
+			Address       ethgo.Address   `json:"address"`
+			RefundAddress ethgo.Address   `json:"refundAddress"`
+			Balance       *ethgo.ArgBig   `json:"balance"`
+			Author        ethgo.Address   `json:"author"`
+			RewardType    string          `json:"rewardType"`
+		} `json:"action"`
+		Result *struct {
+			GasUsed ethgo.ArgUint64 `json:"gasUsed"`
+			Output  ethgo.ArgBytes  `json:"output"`
+			Address ethgo.Address   `json:"address"`
+			Code    ethgo.ArgBytes  `json:"code"`
+		} `json:"result"`
+		Error               string      `json:"error"`
+		Subtraces           uint64      `json:"subtraces"`
+		TraceAddress        []uint64    `json:"traceAddress"`
+		BlockHash           *ethgo.Hash `json:"blockHash"`
+		BlockNumber         uint64      `json:"blockNumber"`
+		TransactionHash     *ethgo.Hash `json:"transactionHash"`
+		TransactionPosition *uint64     `json:"transactionPosition"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*l = LocalizedTrace{
+		Type:                raw.Type,
+		Error:               raw.Error,
+		Subtraces:           raw.Subtraces,
+		TraceAddress:        raw.TraceAddress,
+		BlockHash:           raw.BlockHash,
+		BlockNumber:         raw.BlockNumber,
+		TransactionHash:     raw.TransactionHash,
+		TransactionPosition: raw.TransactionPosition,
+	}
+
+	action := raw.Action
+	switch raw.Type {
+	case TraceKindCall:
+		l.Call = &CallAction{
+			CallType: action.CallType,
+			From:     action.From,
+			To:       action.To,
+			Value:    argBigOrZero(action.Value),
+			Gas:      action.Gas.Uint64(),
+			Input:    action.Input,
+		}
+		if raw.Result != nil {
+			l.CallResult = &CallResult{
+				GasUsed: raw.Result.GasUsed.Uint64(),
+				Output:  raw.Result.Output,
+			}
+		}
+
+	case TraceKindCreate:
+		l.Create = &CreateAction{
+			From:  action.From,
+			Value: argBigOrZero(action.Value),
+			Gas:   action.Gas.Uint64(),
+			Init:  action.Init,
+		}
+		if raw.Result != nil {
+			l.CreateResult = &CreateResult{
+				Address: raw.Result.Address,
+				Code:    raw.Result.Code,
+				GasUsed: raw.Result.GasUsed.Uint64(),
+			}
+		}
+
+	case TraceKindSuicide:
+		l.Suicide = &SuicideAction{
+			Address:       action.Address,
+			RefundAddress: action.RefundAddress,
+			Balance:       argBigOrZero(action.Balance),
+		}
+
+	case TraceKindReward:
+		l.Reward = &RewardAction{
+			Author:     action.Author,
+			Value:      argBigOrZero(action.Value),
+			RewardType: action.RewardType,
+		}
+
+	default:
+		return fmt.Errorf("trace type '%s' not supported", raw.Type)
+	}
+	return nil
+}
+
+func argBigOrZero(b *ethgo.ArgBig) *big.Int {
+	if b == nil {
+		return new(big.Int)
+	}
+	return (*big.Int)(b)
+}
+
+// TraceType is a type of trace returned by trace_call and trace_replayTransaction
+type TraceType string
+
+const (
+	// TraceTypeTrace returns the traces of the actions of the transaction
+	TraceTypeTrace TraceType = "trace"
+
+	// TraceTypeVMTrace returns the trace of the execution of the evm
+	TraceTypeVMTrace TraceType = "vmTrace"
+
+	// TraceTypeStateDiff returns the changes in the state
+	TraceTypeStateDiff TraceType = "stateDiff"
+)
+
+// TraceResults are the traces of trace_call and trace_replayTransaction.
+// Only the types of traces requested are set.
+type TraceResults struct {
+	Output    []byte
+	Trace     []*LocalizedTrace
+	StateDiff StateDiff
+	VMTrace   *VMTrace
+}
+
+func (t *TraceResults) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Output    ethgo.ArgBytes    `json:"output"`
+		Trace     []*LocalizedTrace `json:"trace"`
+		StateDiff StateDiff         `json:"stateDiff"`
+		VMTrace   *VMTrace          `json:"vmTrace"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	t.Output = raw.Output
+	t.Trace = raw.Trace
+	t.StateDiff = raw.StateDiff
+	t.VMTrace = raw.VMTrace
+	return nil
+}
+
+// StateDiff are the changes in the state of the accounts modified by a transaction
+type StateDiff map[ethgo.Address]*AccountDiff
+
+// AccountDiff are the changes in the state of an account
+type AccountDiff struct {
+	Balance *BigDiff                 `json:"balance"`
+	Nonce   *BigDiff                 `json:"nonce"`
+	Code    *BytesDiff               `json:"code"`
+	Storage map[ethgo.Hash]*HashDiff `json:"storage"`
+}
+
+// DiffKind is the kind of change of a value in a state diff
+type DiffKind string
+
+const (
+	// DiffSame means the value did not change
+	DiffSame DiffKind = "="
+
+	// DiffAdded means the value was created
+	DiffAdded DiffKind = "+"
+
+	// DiffRemoved means the value was removed
+	DiffRemoved DiffKind = "-"
+
+	// DiffChanged means the value was modified
+	DiffChanged DiffKind = "*"
+)
+
+// decodeDiff decodes a change of a value in a state diff. The value is
+// either '=' or an object with the kind of change as the key. From is
+// nil if the value was added and To is nil if it was removed.
+func decodeDiff[T any](data []byte) (kind DiffKind, from *T, to *T, err error) {
+	var same string
+	if err = json.Unmarshal(data, &same); err == nil {
+		if DiffKind(same) != DiffSame {
+			err = fmt.Errorf("diff kind '%s' not expected", same)
+		}
+		return DiffSame, nil, nil, err
+	}
+
+	var raw map[DiffKind]json.RawMessage
+	if err = json.Unmarshal(data, &raw); err != nil {
+		return
+	}
+	if len(raw) != 1 {
+		return "", nil, nil, fmt.Errorf("diff expects one kind of change but found %d", len(raw))
+	}
+	for kind, val := range raw {
+		switch kind {
+		case DiffAdded:
+			to = new(T)
+			err = json.Unmarshal(val, to)
+		case DiffRemoved:
+			from = new(T)
+			err = json.Unmarshal(val, from)
+		case DiffChanged:
+			var change struct {
+				From *T `json:"from"`
+				To   *T `json:"to"`
+			}
+			err = json.Unmarshal(val, &change)
+			from, to = change.From, change.To
+		default:
+			err = fmt.Errorf("diff kind '%s' not expected", kind)
+		}
+		return kind, from, to, err
+	}
+	return
+}
+
+// BigDiff is the change of a number in a state diff
+type BigDiff struct {
+	Kind DiffKind
+	From *big.Int
+	To   *big.Int
+}
+
+func (b *BigDiff) UnmarshalJSON(data []byte) error {
+	kind, from, to, err := decodeDiff[ethgo.ArgBig](data)
+	if err != nil {
+		return err
+	}
+	*b = BigDiff{Kind: kind, From: (*big.Int)(from), To: (*big.Int)(to)}
+	return nil
+}
+
+// BytesDiff is the change of the code in a state diff
+type BytesDiff struct {
+	Kind DiffKind
+	From []byte
+	To   []byte
+}
+
+func (b *BytesDiff) UnmarshalJSON(data []byte) error {
+	kind, from, to, err := decodeDiff[ethgo.ArgBytes](data)
+	if err != nil {
+		return err
+	}
+	*b = BytesDiff{Kind: kind}
+	if from != nil {
+		b.From = *from
+	}
+	if to != nil {
+		b.To = *to
+	}
+	return nil
+}
+
+// HashDiff is the change of a storage slot in a state diff
+type HashDiff struct {
+	Kind DiffKind
+	From *ethgo.Hash
+	To   *ethgo.Hash
+}
+
+func (h *HashDiff) UnmarshalJSON(data []byte) error {
+	kind, from, to, err := decodeDiff[ethgo.Hash](data)
+	if err != nil {
+		return err
+	}
+	*h = HashDiff{Kind: kind, From: from, To: to}
+	return nil
+}
+
+// VMTrace is the trace of the execution of the code of a call
+type VMTrace struct {
+	Code []byte
+	Ops  []*VMOperation
+}
+
+// VMOperation is an instruction executed by the evm
+type VMOperation struct {
+	Pc   uint64
+	Cost uint64
+
+	// Ex is the result of the instruction, it is nil if the instruction failed
+	Ex *VMExecutedOperation
+
+	// Sub is the trace of the inner call made by the instruction, if any
+	Sub *VMTrace
+}
+
+// VMExecutedOperation is the result of an instruction executed by the evm
+type VMExecutedOperation struct {
+	// Used is the gas available after the instruction
+	Used uint64
+
+	// Push are the values pushed to the stack
+	Push [][]byte
+
+	// Mem is the change in the memory, if any
+	Mem *VMMemoryDiff
+
+	// Store is the change in the storage, if any
+	Store *VMStorageDiff
+}
+
+// VMMemoryDiff is a change in the memory
+type VMMemoryDiff struct {
+	Off  uint64
+	Data []byte
+}
+
+// VMStorageDiff is a change in the storage
+type VMStorageDiff struct {
+	Key []byte
+	Val []byte
+}
+
+func (v *VMTrace) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Code ethgo.ArgBytes `json:"code"`
+		Ops  []struct {
+			Pc   uint64   `json:"pc"`
+			Cost uint64   `json:"cost"`
+			Sub  *VMTrace `json:"sub"`
+			Ex   *struct {
+				Used uint64           `json:"used"`
+				Push []ethgo.ArgBytes `json:"push"`
+				Mem  *struct {
+					Off  uint64         `json:"off"`
+					Data ethgo.ArgBytes `json:"data"`
+				} `json:"mem"`
+				Store *struct {
+					Key ethgo.ArgBytes `json:"key"`
+					Val ethgo.ArgBytes `json:"val"`
+				} `json:"store"`
+			} `json:"ex"`
+		} `json:"ops"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	v.Code = raw.Code
+	v.Ops = []*VMOperation{}
+	for _, rawOp := range raw.Ops {
+		op := &VMOperation{
+			Pc:   rawOp.Pc,
+			Cost: rawOp.Cost,
+			Sub:  rawOp.Sub,
+		}
+		if ex := rawOp.Ex; ex != nil {
+			op.Ex = &VMExecutedOperation{
+				Used: ex.Used,
+				Push: [][]byte{},
+			}
+			for _, val := range ex.Push {
+				op.Ex.Push = append(op.Ex.Push, val)
+			}
+			if ex.Mem != nil {
+				op.Ex.Mem = &VMMemoryDiff{Off: ex.Mem.Off, Data: ex.Mem.Data}
+			}
+			if ex.Store != nil {
+				op.Ex.Store = &VMStorageDiff{Key: ex.Store.Key, Val: ex.Store.Val}
+			}
+		}
+		v.Ops = append(v.Ops, op)
+	}
+	return nil
+}
+
+// TraceFilter is the filter of the traces of trace_filter
+type TraceFilter struct {
+	FromBlock   *ethgo.BlockNumber
+	ToBlock     *ethgo.BlockNumber
+	FromAddress []ethgo.Address
+	ToAddress   []ethgo.Address
+
+	// After is the number of traces to skip and Count
+	// the maximum number of traces to return
+	After *uint64
+	Count *uint64
+}
+
+func (t *TraceFilter) MarshalJSON() ([]byte, error) {
+	obj := map[string]interface{}{}
+	if t.FromBlock != nil {
+		obj["fromBlock"] = t.FromBlock.String()
+	}
+	if t.ToBlock != nil {
+		obj["toBlock"] = t.ToBlock.String()
+	}
+	if len(t.FromAddress) != 0 {
+		obj["fromAddress"] = t.FromAddress
+	}
+	if len(t.ToAddress) != 0 {
+		obj["toAddress"] = t.ToAddress
+	}
+	if t.After != nil {
+		obj["after"] = *t.After
+	}
+	if t.Count != nil {
+		obj["count"] = *t.Count
+	}
+	return json.Marshal(obj)
+}
+
+// Block returns the traces of the transactions and the rewards of a block
+func (t *Trace) Block(block ethgo.BlockNumber) ([]*LocalizedTrace, error) {
+	var out []*LocalizedTrace
+	if err := t.c.Call("trace_block", &out, block.String()); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Transaction returns the traces of a transaction
+func (t *Trace) Transaction(hash ethgo.Hash) ([]*LocalizedTrace, error) {
+	var out []*LocalizedTrace
+	if err := t.c.Call("trace_transaction", &out, hash); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Filter returns the traces that match the filter
+func (t *Trace) Filter(filter *TraceFilter) ([]*LocalizedTrace, error) {
+	var out []*LocalizedTrace
+	if err := t.c.Call("trace_filter", &out, filter); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReplayTransaction executes again a transaction and returns the types of traces
+func (t *Trace) ReplayTransaction(hash ethgo.Hash, types ...TraceType) (*TraceResults, error) {
+	var out *TraceResults
+	if err := t.c.Call("trace_replayTransaction", &out, hash, encodeTraceTypes(types)); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Call executes a call at the given block and returns the types of traces
+func (t *Trace) Call(msg *ethgo.CallMsg, block ethgo.BlockNumberOrHash, types ...TraceType) (*TraceResults, error) {
+	var out *TraceResults
+	if err := t.c.Call("trace_call", &out, msg, encodeTraceTypes(types), block.Location()); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// encodeTraceTypes returns the trace types or only
+// the traces of the actions if no type is requested
+func encodeTraceTypes(types []TraceType) []TraceType {
+	if len(types) == 0 {
+		return []TraceType{TraceTypeTrace}
+	}
+	return types
+}
@@ -0,0 +1,206 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/ethgo"
+)
+
+const testTraces = `[
+	{
+		"action": {"callType": "call", "from": "0x0100000000000000000000000000000000000000", "gas": "0x10", "input": "0x01", "to": "0x0200000000000000000000000000000000000000", "value": "0xa"},
+		"blockHash": "0x0100000000000000000000000000000000000000000000000000000000000000",
+		"blockNumber": 100,
+		"result": {"gasUsed": "0x5", "output": "0x02"},
+		"subtraces": 1,
+		"traceAddress": [],
+		"transactionHash": "0x0200000000000000000000000000000000000000000000000000000000000000",
+		"transactionPosition": 3,
+		"type": "call"
+	},
+	{
+		"action": {"from": "0x0200000000000000000000000000000000000000", "gas": "0x8", "init": "0x6000", "value": "0x0"},
+		"blockHash": "0x0100000000000000000000000000000000000000000000000000000000000000",
+		"blockNumber": 100,
+		"result": {"address": "0x0300000000000000000000000000000000000000", "code": "0x00", "gasUsed": "0x4"},
+		"subtraces": 0,
+		"traceAddress": [0],
+		"transactionHash": "0x0200000000000000000000000000000000000000000000000000000000000000",
+		"transactionPosition": 3,
+		"type": "create"
+	},
+	{
+		"action": {"address": "0x0300000000000000000000000000000000000000", "balance": "0x1", "refundAddress": "0x0100000000000000000000000000000000000000"},
+		"blockHash": "0x0100000000000000000000000000000000000000000000000000000000000000",
+		"blockNumber": 100,
+		"result": null,
+		"subtraces": 0,
+		"traceAddress": [1],
+		"transactionHash": "0x0200000000000000000000000000000000000000000000000000000000000000",
+		"transactionPosition": 3,
+		"type": "suicide"
+	},
+	{
+		"action": {"callType": "call", "from": "0x0100000000000000000000000000000000000000", "gas": "0x10", "input": "0x", "to": "0x0200000000000000000000000000000000000000", "value": "0x0"},
+		"blockHash": "0x0100000000000000000000000000000000000000000000000000000000000000",
+		"blockNumber": 100,
+		"error": "Reverted",
+		"subtraces": 0,
+		"traceAddress": [],
+		"transactionHash": "0x0300000000000000000000000000000000000000000000000000000000000000",
+		"transactionPosition": 4,
+		"type": "call"
+	},
+	{
+		"action": {"author": "0x0400000000000000000000000000000000000000", "rewardType": "block", "value": "0x1bc16d674ec80000"},
+		"blockHash": "0x0100000000000000000000000000000000000000000000000000000000000000",
+		"blockNumber": 100,
+		"result": null,
+		"subtraces": 0,
+		"traceAddress": [],
+		"transactionHash": null,
+		"transactionPosition": null,
+		"type": "reward"
+	}
+]`
+
+func TestTrace_Block(t *testing.T) {
+	c, srv := newMockClient(t, map[string]string{
+		"trace_block":       testTraces,
+		"trace_transaction": testTraces,
+	})
+
+	traces, err := c.Trace().Block(ethgo.BlockNumber(100))
+	assert.NoError(t, err)
+	assert.Equal(t, `["0x64"]`, string(srv.params["trace_block"]))
+	assert.Len(t, traces, 5)
+
+	call := traces[0]
+	assert.Equal(t, TraceKindCall, call.Type)
+	assert.Equal(t, &CallAction{
+		CallType: "call",
+		From:     ethgo.Address{0x1},
+		To:       ethgo.Address{0x2},
+		Value:    big.NewInt(10),
+		Gas:      16,
+		Input:    []byte{0x1},
+	}, call.Call)
+	assert.Equal(t, &CallResult{GasUsed: 5, Output: []byte{0x2}}, call.CallResult)
+	assert.Equal(t, uint64(100), call.BlockNumber)
+	assert.Equal(t, ethgo.Hash{0x2}, *call.TransactionHash)
+	assert.Equal(t, uint64(3), *call.TransactionPosition)
+	assert.Equal(t, uint64(1), call.Subtraces)
+
+	create := traces[1]
+	assert.Equal(t, TraceKindCreate, create.Type)
+	assert.Equal(t, []byte{0x60, 0x0}, create.Create.Init)
+	assert.Equal(t, ethgo.Address{0x3}, create.CreateResult.Address)
+	assert.Equal(t, []uint64{0}, create.TraceAddress)
+
+	suicide := traces[2]
+	assert.Equal(t, &SuicideAction{Address: ethgo.Address{0x3}, RefundAddress: ethgo.Address{0x1}, Balance: big.NewInt(1)}, suicide.Suicide)
+
+	reverted := traces[3]
+	assert.Equal(t, "Reverted", reverted.Error)
+	assert.Nil(t, reverted.CallResult)
+
+	reward := traces[4]
+	assert.Equal(t, "block", reward.Reward.RewardType)
+	assert.Equal(t, ethgo.Address{0x4}, reward.Reward.Author)
+	assert.Nil(t, reward.TransactionHash)
+
+	_, err = c.Trace().Transaction(ethgo.Hash{0x2})
+	assert.NoError(t, err)
+	assert.Equal(t, `["0x0200000000000000000000000000000000000000000000000000000000000000"]`, string(srv.params["trace_transaction"]))
+}
+
+func TestTrace_Filter(t *testing.T) {
+	c, srv := newMockClient(t, map[string]string{
+		"trace_filter": "[]",
+	})
+
+	from, to := ethgo.BlockNumber(1), ethgo.Latest
+	count := uint64(10)
+
+	traces, err := c.Trace().Filter(&TraceFilter{
+		FromBlock: &from,
+		ToBlock:   &to,
+		ToAddress: []ethgo.Address{{0x1}},
+		Count:     &count,
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, traces)
+	assert.Equal(t, `[{"count":10,"fromBlock":"0x1","toAddress":["0x0100000000000000000000000000000000000000"],"toBlock":"latest"}]`, string(srv.params["trace_filter"]))
+}
+
+func TestTrace_ReplayTransaction(t *testing.T) {
+	c, srv := newMockClient(t, map[string]string{
+		"trace_replayTransaction": `{
+			"output": "0x01",
+			"stateDiff": {
+				"0x0100000000000000000000000000000000000000": {
+					"balance": {"*": {"from": "0x10", "to": "0x8"}},
+					"code": "=",
+					"nonce": {"+": "0x1"},
+					"storage": {
+						"0x0000000000000000000000000000000000000000000000000000000000000001": {"-": "0x0000000000000000000000000000000000000000000000000000000000000002"}
+					}
+				}
+			},
+			"trace": [],
+			"vmTrace": {
+				"code": "0x6001",
+				"ops": [
+					{"cost": 3, "ex": {"mem": null, "push": ["0x1"], "store": {"key": "0x1", "val": "0x2"}, "used": 100}, "pc": 0, "sub": {"code": "0x", "ops": []}},
+					{"cost": 1, "ex": null, "pc": 2, "sub": null}
+				]
+			}
+		}`,
+		"trace_call": `{"output": "0x", "stateDiff": null, "trace": [], "vmTrace": null}`,
+	})
+
+	res, err := c.Trace().ReplayTransaction(ethgo.Hash{0x1}, TraceTypeStateDiff, TraceTypeVMTrace)
+	assert.NoError(t, err)
+	assert.Equal(t, `["0x0100000000000000000000000000000000000000000000000000000000000000",["stateDiff","vmTrace"]]`, string(srv.params["trace_replayTransaction"]))
+	assert.Equal(t, []byte{0x1}, res.Output)
+
+	diff := res.StateDiff[ethgo.Address{0x1}]
+	assert.Equal(t, &BigDiff{Kind: DiffChanged, From: big.NewInt(16), To: big.NewInt(8)}, diff.Balance)
+	assert.Equal(t, &BytesDiff{Kind: DiffSame}, diff.Code)
+	assert.Equal(t, &BigDiff{Kind: DiffAdded, To: big.NewInt(1)}, diff.Nonce)
+
+	slot := diff.Storage[ethgo.HexToHash("0x1")]
+	assert.Equal(t, DiffRemoved, slot.Kind)
+	assert.Equal(t, ethgo.HexToHash("0x2"), *slot.From)
+	assert.Nil(t, slot.To)
+
+	vm := res.VMTrace
+	assert.Equal(t, []byte{0x60, 0x1}, vm.Code)
+	assert.Len(t, vm.Ops, 2)
+	assert.Equal(t, uint64(100), vm.Ops[0].Ex.Used)
+	assert.Equal(t, [][]byte{{0x1}}, vm.Ops[0].Ex.Push)
+	assert.Equal(t, &VMStorageDiff{Key: []byte{0x1}, Val: []byte{0x2}}, vm.Ops[0].Ex.Store)
+	assert.NotNil(t, vm.Ops[0].Sub)
+	assert.Nil(t, vm.Ops[1].Ex)
+
+	// trace_call returns the traces of the actions by default
+	res, err = c.Trace().Call(&ethgo.CallMsg{To: &addr0}, ethgo.Latest)
+	assert.NoError(t, err)
+	assert.Nil(t, res.StateDiff)
+	assert.Nil(t, res.VMTrace)
+
+	var params []json.RawMessage
+	assert.NoError(t, json.Unmarshal(srv.params["trace_call"], &params))
+	assert.Equal(t, `["trace"]`, string(params[1]))
+	assert.Equal(t, `"latest"`, string(params[2]))
+}
+
+func TestTrace_InvalidDiff(t *testing.T) {
+	var diff BigDiff
+	assert.Error(t, json.Unmarshal([]byte(`"?"`), &diff))
+	assert.Error(t, json.Unmarshal([]byte(`{"+": "0x1", "-": "0x2"}`), &diff))
+	assert.Error(t, json.Unmarshal([]byte(`{"?": "0x1"}`), &diff))
+}
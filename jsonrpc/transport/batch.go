@@ -0,0 +1,85 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/umbracle/ethgo/jsonrpc/codec"
+)
+
+// BatchElem is a single jsonrpc request in a batch
+type BatchElem struct {
+	// Method is the jsonrpc method
+	Method string
+
+	// Params are the arguments of the method
+	Params []interface{}
+
+	// Result is the object where the result is decoded
+	Result interface{}
+
+	// Error is set if the server returns an error for this request
+	// or if the result cannot be decoded
+	Error error
+}
+
+// BatchTransport is a transport that sends many requests in a single batch
+type BatchTransport interface {
+	// BatchCallContext sends all the elements in a single batch request. It only
+	// returns an error if the request fails, errors of the individual requests are
+	// set in each element.
+	BatchCallContext(ctx context.Context, elems []BatchElem) error
+}
+
+// encodeBatch encodes the elements with the given ids as a jsonrpc batch request
+func encodeBatch(elems []BatchElem, ids []uint64) ([]byte, error) {
+	requests := make([]codec.Request, len(elems))
+	for indx, elem := range elems {
+		request := codec.Request{
+			JsonRPC: "2.0",
+			ID:      ids[indx],
+			Method:  elem.Method,
+		}
+		if len(elem.Params) > 0 {
+			data, err := json.Marshal(elem.Params)
+			if err != nil {
+				return nil, err
+			}
+			request.Params = data
+		} else {
+			request.Params = []byte{'[', ']'}
+		}
+		requests[indx] = request
+	}
+	return json.Marshal(requests)
+}
+
+// setBatchResult decodes the response of a batch element. The error of a
+// previous attempt of the batch (i.e. a retry) is replaced.
+func setBatchResult(elem *BatchElem, result json.RawMessage, err error) {
+	elem.Error = err
+	if err != nil {
+		return
+	}
+	if elem.Result == nil {
+		return
+	}
+	if err := json.Unmarshal(result, elem.Result); err != nil {
+		elem.Error = err
+	}
+}
+
+// errBatchMissingResponse is set in the elements without a response in the batch
+func errBatchMissingResponse(id uint64) error {
+	return fmt.Errorf("missing response for batch request %d", id)
+}
+
+// batchMethods returns the methods of the batch requests
+func batchMethods(elems []BatchElem) []string {
+	methods := make([]string, len(elems))
+	for indx, elem := range elems {
+		methods[indx] = elem.Method
+	}
+	return methods
+}
@@ -1,37 +1,95 @@
 package transport
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/umbracle/ethgo/jsonrpc/codec"
-	"github.com/valyala/fasthttp"
 )
 
 // HTTP is an http transport
 type HTTP struct {
 	addr    string
-	client  *fasthttp.Client
+	client  *http.Client
 	headers map[string]string
+
+	// jwtSecret signs every request with a new token if set
+	jwtSecret []byte
 }
 
+const (
+	// defaultMaxConnsPerHost is the default maximum number of
+	// connections with the host (the same as fasthttp)
+	defaultMaxConnsPerHost = 512
+
+	// defaultMaxIdleConnsPerHost is the number of idle connections
+	// kept with the host to be reused by the next requests
+	defaultMaxIdleConnsPerHost = 100
+)
+
 func newHTTP(addr string, headers map[string]string) *HTTP {
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.MaxConnsPerHost = defaultMaxConnsPerHost
+	tr.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+
 	return &HTTP{
-		addr: addr,
-		client: &fasthttp.Client{
-			DialDualStack: true,
-		},
+		addr:    addr,
+		client:  &http.Client{Transport: tr},
 		headers: headers,
 	}
 }
 
+// HTTPError is the error returned when the http response has a non 200 status code
+type HTTPError struct {
+	StatusCode int
+	Body       []byte
+
+	// RetryAfter is the delay requested by the server
+	// in the 'Retry-After' header, if any
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("status code is %d. response = %s", e.StatusCode, string(e.Body))
+}
+
+// parseRetryAfter parses the value of the 'Retry-After' header
+// either as a number of seconds or as an http date
+func parseRetryAfter(val string, now time.Time) time.Duration {
+	if val == "" {
+		return 0
+	}
+	if secs, err := strconv.ParseUint(val, 10, 32); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if date, err := http.ParseTime(val); err == nil {
+		if delay := date.Sub(now); delay > 0 {
+			return delay
+		}
+	}
+	return 0
+}
+
 // Close implements the transport interface
 func (h *HTTP) Close() error {
+	h.client.CloseIdleConnections()
 	return nil
 }
 
 // Call implements the transport interface
 func (h *HTTP) Call(method string, out interface{}, params ...interface{}) error {
+	return h.CallContext(context.Background(), method, out, params...)
+}
+
+// CallContext implements the ContextTransport interface
+func (h *HTTP) CallContext(ctx context.Context, method string, out interface{}, params ...interface{}) error {
 	// Encode json-rpc request
 	request := codec.Request{
 		JsonRPC: "2.0",
@@ -51,31 +109,14 @@ func (h *HTTP) Call(method string, out interface{}, params ...interface{}) error
 		return err
 	}
 
-	req := fasthttp.AcquireRequest()
-	res := fasthttp.AcquireResponse()
-
-	defer fasthttp.ReleaseRequest(req)
-	defer fasthttp.ReleaseResponse(res)
-
-	req.SetRequestURI(h.addr)
-	req.Header.SetMethod("POST")
-	req.Header.SetContentType("application/json")
-	for k, v := range h.headers {
-		req.Header.Add(k, v)
-	}
-	req.SetBody(raw)
-
-	if err := h.client.Do(req, res); err != nil {
+	body, err := h.do(ctx, raw)
+	if err != nil {
 		return err
 	}
 
-	if sc := res.StatusCode(); sc != fasthttp.StatusOK {
-		return fmt.Errorf("status code is %d. response = %s", sc, string(res.Body()))
-	}
-
 	// Decode json-rpc response
 	var response codec.Response
-	if err := json.Unmarshal(res.Body(), &response); err != nil {
+	if err := json.Unmarshal(body, &response); err != nil {
 		return err
 	}
 	if response.Error != nil {
@@ -88,7 +129,103 @@ func (h *HTTP) Call(method string, out interface{}, params ...interface{}) error
 	return nil
 }
 
+// BatchCallContext implements the BatchTransport interface
+func (h *HTTP) BatchCallContext(ctx context.Context, elems []BatchElem) error {
+	ids := make([]uint64, len(elems))
+	for indx := range elems {
+		ids[indx] = uint64(indx + 1)
+	}
+	raw, err := encodeBatch(elems, ids)
+	if err != nil {
+		return err
+	}
+
+	body, err := h.do(ctx, raw)
+	if err != nil {
+		return err
+	}
+
+	// Decode json-rpc responses. The server replies with a single
+	// response object if the whole batch is rejected.
+	body = bytes.TrimLeft(body, " \t\r\n")
+	if len(body) != 0 && body[0] == '{' {
+		var response codec.Response
+		if err := json.Unmarshal(body, &response); err != nil {
+			return err
+		}
+		if response.Error != nil {
+			return response.Error
+		}
+		return fmt.Errorf("batch request expects an array response")
+	}
+
+	var responses []codec.Response
+	if err := json.Unmarshal(body, &responses); err != nil {
+		return err
+	}
+	found := make([]bool, len(elems))
+	for _, response := range responses {
+		if response.ID == 0 || response.ID > uint64(len(elems)) {
+			continue
+		}
+		indx := response.ID - 1
+		if response.Error != nil {
+			setBatchResult(&elems[indx], nil, response.Error)
+		} else {
+			setBatchResult(&elems[indx], response.Result, nil)
+		}
+		found[indx] = true
+	}
+	for indx, ok := range found {
+		if !ok {
+			elems[indx].Error = errBatchMissingResponse(ids[indx])
+		}
+	}
+	return nil
+}
+
+// do sends the body in a POST request and returns the body of the response.
+// The request is aborted and its connection closed if the context is done.
+func (h *HTTP) do(ctx context.Context, raw []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.addr, bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range h.headers {
+		req.Header.Add(k, v)
+	}
+	if h.jwtSecret != nil {
+		req.Header.Set("Authorization", jwtAuthorization(h.jwtSecret))
+	}
+
+	res, err := h.client.Do(req)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, &HTTPError{
+			StatusCode: res.StatusCode,
+			Body:       body,
+			RetryAfter: parseRetryAfter(res.Header.Get("Retry-After"), time.Now()),
+		}
+	}
+	return body, nil
+}
+
 // SetMaxConnsPerHost sets the maximum number of connections that can be established with a host
 func (h *HTTP) SetMaxConnsPerHost(count int) {
-	h.client.MaxConnsPerHost = count
+	h.client.Transport.(*http.Transport).MaxConnsPerHost = count
 }
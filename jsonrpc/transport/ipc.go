@@ -5,19 +5,20 @@ import (
 	"net"
 )
 
-func newIPC(addr string) (Transport, error) {
-	conn, err := net.Dial("unix", addr)
-	if err != nil {
-		return nil, err
-	}
-
-	codec := &ipcCodec{
-		buf:  json.RawMessage{},
-		conn: conn,
-		dec:  json.NewDecoder(conn),
+func newIPC(addr string, reconnect *ReconnectConfig) (Transport, error) {
+	dial := func() (Codec, error) {
+		conn, err := net.Dial("unix", addr)
+		if err != nil {
+			return nil, err
+		}
+		codec := &ipcCodec{
+			buf:  json.RawMessage{},
+			conn: conn,
+			dec:  json.NewDecoder(conn),
+		}
+		return codec, nil
 	}
-
-	return newStream(codec)
+	return newStream(dial, reconnect)
 }
 
 type ipcCodec struct {
@@ -0,0 +1,57 @@
+package transport
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// jwtSecretLength is the length of the secret shared between
+// the execution and consensus clients
+const jwtSecretLength = 32
+
+// ReadJWTSecret reads the hex encoded secret used to authenticate
+// the requests with the execution clients (i.e. the engine api)
+func ReadJWTSecret(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseJWTSecret(string(data))
+}
+
+// ParseJWTSecret decodes a hex encoded jwt secret with or without 0x prefix
+func ParseJWTSecret(str string) ([]byte, error) {
+	str = strings.TrimPrefix(strings.TrimSpace(str), "0x")
+	secret, err := hex.DecodeString(str)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jwt secret: %v", err)
+	}
+	if len(secret) != jwtSecretLength {
+		return nil, fmt.Errorf("invalid jwt secret: expected %d bytes but found %d", jwtSecretLength, len(secret))
+	}
+	return secret, nil
+}
+
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// newJWTToken returns an HS256 token with the 'iat' claim set to now
+func newJWTToken(secret []byte, now time.Time) string {
+	claims := `{"iat":` + strconv.FormatInt(now.Unix(), 10) + `}`
+	msg := jwtHeader + "." + base64.RawURLEncoding.EncodeToString([]byte(claims))
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(msg))
+	return msg + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// jwtAuthorization returns the value of the 'Authorization' header
+func jwtAuthorization(secret []byte) string {
+	return "Bearer " + newJWTToken(secret, time.Now())
+}
@@ -0,0 +1,92 @@
+package transport
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// verifyJWTToken checks the signature of the token and returns the 'iat' claim
+func verifyJWTToken(t *testing.T, secret []byte, token string) int64 {
+	parts := strings.Split(token, ".")
+	assert.Len(t, parts, 3)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), parts[2])
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	data, err := base64.RawURLEncoding.DecodeString(parts[0])
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(data, &header))
+	assert.Equal(t, "HS256", header.Alg)
+
+	var claims struct {
+		Iat int64 `json:"iat"`
+	}
+	data, err = base64.RawURLEncoding.DecodeString(parts[1])
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(data, &claims))
+	return claims.Iat
+}
+
+func TestJWT_ReadSecret(t *testing.T) {
+	secret := strings.Repeat("ab", 32)
+
+	path := filepath.Join(t.TempDir(), "jwt.hex")
+	assert.NoError(t, os.WriteFile(path, []byte("0x"+secret+"\n"), 0600))
+
+	buf, err := ReadJWTSecret(path)
+	assert.NoError(t, err)
+	assert.Len(t, buf, 32)
+	assert.Equal(t, byte(0xab), buf[0])
+
+	_, err = ParseJWTSecret(secret)
+	assert.NoError(t, err)
+
+	_, err = ParseJWTSecret("0xabcd")
+	assert.Error(t, err)
+
+	_, err = ParseJWTSecret("0xzz")
+	assert.Error(t, err)
+
+	_, err = ReadJWTSecret(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
+
+func TestJWT_HTTP(t *testing.T) {
+	secret := []byte(strings.Repeat("a", 32))
+
+	tokens := []string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		tokens = append(tokens, token)
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+	}))
+	defer srv.Close()
+
+	tt, err := NewTransportWithConfig(srv.URL, &Config{JWTSecret: secret})
+	assert.NoError(t, err)
+
+	var out string
+	assert.NoError(t, tt.Call("engine_exchangeCapabilities", &out))
+	assert.NoError(t, tt.Call("engine_exchangeCapabilities", &out))
+
+	assert.Len(t, tokens, 2)
+	for _, token := range tokens {
+		iat := verifyJWTToken(t, secret, token)
+		assert.InDelta(t, time.Now().Unix(), iat, 5)
+	}
+}
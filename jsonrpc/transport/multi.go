@@ -0,0 +1,476 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Strategy is the order in which the endpoints of a multi transport are used
+type Strategy int
+
+const (
+	// StrategyFailover uses the healthy endpoint with the highest priority
+	// and moves to the next one if the request fails
+	StrategyFailover Strategy = iota
+
+	// StrategyRoundRobin spreads the requests among the healthy endpoints
+	StrategyRoundRobin
+)
+
+// Endpoint is one of the transports of a multi transport
+type Endpoint struct {
+	// Name is the unique name of the endpoint
+	Name string
+
+	// Transport is the transport to the endpoint
+	Transport Transport
+
+	// Priority is the priority of the endpoint with the failover
+	// strategy. Endpoints with a lower value are used first.
+	Priority int
+}
+
+// Route sends the methods with a given prefix only to some endpoints
+// (i.e. 'debug_' or 'eth_getLogs' requests to archive nodes)
+type Route struct {
+	// Prefix is the prefix of the methods of the route
+	Prefix string
+
+	// Endpoints are the names of the endpoints of the route
+	Endpoints []string
+}
+
+// MultiConfig is the configuration of the multi transport
+type MultiConfig struct {
+	// Strategy is the order in which the endpoints are used
+	Strategy Strategy
+
+	// Routes restrict the endpoints of some methods. If several routes
+	// match a method, the one with the longest prefix is used.
+	Routes []Route
+
+	// HealthInterval is the interval between health checks. The
+	// health checks are disabled if it is zero.
+	HealthInterval time.Duration
+
+	// HealthTimeout is the timeout of the health check request
+	HealthTimeout time.Duration
+
+	// MaxBlockLag is the number of blocks an endpoint can be behind the
+	// highest block of all the endpoints before it is considered unhealthy
+	MaxBlockLag uint64
+
+	// Quorum is the number of endpoints that must return the same result
+	// for the quorum methods. Quorum is disabled if it is lower than 2.
+	Quorum int
+
+	// QuorumMethods are the methods that require a quorum. If empty, all
+	// the methods require a quorum. Batch requests never require a quorum.
+	QuorumMethods []string
+
+	// Retryable returns true if a failed request should be sent to the next
+	// endpoint. By default the errors retryable by RetryConfig are used.
+	Retryable func(err error) bool
+
+	// FailoverNonIdempotent also sends the failed requests of the non
+	// idempotent methods (i.e. 'eth_sendRawTransaction') to the next endpoint.
+	// The request may be executed twice if it reached the first endpoint.
+	FailoverNonIdempotent bool
+}
+
+// DefaultMultiConfig returns the default configuration of the multi transport
+func DefaultMultiConfig() *MultiConfig {
+	return &MultiConfig{
+		Strategy:       StrategyFailover,
+		HealthInterval: 10 * time.Second,
+		HealthTimeout:  5 * time.Second,
+		MaxBlockLag:    5,
+	}
+}
+
+// ErrNoQuorum is returned when not enough endpoints agree on the result
+var ErrNoQuorum = fmt.Errorf("no quorum")
+
+type endpointState struct {
+	*Endpoint
+
+	lock    sync.RWMutex
+	healthy bool
+	head    uint64
+}
+
+func (e *endpointState) isHealthy() bool {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+
+	return e.healthy
+}
+
+func (e *endpointState) setHealth(healthy bool, head uint64) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.healthy = healthy
+	e.head = head
+}
+
+// multi is a transport that sends the requests to several endpoints
+type multi struct {
+	config    *MultiConfig
+	endpoints []*endpointState
+	byName    map[string]*endpointState
+	seq       uint64
+
+	closeCh   chan struct{}
+	closeOnce sync.Once
+}
+
+// NewMulti creates a transport that sends the requests to several endpoints
+// with failover, load balancing, routing by method and quorum reads. The
+// subscriptions use the first endpoint (by priority) that supports them.
+func NewMulti(endpoints []*Endpoint, config *MultiConfig) (Transport, error) {
+	if config == nil {
+		config = DefaultMultiConfig()
+	}
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("no endpoints")
+	}
+	if config.Quorum > len(endpoints) {
+		return nil, fmt.Errorf("quorum %d is larger than the number of endpoints %d", config.Quorum, len(endpoints))
+	}
+
+	m := &multi{
+		config:  config,
+		byName:  map[string]*endpointState{},
+		closeCh: make(chan struct{}),
+	}
+	for _, e := range endpoints {
+		if e.Transport == nil {
+			return nil, fmt.Errorf("endpoint '%s' without transport", e.Name)
+		}
+		if _, ok := m.byName[e.Name]; ok {
+			return nil, fmt.Errorf("endpoint '%s' is duplicated", e.Name)
+		}
+		state := &endpointState{Endpoint: e, healthy: true}
+		m.endpoints = append(m.endpoints, state)
+		m.byName[e.Name] = state
+	}
+	for _, route := range config.Routes {
+		for _, name := range route.Endpoints {
+			if _, ok := m.byName[name]; !ok {
+				return nil, fmt.Errorf("route '%s' with unknown endpoint '%s'", route.Prefix, name)
+			}
+		}
+	}
+	sort.SliceStable(m.endpoints, func(i, j int) bool {
+		return m.endpoints[i].Priority < m.endpoints[j].Priority
+	})
+
+	if config.HealthInterval != 0 {
+		m.checkHealth()
+		go m.runHealthChecks()
+	}
+
+	for _, e := range m.endpoints {
+		if _, ok := e.Transport.(PubSubTransport); ok {
+			return withPubSub(m, e.Transport), nil
+		}
+	}
+	return m, nil
+}
+
+func (m *multi) runHealthChecks() {
+	ticker := time.NewTicker(m.config.HealthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.checkHealth()
+		case <-m.closeCh:
+			return
+		}
+	}
+}
+
+// checkHealth queries the head of all the endpoints. An endpoint is healthy
+// if it replies and it is not too far behind the highest head.
+func (m *multi) checkHealth() {
+	heads := make([]uint64, len(m.endpoints))
+	errs := make([]error, len(m.endpoints))
+
+	var wg sync.WaitGroup
+	for indx, e := range m.endpoints {
+		wg.Add(1)
+		go func(indx int, e *endpointState) {
+			defer wg.Done()
+
+			ctx := context.Background()
+			if m.config.HealthTimeout != 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, m.config.HealthTimeout)
+				defer cancel()
+			}
+
+			var out string
+			if err := CallContext(ctx, e.Transport, "eth_blockNumber", &out); err != nil {
+				errs[indx] = err
+				return
+			}
+			heads[indx], errs[indx] = strconv.ParseUint(strings.TrimPrefix(out, "0x"), 16, 64)
+		}(indx, e)
+	}
+	wg.Wait()
+
+	maxHead := uint64(0)
+	for indx, head := range heads {
+		if errs[indx] == nil && head > maxHead {
+			maxHead = head
+		}
+	}
+	for indx, e := range m.endpoints {
+		healthy := errs[indx] == nil && heads[indx]+m.config.MaxBlockLag >= maxHead
+		e.setHealth(healthy, heads[indx])
+	}
+}
+
+// route returns the endpoints that can serve the method
+func (m *multi) route(method string) []*endpointState {
+	var match *Route
+	for indx, route := range m.config.Routes {
+		if strings.HasPrefix(method, route.Prefix) {
+			if match == nil || len(route.Prefix) > len(match.Prefix) {
+				match = &m.config.Routes[indx]
+			}
+		}
+	}
+	if match == nil {
+		return m.endpoints
+	}
+
+	// keep the priority order
+	res := []*endpointState{}
+	for _, e := range m.endpoints {
+		for _, name := range match.Endpoints {
+			if e.Name == name {
+				res = append(res, e)
+			}
+		}
+	}
+	return res
+}
+
+// candidates returns the endpoints in the order they have to be used for the
+// methods. Unhealthy endpoints are only used after all the healthy ones fail.
+func (m *multi) candidates(methods ...string) []*endpointState {
+	endpoints := m.endpoints
+	for _, method := range methods {
+		endpoints = intersectEndpoints(endpoints, m.route(method))
+	}
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	if m.config.Strategy == StrategyRoundRobin {
+		start := int(atomic.AddUint64(&m.seq, 1)-1) % len(endpoints)
+		endpoints = append(append([]*endpointState{}, endpoints[start:]...), endpoints[:start]...)
+	}
+
+	healthy := []*endpointState{}
+	unhealthy := []*endpointState{}
+	for _, e := range endpoints {
+		if e.isHealthy() {
+			healthy = append(healthy, e)
+		} else {
+			unhealthy = append(unhealthy, e)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+func intersectEndpoints(a, b []*endpointState) []*endpointState {
+	res := []*endpointState{}
+	for _, i := range a {
+		for _, j := range b {
+			if i == j {
+				res = append(res, i)
+				break
+			}
+		}
+	}
+	return res
+}
+
+func (m *multi) isRetryable(err error) bool {
+	if m.config.Retryable != nil {
+		return m.config.Retryable(err)
+	}
+	return DefaultRetryConfig().IsRetryable(err)
+}
+
+// failover runs the handler on the endpoints until one succeeds or fails with
+// an error that is not retryable. The requests with non idempotent methods are
+// only sent to the first endpoint unless FailoverNonIdempotent is set.
+func (m *multi) failover(ctx context.Context, endpoints []*endpointState, methods []string, handler func(e *endpointState) error) error {
+	if !m.config.FailoverNonIdempotent && !isIdempotent(methods...) {
+		endpoints = endpoints[:1]
+	}
+
+	var err error
+	for _, e := range endpoints {
+		if err = handler(e); err == nil || !m.isRetryable(err) || ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+func (m *multi) requiresQuorum(method string) bool {
+	if m.config.Quorum < 2 {
+		return false
+	}
+	if len(m.config.QuorumMethods) == 0 {
+		return true
+	}
+	for _, quorumMethod := range m.config.QuorumMethods {
+		if quorumMethod == method {
+			return true
+		}
+	}
+	return false
+}
+
+// Call implements the transport interface
+func (m *multi) Call(method string, out interface{}, params ...interface{}) error {
+	return m.CallContext(context.Background(), method, out, params...)
+}
+
+// CallContext implements the ContextTransport interface
+func (m *multi) CallContext(ctx context.Context, method string, out interface{}, params ...interface{}) error {
+	endpoints := m.candidates(method)
+	if len(endpoints) == 0 {
+		return fmt.Errorf("no endpoints for method %s", method)
+	}
+	if m.requiresQuorum(method) {
+		return m.quorum(ctx, endpoints, method, out, params...)
+	}
+	return m.failover(ctx, endpoints, []string{method}, func(e *endpointState) error {
+		return CallContext(ctx, e.Transport, method, out, params...)
+	})
+}
+
+// quorum sends the request to all the endpoints and decodes the
+// result returned by at least a quorum of them
+func (m *multi) quorum(ctx context.Context, endpoints []*endpointState, method string, out interface{}, params ...interface{}) error {
+	if len(endpoints) < m.config.Quorum {
+		return fmt.Errorf("%w: only %d endpoints for method %s", ErrNoQuorum, len(endpoints), method)
+	}
+
+	type result struct {
+		raw json.RawMessage
+		val interface{}
+		err error
+	}
+	resCh := make(chan result, len(endpoints))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for _, e := range endpoints {
+		go func(e *endpointState) {
+			var raw json.RawMessage
+			if err := CallContext(ctx, e.Transport, method, &raw, params...); err != nil {
+				resCh <- result{err: err}
+				return
+			}
+			// compare the decoded values since the encoding
+			// may be different between the endpoints
+			var val interface{}
+			if err := json.Unmarshal(raw, &val); err != nil {
+				resCh <- result{err: err}
+				return
+			}
+			resCh <- result{raw: raw, val: val}
+		}(e)
+	}
+
+	results := []result{}
+	counts := []int{}
+	var lastErr error
+	for i := 0; i < len(endpoints); i++ {
+		res := <-resCh
+		if res.err != nil {
+			lastErr = res.err
+			continue
+		}
+
+		found := false
+		for indx, other := range results {
+			if reflect.DeepEqual(other.val, res.val) {
+				found = true
+				counts[indx]++
+				if counts[indx] >= m.config.Quorum {
+					return json.Unmarshal(other.raw, out)
+				}
+			}
+		}
+		if !found {
+			results = append(results, res)
+			counts = append(counts, 1)
+		}
+	}
+	if lastErr != nil {
+		return fmt.Errorf("%w for method %s: %v", ErrNoQuorum, method, lastErr)
+	}
+	return fmt.Errorf("%w for method %s", ErrNoQuorum, method)
+}
+
+// BatchCallContext implements the BatchTransport interface. The batch is sent
+// to the endpoints that support batches and can serve all of its methods.
+// Otherwise, the calls are made one by one.
+func (m *multi) BatchCallContext(ctx context.Context, elems []BatchElem) error {
+	methods := batchMethods(elems)
+	endpoints := []*endpointState{}
+	for _, e := range m.candidates(methods...) {
+		if _, ok := e.Transport.(BatchTransport); ok {
+			endpoints = append(endpoints, e)
+		}
+	}
+	if len(endpoints) == 0 {
+		return batchCallContext(ctx, transportOnly{m}, elems)
+	}
+	return m.failover(ctx, endpoints, methods, func(e *endpointState) error {
+		return e.Transport.(BatchTransport).BatchCallContext(ctx, elems)
+	})
+}
+
+// SetMaxConnsPerHost implements the transport interface
+func (m *multi) SetMaxConnsPerHost(count int) {
+	for _, e := range m.endpoints {
+		e.Transport.SetMaxConnsPerHost(count)
+	}
+}
+
+// Close implements the transport interface. The endpoints
+// are only closed the first time it is called.
+func (m *multi) Close() error {
+	var err error
+	m.closeOnce.Do(func() {
+		close(m.closeCh)
+
+		for _, e := range m.endpoints {
+			if closeErr := e.Transport.Close(); closeErr != nil {
+				err = closeErr
+			}
+		}
+	})
+	return err
+}
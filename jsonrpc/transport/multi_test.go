@@ -0,0 +1,314 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/ethgo/jsonrpc/codec"
+)
+
+// mockTransport is an in memory transport that replies
+// with fixed results and records the methods called
+type mockTransport struct {
+	lock    sync.Mutex
+	results map[string]string
+	err     error
+	calls   []string
+}
+
+func newMockTransport(results map[string]string) *mockTransport {
+	return &mockTransport{results: results}
+}
+
+func (m *mockTransport) setErr(err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.err = err
+}
+
+func (m *mockTransport) numCalls() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return len(m.calls)
+}
+
+func (m *mockTransport) Call(method string, out interface{}, params ...interface{}) error {
+	return m.CallContext(context.Background(), method, out, params...)
+}
+
+func (m *mockTransport) CallContext(ctx context.Context, method string, out interface{}, params ...interface{}) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.calls = append(m.calls, method)
+	if m.err != nil {
+		return m.err
+	}
+	res, ok := m.results[method]
+	if !ok {
+		return &codec.ErrorObject{Code: -32601, Message: "method not found"}
+	}
+	return json.Unmarshal([]byte(res), out)
+}
+
+func (m *mockTransport) SetMaxConnsPerHost(count int) {
+}
+
+func (m *mockTransport) Close() error {
+	return nil
+}
+
+// mockBatchTransport is a mock transport with batches. The first failedBatches
+// batches fail with a connection error after the first element failed.
+type mockBatchTransport struct {
+	*mockTransport
+	failedBatches int
+}
+
+func (m *mockBatchTransport) BatchCallContext(ctx context.Context, elems []BatchElem) error {
+	m.lock.Lock()
+	failed := m.failedBatches > 0
+	if failed {
+		m.failedBatches--
+	}
+	m.lock.Unlock()
+
+	if failed {
+		setBatchResult(&elems[0], nil, &codec.ErrorObject{Code: -32000, Message: "failed"})
+		return ErrConnectionLost
+	}
+	for indx := range elems {
+		var raw json.RawMessage
+		err := m.CallContext(ctx, elems[indx].Method, &raw, elems[indx].Params...)
+		setBatchResult(&elems[indx], raw, err)
+	}
+	return nil
+}
+
+func TestMulti_Failover(t *testing.T) {
+	a := newMockTransport(map[string]string{"eth_chainId": `"0x1"`})
+	b := newMockTransport(map[string]string{"eth_chainId": `"0x2"`})
+
+	tt, err := NewMulti([]*Endpoint{
+		{Name: "b", Transport: b, Priority: 1},
+		{Name: "a", Transport: a, Priority: 0},
+	}, &MultiConfig{})
+	assert.NoError(t, err)
+
+	var out string
+	assert.NoError(t, tt.Call("eth_chainId", &out))
+	assert.Equal(t, "0x1", out)
+
+	// fail over to the next endpoint with a retryable error
+	a.setErr(io.EOF)
+	assert.NoError(t, tt.Call("eth_chainId", &out))
+	assert.Equal(t, "0x2", out)
+
+	// errors that are not retryable are returned
+	a.setErr(&codec.ErrorObject{Code: 3, Message: "execution reverted"})
+	assert.Error(t, tt.Call("eth_chainId", &out))
+	assert.Equal(t, 1, b.numCalls())
+
+	// the last error is returned if all the endpoints fail
+	a.setErr(io.EOF)
+	b.setErr(io.ErrUnexpectedEOF)
+	assert.ErrorIs(t, tt.Call("eth_chainId", &out), io.ErrUnexpectedEOF)
+}
+
+func TestMulti_FailoverNonIdempotent(t *testing.T) {
+	results := map[string]string{"eth_sendRawTransaction": `"0x1"`}
+	a, b := newMockTransport(results), newMockTransport(results)
+
+	config := &MultiConfig{}
+	tt, err := NewMulti([]*Endpoint{
+		{Name: "a", Transport: a, Priority: 0},
+		{Name: "b", Transport: b, Priority: 1},
+	}, config)
+	assert.NoError(t, err)
+
+	// the transaction is not sent to the next endpoint
+	a.setErr(io.EOF)
+
+	var out string
+	assert.ErrorIs(t, tt.Call("eth_sendRawTransaction", &out, "0x"), io.EOF)
+	assert.Equal(t, 0, b.numCalls())
+
+	config.FailoverNonIdempotent = true
+	assert.NoError(t, tt.Call("eth_sendRawTransaction", &out, "0x"))
+	assert.Equal(t, "0x1", out)
+	assert.Equal(t, 1, b.numCalls())
+}
+
+func TestMulti_Close(t *testing.T) {
+	tt, err := NewMulti([]*Endpoint{
+		{Name: "a", Transport: newMockTransport(nil)},
+	}, &MultiConfig{HealthInterval: time.Hour})
+	assert.NoError(t, err)
+
+	assert.NoError(t, tt.Close())
+	assert.NoError(t, tt.Close())
+}
+
+func TestMulti_RoundRobin(t *testing.T) {
+	results := map[string]string{"eth_chainId": `"0x1"`}
+	a, b := newMockTransport(results), newMockTransport(results)
+
+	tt, err := NewMulti([]*Endpoint{
+		{Name: "a", Transport: a},
+		{Name: "b", Transport: b},
+	}, &MultiConfig{Strategy: StrategyRoundRobin})
+	assert.NoError(t, err)
+
+	var out string
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, tt.Call("eth_chainId", &out))
+	}
+	assert.Equal(t, 5, a.numCalls())
+	assert.Equal(t, 5, b.numCalls())
+}
+
+func TestMulti_Routes(t *testing.T) {
+	full := newMockTransport(map[string]string{"eth_chainId": `"0x1"`})
+	archive := newMockTransport(map[string]string{"eth_getLogs": `[]`, "debug_traceTransaction": `{}`})
+
+	tt, err := NewMulti([]*Endpoint{
+		{Name: "full", Transport: full},
+		{Name: "archive", Transport: archive, Priority: 1},
+	}, &MultiConfig{
+		Routes: []Route{
+			{Prefix: "eth_getLogs", Endpoints: []string{"archive"}},
+			{Prefix: "debug_", Endpoints: []string{"archive"}},
+		},
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, tt.Call("eth_chainId", new(string)))
+	assert.NoError(t, tt.Call("eth_getLogs", new([]interface{})))
+	assert.NoError(t, tt.Call("debug_traceTransaction", new(interface{})))
+
+	assert.Equal(t, []string{"eth_chainId"}, full.calls)
+	assert.Equal(t, []string{"eth_getLogs", "debug_traceTransaction"}, archive.calls)
+
+	// routes with unknown endpoints are rejected
+	_, err = NewMulti([]*Endpoint{
+		{Name: "full", Transport: full},
+	}, &MultiConfig{
+		Routes: []Route{{Prefix: "debug_", Endpoints: []string{"archive"}}},
+	})
+	assert.Error(t, err)
+}
+
+func TestMulti_HealthCheck(t *testing.T) {
+	a := newMockTransport(map[string]string{"eth_blockNumber": `"0x10"`, "eth_chainId": `"0x1"`})
+	b := newMockTransport(map[string]string{"eth_blockNumber": `"0x20"`, "eth_chainId": `"0x2"`})
+
+	tt, err := NewMulti([]*Endpoint{
+		{Name: "a", Transport: a},
+		{Name: "b", Transport: b, Priority: 1},
+	}, &MultiConfig{MaxBlockLag: 5})
+	assert.NoError(t, err)
+
+	m := tt.(*multi)
+	m.checkHealth()
+
+	// 'a' is behind and it is only used after the healthy endpoints
+	var out string
+	assert.NoError(t, tt.Call("eth_chainId", &out))
+	assert.Equal(t, "0x2", out)
+
+	// endpoints that fail are unhealthy
+	b.setErr(io.EOF)
+	m.checkHealth()
+
+	assert.NoError(t, tt.Call("eth_chainId", &out))
+	assert.Equal(t, "0x1", out)
+}
+
+func TestMulti_Quorum(t *testing.T) {
+	a := newMockTransport(map[string]string{"eth_getBalance": `"0x1"`, "eth_chainId": `"0x1"`})
+	b := newMockTransport(map[string]string{"eth_getBalance": `"0x1"`, "eth_chainId": `"0x1"`})
+	c := newMockTransport(map[string]string{"eth_getBalance": `"0x2"`, "eth_chainId": `"0x1"`})
+
+	tt, err := NewMulti([]*Endpoint{
+		{Name: "a", Transport: a},
+		{Name: "b", Transport: b},
+		{Name: "c", Transport: c},
+	}, &MultiConfig{Quorum: 2, QuorumMethods: []string{"eth_getBalance"}})
+	assert.NoError(t, err)
+
+	var out string
+	assert.NoError(t, tt.Call("eth_getBalance", &out))
+	assert.Equal(t, "0x1", out)
+
+	// not enough endpoints agree
+	b.setErr(fmt.Errorf("failed"))
+	assert.ErrorIs(t, tt.Call("eth_getBalance", &out), ErrNoQuorum)
+
+	// other methods do not require a quorum
+	assert.NoError(t, tt.Call("eth_chainId", &out))
+	assert.NotContains(t, c.calls, "eth_chainId")
+
+	// the quorum cannot be larger than the number of endpoints
+	_, err = NewMulti([]*Endpoint{{Name: "a", Transport: a}}, &MultiConfig{Quorum: 2})
+	assert.Error(t, err)
+}
+
+func TestMulti_Batch(t *testing.T) {
+	a := newMockTransport(map[string]string{"eth_chainId": `"0x1"`})
+	a.setErr(io.EOF)
+	b := newMockTransport(map[string]string{"eth_chainId": `"0x2"`})
+
+	tt, err := NewMulti([]*Endpoint{
+		{Name: "a", Transport: a},
+		{Name: "b", Transport: b, Priority: 1},
+	}, &MultiConfig{})
+	assert.NoError(t, err)
+
+	var res1, res2 string
+	elems := []BatchElem{
+		{Method: "eth_chainId", Result: &res1},
+		{Method: "eth_chainId", Result: &res2},
+	}
+	assert.NoError(t, tt.(BatchTransport).BatchCallContext(context.Background(), elems))
+
+	// the mock transport does not support batches and
+	// each call fails over to the next endpoint
+	for _, elem := range elems {
+		assert.NoError(t, elem.Error)
+	}
+	assert.Equal(t, "0x2", res1)
+	assert.Equal(t, "0x2", res2)
+}
+
+func TestMulti_BatchFailover(t *testing.T) {
+	a := &mockBatchTransport{mockTransport: newMockTransport(nil), failedBatches: 1}
+	b := &mockBatchTransport{mockTransport: newMockTransport(map[string]string{"eth_chainId": `"0x2"`})}
+
+	tt, err := NewMulti([]*Endpoint{
+		{Name: "a", Transport: a},
+		{Name: "b", Transport: b, Priority: 1},
+	}, &MultiConfig{})
+	assert.NoError(t, err)
+
+	var res1, res2 string
+	elems := []BatchElem{
+		{Method: "eth_chainId", Result: &res1},
+		{Method: "eth_chainId", Result: &res2},
+	}
+	assert.NoError(t, tt.(BatchTransport).BatchCallContext(context.Background(), elems))
+
+	// the error of the first endpoint is not kept
+	for _, elem := range elems {
+		assert.NoError(t, elem.Error)
+	}
+	assert.Equal(t, "0x2", res1)
+	assert.Equal(t, "0x2", res2)
+}
@@ -0,0 +1,128 @@
+package transport
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// tokenBucket is a token bucket rate limiter
+type tokenBucket struct {
+	lock   sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token from the bucket and returns
+// how long to wait until the token is available
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a token that was not used to the bucket
+func (b *tokenBucket) cancel() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.tokens++
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+// wait blocks until a token is available or the context is done
+func (b *tokenBucket) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	delay := b.reserve(time.Now())
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	}
+}
+
+// rateLimitTransport is a transport that limits the number of requests per second
+type rateLimitTransport struct {
+	Transport
+	bucket *tokenBucket
+}
+
+// NewRateLimit wraps the transport to send at most rate requests per second
+// with bursts of up to burst requests. A batch counts as a single request.
+// A rate of zero or less is no limit and the transport is returned as is.
+func NewRateLimit(t Transport, rate float64, burst int) Transport {
+	if rate <= 0 {
+		return t
+	}
+	r := &rateLimitTransport{
+		Transport: t,
+		bucket:    newTokenBucket(rate, burst),
+	}
+	return withPubSub(r, t)
+}
+
+// Call implements the transport interface
+func (r *rateLimitTransport) Call(method string, out interface{}, params ...interface{}) error {
+	return r.CallContext(context.Background(), method, out, params...)
+}
+
+// CallContext implements the ContextTransport interface
+func (r *rateLimitTransport) CallContext(ctx context.Context, method string, out interface{}, params ...interface{}) error {
+	if err := r.bucket.wait(ctx); err != nil {
+		return err
+	}
+	return CallContext(ctx, r.Transport, method, out, params...)
+}
+
+// BatchCallContext implements the BatchTransport interface
+func (r *rateLimitTransport) BatchCallContext(ctx context.Context, elems []BatchElem) error {
+	batch, ok := r.Transport.(BatchTransport)
+	if !ok {
+		// each call is limited on its own
+		return batchCallContext(ctx, transportOnly{r}, elems)
+	}
+	if err := r.bucket.wait(ctx); err != nil {
+		return err
+	}
+	return batch.BatchCallContext(ctx, elems)
+}
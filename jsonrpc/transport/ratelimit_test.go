@@ -0,0 +1,45 @@
+package transport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit_TokenBucket(t *testing.T) {
+	b := newTokenBucket(10, 2)
+	now := b.last
+
+	// burst
+	assert.Equal(t, time.Duration(0), b.reserve(now))
+	assert.Equal(t, time.Duration(0), b.reserve(now))
+
+	// the next tokens are available every 100ms
+	assert.Equal(t, 100*time.Millisecond, b.reserve(now))
+	assert.Equal(t, 200*time.Millisecond, b.reserve(now))
+
+	// the bucket refills over time up to the burst
+	now = now.Add(time.Second)
+	assert.Equal(t, time.Duration(0), b.reserve(now))
+	assert.Equal(t, time.Duration(0), b.reserve(now))
+	assert.Equal(t, 100*time.Millisecond, b.reserve(now))
+}
+
+func TestRateLimit_WaitContext(t *testing.T) {
+	b := newTokenBucket(1, 1)
+	assert.NoError(t, b.wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, b.wait(ctx), context.DeadlineExceeded)
+}
+
+func TestRateLimit_NoLimit(t *testing.T) {
+	tt := newMockTransport(nil)
+
+	assert.Equal(t, Transport(tt), NewRateLimit(tt, 0, 1))
+	assert.Equal(t, Transport(tt), NewRateLimit(tt, -1, 1))
+}
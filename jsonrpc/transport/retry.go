@@ -0,0 +1,245 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/umbracle/ethgo/jsonrpc/codec"
+)
+
+// RetryConfig is the configuration of the retry transport
+type RetryConfig struct {
+	// MaxRetries is the maximum number of retries of a request
+	MaxRetries int
+
+	// MinBackoff is the delay before the first retry
+	MinBackoff time.Duration
+
+	// MaxBackoff is the maximum delay between retries. It also caps the
+	// delay requested by the server with the 'Retry-After' header
+	MaxBackoff time.Duration
+
+	// ErrorCodes are the jsonrpc error codes that are retried
+	ErrorCodes []int
+
+	// ErrorMessages are the messages of the jsonrpc errors that are retried
+	// (i.e. 'header not found'). The error matches if it contains any of them.
+	ErrorMessages []string
+
+	// StatusCodes are the http status codes that are retried
+	StatusCodes []int
+
+	// Retryable overrides the classification of the errors if set
+	Retryable func(err error) bool
+
+	// RetryNonIdempotent also retries the requests of the non idempotent
+	// methods (i.e. 'eth_sendRawTransaction'). A retried request may be
+	// executed twice if the first one reached the server.
+	RetryNonIdempotent bool
+}
+
+// nonIdempotentMethods are the methods that are not retried by default
+var nonIdempotentMethods = []string{
+	"eth_sendRawTransaction",
+	"eth_sendTransaction",
+}
+
+// isIdempotent returns true if the methods can be sent more than once
+func isIdempotent(methods ...string) bool {
+	for _, method := range methods {
+		for _, nonIdempotent := range nonIdempotentMethods {
+			if method == nonIdempotent {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// canRetry returns true if the requests of the methods can be retried
+func (c *RetryConfig) canRetry(methods ...string) bool {
+	return c.RetryNonIdempotent || isIdempotent(methods...)
+}
+
+// DefaultRetryConfig returns the default configuration of the retry transport
+func DefaultRetryConfig() *RetryConfig {
+	return &RetryConfig{
+		MaxRetries: 5,
+		MinBackoff: 100 * time.Millisecond,
+		MaxBackoff: 10 * time.Second,
+		ErrorCodes: []int{
+			-32005, // limit exceeded
+			429,    // too many requests
+		},
+		ErrorMessages: []string{
+			"header not found",
+			"rate limit",
+		},
+		StatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// IsRetryable returns true if the request that failed with the error can be retried
+func (c *RetryConfig) IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if c.Retryable != nil {
+		return c.Retryable(err)
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		// the request was aborted by the caller
+		return false
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return containsInt(c.StatusCodes, httpErr.StatusCode)
+	}
+
+	var errObj *codec.ErrorObject
+	if errors.As(err, &errObj) {
+		if containsInt(c.ErrorCodes, errObj.Code) {
+			return true
+		}
+		msg := strings.ToLower(errObj.Message)
+		for _, m := range c.ErrorMessages {
+			if strings.Contains(msg, strings.ToLower(m)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// network errors
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, ErrTimeout) ||
+		errors.Is(err, ErrConnectionLost)
+}
+
+// backoff returns the delay before the given retry attempt (starting at 0)
+func (c *RetryConfig) backoff(attempt int) time.Duration {
+	return backoff(c.MinBackoff, c.MaxBackoff, attempt)
+}
+
+// retryDelay returns the delay before the given retry attempt of a request that
+// failed with the error. It waits as long as the server requests with the
+// 'Retry-After' header, up to MaxBackoff.
+func (c *RetryConfig) retryDelay(attempt int, err error) time.Duration {
+	delay := c.backoff(attempt)
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.RetryAfter > delay {
+		delay = httpErr.RetryAfter
+		if c.MaxBackoff != 0 && delay > c.MaxBackoff {
+			delay = c.MaxBackoff
+		}
+	}
+	return delay
+}
+
+// backoff is an exponential backoff with a random jitter of up to half the delay
+func backoff(minDelay, maxDelay time.Duration, attempt int) time.Duration {
+	delay := minDelay
+	for i := 0; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if maxDelay != 0 && delay > maxDelay {
+		delay = maxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+func containsInt(list []int, i int) bool {
+	for _, j := range list {
+		if i == j {
+			return true
+		}
+	}
+	return false
+}
+
+// retryTransport is a transport that retries the failed requests
+type retryTransport struct {
+	Transport
+	config *RetryConfig
+}
+
+// NewRetry wraps the transport to retry the requests that fail with
+// a retryable error using an exponential backoff
+func NewRetry(t Transport, config *RetryConfig) Transport {
+	if config == nil {
+		config = DefaultRetryConfig()
+	}
+	r := &retryTransport{
+		Transport: t,
+		config:    config,
+	}
+	return withPubSub(r, t)
+}
+
+// Call implements the transport interface
+func (r *retryTransport) Call(method string, out interface{}, params ...interface{}) error {
+	return r.CallContext(context.Background(), method, out, params...)
+}
+
+// CallContext implements the ContextTransport interface
+func (r *retryTransport) CallContext(ctx context.Context, method string, out interface{}, params ...interface{}) error {
+	handler := func() error {
+		return CallContext(ctx, r.Transport, method, out, params...)
+	}
+	if !r.config.canRetry(method) {
+		return handler()
+	}
+	return r.retry(ctx, handler)
+}
+
+// BatchCallContext implements the BatchTransport interface. Only the failed
+// batch requests are retried, not the individual calls that fail.
+func (r *retryTransport) BatchCallContext(ctx context.Context, elems []BatchElem) error {
+	handler := func() error {
+		return batchCallContext(ctx, r.Transport, elems)
+	}
+	if !r.config.canRetry(batchMethods(elems)...) {
+		return handler()
+	}
+	return r.retry(ctx, handler)
+}
+
+func (r *retryTransport) retry(ctx context.Context, handler func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := handler()
+		if err == nil || attempt >= r.config.MaxRetries || !r.config.IsRetryable(err) {
+			return err
+		}
+
+		timer := time.NewTimer(r.config.retryDelay(attempt, err))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		}
+	}
+}
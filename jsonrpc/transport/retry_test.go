@@ -0,0 +1,193 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/ethgo/jsonrpc/codec"
+)
+
+func TestRetry_IsRetryable(t *testing.T) {
+	config := DefaultRetryConfig()
+
+	cases := []struct {
+		err       error
+		retryable bool
+	}{
+		{nil, false},
+		{context.Canceled, false},
+		{context.DeadlineExceeded, false},
+		{fmt.Errorf("other"), false},
+		{io.EOF, true},
+		{&net.OpError{Op: "dial", Err: fmt.Errorf("connection refused")}, true},
+		{&HTTPError{StatusCode: 429}, true},
+		{&HTTPError{StatusCode: 503}, true},
+		{&HTTPError{StatusCode: 400}, false},
+		{&codec.ErrorObject{Code: -32005, Message: "limit exceeded"}, true},
+		{&codec.ErrorObject{Code: -32000, Message: "header not found"}, true},
+		{&codec.ErrorObject{Code: -32000, Message: "execution reverted"}, false},
+		{fmt.Errorf("wrapped: %w", &HTTPError{StatusCode: 502}), true},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.retryable, config.IsRetryable(c.err), c.err)
+	}
+
+	// custom classification
+	config.Retryable = func(err error) bool { return true }
+	assert.True(t, config.IsRetryable(fmt.Errorf("other")))
+}
+
+func TestRetry_Backoff(t *testing.T) {
+	config := &RetryConfig{
+		MinBackoff: 100 * time.Millisecond,
+		MaxBackoff: time.Second,
+	}
+	for attempt, max := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		max *= time.Millisecond
+		for i := 0; i < 10; i++ {
+			delay := config.backoff(attempt)
+			assert.GreaterOrEqual(t, delay, max/2)
+			assert.LessOrEqual(t, delay, max)
+		}
+	}
+}
+
+func TestRetry_RetryAfter(t *testing.T) {
+	config := &RetryConfig{
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 10 * time.Second,
+	}
+
+	// the server delay is used if it is longer than the backoff
+	assert.Equal(t, 5*time.Second, config.retryDelay(0, &HTTPError{StatusCode: 429, RetryAfter: 5 * time.Second}))
+
+	// and it is capped at the maximum backoff
+	assert.Equal(t, 10*time.Second, config.retryDelay(0, &HTTPError{StatusCode: 429, RetryAfter: time.Hour}))
+}
+
+func TestRetry_HTTP(t *testing.T) {
+	var count uint64
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddUint64(&count, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.Write([]byte(`{"jsonrpc":"2.0","id":0,"error":{"code":-32000,"message":"header not found"}}`))
+		default:
+			w.Write([]byte(`{"jsonrpc":"2.0","id":0,"result":"0x1"}`))
+		}
+	}))
+	defer srv.Close()
+
+	config := DefaultRetryConfig()
+	config.MinBackoff = time.Millisecond
+
+	tt := NewRetry(newHTTP(srv.URL, nil), config)
+
+	var out string
+	now := time.Now()
+	assert.NoError(t, tt.Call("eth_chainId", &out))
+	assert.Equal(t, "0x1", out)
+	assert.Equal(t, uint64(3), atomic.LoadUint64(&count))
+
+	// it waits for the 'Retry-After' delay
+	assert.GreaterOrEqual(t, time.Since(now), time.Second)
+
+	// http transports do not support subscriptions
+	_, ok := tt.(PubSubTransport)
+	assert.False(t, ok)
+}
+
+func TestRetry_MaxRetries(t *testing.T) {
+	var count uint64
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddUint64(&count, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	config := DefaultRetryConfig()
+	config.MaxRetries = 2
+	config.MinBackoff = time.Millisecond
+
+	tt := NewRetry(newHTTP(srv.URL, nil), config)
+
+	var out string
+	err := tt.Call("eth_chainId", &out)
+
+	var httpErr *HTTPError
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusServiceUnavailable, httpErr.StatusCode)
+	assert.Equal(t, uint64(3), atomic.LoadUint64(&count))
+}
+
+func TestRetry_Batch(t *testing.T) {
+	m := &mockBatchTransport{mockTransport: newMockTransport(map[string]string{"eth_chainId": `"0x1"`}), failedBatches: 1}
+
+	config := DefaultRetryConfig()
+	config.MinBackoff = time.Millisecond
+
+	tt := NewRetry(m, config)
+
+	var res1, res2 string
+	elems := []BatchElem{
+		{Method: "eth_chainId", Result: &res1},
+		{Method: "eth_chainId", Result: &res2},
+	}
+	assert.NoError(t, tt.(BatchTransport).BatchCallContext(context.Background(), elems))
+
+	// the error of the failed attempt is not kept
+	for _, elem := range elems {
+		assert.NoError(t, elem.Error)
+	}
+	assert.Equal(t, "0x1", res1)
+	assert.Equal(t, "0x1", res2)
+}
+
+func TestRetry_NonIdempotent(t *testing.T) {
+	m := newMockTransport(map[string]string{"eth_sendRawTransaction": `"0x1"`})
+	m.setErr(io.EOF)
+
+	config := DefaultRetryConfig()
+	config.MinBackoff = time.Millisecond
+
+	var out string
+	tt := NewRetry(m, config)
+	assert.ErrorIs(t, tt.Call("eth_sendRawTransaction", &out, "0x"), io.EOF)
+	assert.Equal(t, 1, m.numCalls())
+
+	// the batch is not retried if any of the methods is not idempotent
+	elems := []BatchElem{
+		{Method: "eth_chainId", Result: &out},
+		{Method: "eth_sendRawTransaction", Params: []interface{}{"0x"}, Result: &out},
+	}
+	assert.NoError(t, tt.(BatchTransport).BatchCallContext(context.Background(), elems))
+	assert.Equal(t, 3, m.numCalls())
+
+	// the non idempotent methods are retried if enabled
+	config.RetryNonIdempotent = true
+	config.MaxRetries = 2
+
+	assert.ErrorIs(t, tt.Call("eth_sendRawTransaction", &out, "0x"), io.EOF)
+	assert.Equal(t, 6, m.numCalls())
+}
+
+func TestHTTP_ParseRetryAfter(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Duration(0), parseRetryAfter("", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("abc", now))
+	assert.Equal(t, 5*time.Second, parseRetryAfter("5", now))
+	assert.Equal(t, 10*time.Second, parseRetryAfter("Sat, 01 Jan 2022 00:00:10 GMT", now))
+}
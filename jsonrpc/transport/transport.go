@@ -1,8 +1,10 @@
 package transport
 
 import (
+	"context"
 	"os"
 	"strings"
+	"time"
 )
 
 // Transport is an inteface for transport methods to send jsonrpc requests
@@ -17,21 +19,109 @@ type Transport interface {
 	Close() error
 }
 
+// ContextTransport is a transport that aborts the requests if the context is done
+type ContextTransport interface {
+	// CallContext makes a jsonrpc request that is aborted if the context is done
+	CallContext(ctx context.Context, method string, out interface{}, params ...interface{}) error
+}
+
+// CallContext makes a jsonrpc request with the transport. The request is aborted
+// if the context is done only if the transport implements ContextTransport,
+// otherwise it is sent with Call.
+func CallContext(ctx context.Context, t Transport, method string, out interface{}, params ...interface{}) error {
+	if c, ok := t.(ContextTransport); ok {
+		return c.CallContext(ctx, method, out, params...)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return t.Call(method, out, params...)
+}
+
 // PubSubTransport is a transport that allows subscriptions
 type PubSubTransport interface {
 	// Subscribe starts a subscription to a new event
 	Subscribe(method string, callback func(b []byte)) (func() error, error)
 }
 
+// ResubscribeTransport is a transport that re-establishes the subscriptions
+// when it reconnects after the connection is lost
+type ResubscribeTransport interface {
+	// SubscribeWithNotify starts a subscription to a new event with params. The
+	// notify function is called with a nil error when the subscription is
+	// re-established after a reconnect, since some events may have been missed,
+	// and with the error if the subscription cannot be re-established (i.e.
+	// ErrConnectionLost if the transport gives up or does not reconnect).
+	SubscribeWithNotify(method string, params []interface{}, callback func(b []byte), notify func(err error)) (func() error, error)
+}
+
 const (
 	wsPrefix  = "ws://"
 	wssPrefix = "wss://"
 )
 
+// Config is the configuration of the transports
+type Config struct {
+	// Headers are the headers of the http and websocket requests
+	Headers map[string]string
+
+	// JWTSecret is the secret used to authenticate the http and websocket
+	// requests with an HS256 token (i.e. for the engine api). A new token
+	// is signed for every http request and websocket connection.
+	JWTSecret []byte
+
+	// Reconnect is the configuration of the reconnection of the
+	// websocket and ipc transports
+	Reconnect *ReconnectConfig
+}
+
+// ReconnectConfig is the configuration of the reconnection of the websocket and ipc transports
+type ReconnectConfig struct {
+	// Disabled disables the reconnection
+	Disabled bool
+
+	// MinBackoff is the delay before the first reconnection attempt
+	MinBackoff time.Duration
+
+	// MaxBackoff is the maximum delay between reconnection attempts
+	MaxBackoff time.Duration
+
+	// MaxAttempts is the maximum number of consecutive reconnection
+	// attempts. There is no limit if it is zero.
+	MaxAttempts int
+
+	// PingInterval is the interval between websocket pings. The
+	// keepalive is disabled if it is zero.
+	PingInterval time.Duration
+
+	// PongTimeout is the time to wait for a pong before the
+	// websocket connection is considered lost
+	PongTimeout time.Duration
+}
+
+// DefaultReconnectConfig returns the default reconnection configuration
+func DefaultReconnectConfig() *ReconnectConfig {
+	return &ReconnectConfig{
+		MinBackoff:   500 * time.Millisecond,
+		MaxBackoff:   30 * time.Second,
+		PingInterval: 30 * time.Second,
+		PongTimeout:  60 * time.Second,
+	}
+}
+
 // NewTransport creates a new transport object
 func NewTransport(url string, headers map[string]string) (Transport, error) {
+	return NewTransportWithConfig(url, &Config{Headers: headers})
+}
+
+// NewTransportWithConfig creates a new transport object with the configuration
+func NewTransportWithConfig(url string, config *Config) (Transport, error) {
+	reconnect := config.Reconnect
+	if reconnect == nil {
+		reconnect = DefaultReconnectConfig()
+	}
 	if strings.HasPrefix(url, wsPrefix) || strings.HasPrefix(url, wssPrefix) {
-		t, err := newWebsocket(url, headers)
+		t, err := newWebsocket(url, config.Headers, config.JWTSecret, reconnect)
 		if err != nil {
 			return nil, err
 		}
@@ -39,11 +129,71 @@ func NewTransport(url string, headers map[string]string) (Transport, error) {
 	}
 	if _, err := os.Stat(url); err == nil {
 		// path exists, it could be an ipc path
-		t, err := newIPC(url)
+		t, err := newIPC(url, reconnect)
 		if err != nil {
 			return nil, err
 		}
 		return t, nil
 	}
-	return newHTTP(url, headers), nil
+	h := newHTTP(url, config.Headers)
+	h.jwtSecret = config.JWTSecret
+	return h, nil
+}
+
+// batchCallTransport is a transport that supports batch requests
+type batchCallTransport interface {
+	Transport
+	ContextTransport
+	BatchTransport
+}
+
+// pubSubTransport is a wrapped transport that keeps
+// the subscriptions of the inner transport
+type pubSubTransport struct {
+	batchCallTransport
+	PubSubTransport
+}
+
+// resubscribeTransport is a wrapped transport that keeps the
+// subscriptions with notifications of the inner transport
+type resubscribeTransport struct {
+	batchCallTransport
+	PubSubTransport
+	ResubscribeTransport
+}
+
+// withPubSub exposes the subscriptions of the inner transport in the
+// wrapped transport if the inner transport supports them
+func withPubSub(wrapped batchCallTransport, inner Transport) Transport {
+	pub, ok := inner.(PubSubTransport)
+	if !ok {
+		return wrapped
+	}
+	if resub, ok := inner.(ResubscribeTransport); ok {
+		return &resubscribeTransport{wrapped, pub, resub}
+	}
+	return &pubSubTransport{wrapped, pub}
+}
+
+// transportOnly hides the batch requests of a transport
+type transportOnly struct {
+	Transport
+}
+
+// CallContext implements the ContextTransport interface
+func (t transportOnly) CallContext(ctx context.Context, method string, out interface{}, params ...interface{}) error {
+	return CallContext(ctx, t.Transport, method, out, params...)
+}
+
+// batchCallContext sends the batch with the transport or makes
+// the calls one by one if the transport does not support batches
+func batchCallContext(ctx context.Context, t Transport, elems []BatchElem) error {
+	if batch, ok := t.(BatchTransport); ok {
+		return batch.BatchCallContext(ctx, elems)
+	}
+	for indx := range elems {
+		elem := &elems[indx]
+		elem.Error = CallContext(ctx, t, elem.Method, elem.Result, elem.Params...)
+	}
+	return nil
 }
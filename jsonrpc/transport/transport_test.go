@@ -0,0 +1,226 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/ethgo/jsonrpc/codec"
+)
+
+func TestHTTP_CallContextCancel(t *testing.T) {
+	releasedCh := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// never reply, the request context is done when the client closes
+		// the connection once the body is read
+		io.ReadAll(r.Body)
+		<-r.Context().Done()
+		close(releasedCh)
+	}))
+	defer srv.Close()
+
+	tt := newHTTP(srv.URL, nil)
+	defer tt.Close()
+
+	numGoroutines := runtime.NumGoroutine()
+
+	// the context does not have a deadline
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+	}()
+
+	var out string
+	now := time.Now()
+	err := tt.CallContext(ctx, "eth_chainId", &out)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(now), 5*time.Second)
+
+	// the connection of the request is closed
+	select {
+	case <-releasedCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the connection is not released")
+	}
+
+	// and the goroutines of the request finish
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > numGoroutines {
+		if time.Now().After(deadline) {
+			t.Fatalf("goroutines leaked: %d > %d", runtime.NumGoroutine(), numGoroutines)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// a context that is already done does not send the request
+	err = tt.CallContext(ctx, "eth_chainId", &out)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// callOnlyTransport is a transport that does not implement ContextTransport
+type callOnlyTransport struct {
+	calls int
+}
+
+func (c *callOnlyTransport) Call(method string, out interface{}, params ...interface{}) error {
+	c.calls++
+	return nil
+}
+
+func (c *callOnlyTransport) SetMaxConnsPerHost(count int) {
+}
+
+func (c *callOnlyTransport) Close() error {
+	return nil
+}
+
+func TestTransport_CallContextFallback(t *testing.T) {
+	tt := &callOnlyTransport{}
+
+	assert.NoError(t, CallContext(context.Background(), tt, "eth_chainId", nil))
+	assert.Equal(t, 1, tt.calls)
+
+	// the request is not sent if the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.ErrorIs(t, CallContext(ctx, tt, "eth_chainId", nil), context.Canceled)
+	assert.Equal(t, 1, tt.calls)
+}
+
+func TestWebsocket_CallContextCancel(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// read the requests but never reply
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	tt, err := newWebsocket("ws"+strings.TrimPrefix(srv.URL, "http"), nil, nil, DefaultReconnectConfig())
+	assert.NoError(t, err)
+	defer tt.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	var out string
+	err = CallContext(ctx, tt, "eth_chainId", &out)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the pending handler is removed
+	s := tt.(*stream)
+	s.handlerLock.Lock()
+	assert.Empty(t, s.handler)
+	s.handlerLock.Unlock()
+}
+
+// batchHandler replies to a batch request in reverse order. The method
+// 'echo' returns the first param and any other method returns an error.
+func batchHandler(t *testing.T, data []byte) []byte {
+	var reqs []codec.Request
+	assert.NoError(t, json.Unmarshal(data, &reqs))
+
+	resps := []codec.Response{}
+	for i := len(reqs) - 1; i >= 0; i-- {
+		req := reqs[i]
+		resp := codec.Response{ID: req.ID}
+		if req.Method == "echo" {
+			var params []json.RawMessage
+			assert.NoError(t, json.Unmarshal(req.Params, &params))
+			resp.Result = params[0]
+		} else {
+			resp.Error = &codec.ErrorObject{Code: -32601, Message: "method not found"}
+		}
+		resps = append(resps, resp)
+	}
+	res, err := json.Marshal(resps)
+	assert.NoError(t, err)
+	return res
+}
+
+func testBatchCall(t *testing.T, tt BatchTransport) {
+	var res1 string
+	var res2 uint64
+
+	elems := []BatchElem{
+		{Method: "echo", Params: []interface{}{"a"}, Result: &res1},
+		{Method: "unknown", Result: new(string)},
+		{Method: "echo", Params: []interface{}{1}, Result: &res2},
+		{Method: "echo", Params: []interface{}{"b"}, Result: &res2},
+	}
+	assert.NoError(t, tt.BatchCallContext(context.Background(), elems))
+
+	assert.NoError(t, elems[0].Error)
+	assert.Equal(t, "a", res1)
+
+	var errObj *codec.ErrorObject
+	assert.ErrorAs(t, elems[1].Error, &errObj)
+	assert.Equal(t, -32601, errObj.Code)
+
+	assert.NoError(t, elems[2].Error)
+	assert.Equal(t, uint64(1), res2)
+
+	// decoding error
+	assert.Error(t, elems[3].Error)
+}
+
+func TestHTTP_BatchCall(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		w.Write(batchHandler(t, data))
+	}))
+	defer srv.Close()
+
+	testBatchCall(t, newHTTP(srv.URL, nil))
+}
+
+func TestWebsocket_BatchCall(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, batchHandler(t, data)); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	tt, err := newWebsocket("ws"+strings.TrimPrefix(srv.URL, "http"), nil, nil, DefaultReconnectConfig())
+	assert.NoError(t, err)
+	defer tt.Close()
+
+	testBatchCall(t, tt.(BatchTransport))
+}
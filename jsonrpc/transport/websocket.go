@@ -1,7 +1,10 @@
 package transport
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"sync"
@@ -12,24 +15,32 @@ import (
 	"github.com/umbracle/ethgo/jsonrpc/codec"
 )
 
-func newWebsocket(url string, headers map[string]string) (Transport, error) {
-	wsHeaders := http.Header{}
-	for k, v := range headers {
-		wsHeaders.Add(k, v)
-	}
-	wsConn, _, err := websocket.DefaultDialer.Dial(url, wsHeaders)
-	if err != nil {
-		return nil, err
-	}
-	codec := &websocketCodec{
-		conn: wsConn,
+func newWebsocket(url string, headers map[string]string, jwtSecret []byte, reconnect *ReconnectConfig) (Transport, error) {
+	dial := func() (Codec, error) {
+		wsHeaders := http.Header{}
+		for k, v := range headers {
+			wsHeaders.Add(k, v)
+		}
+		if jwtSecret != nil {
+			// the token is checked during the handshake, sign a new one on every dial
+			wsHeaders.Set("Authorization", jwtAuthorization(jwtSecret))
+		}
+		wsConn, _, err := websocket.DefaultDialer.Dial(url, wsHeaders)
+		if err != nil {
+			return nil, err
+		}
+		return newWebsocketCodec(wsConn, reconnect.PingInterval, reconnect.PongTimeout), nil
 	}
-	return newStream(codec)
+	return newStream(dial, reconnect)
 }
 
 // ErrTimeout happens when the websocket requests times out
 var ErrTimeout = fmt.Errorf("ws timeout")
 
+// ErrConnectionLost happens when the connection is lost before the response
+// of a request is received. The request can be retried once the transport reconnects.
+var ErrConnectionLost = fmt.Errorf("connection lost")
+
 type ackMessage struct {
 	buf []byte
 	err error
@@ -37,9 +48,24 @@ type ackMessage struct {
 
 type callback func(b []byte, err error)
 
+// subscription is an active subscription of the stream
+type subscription struct {
+	id       string
+	method   string
+	params   []interface{}
+	callback func(b []byte)
+	notify   func(err error)
+}
+
 type stream struct {
-	seq   uint64
-	codec Codec
+	seq uint64
+
+	// codec is the connection. It is nil while the stream reconnects.
+	codecLock sync.RWMutex
+	codec     Codec
+
+	dial      func() (Codec, error)
+	reconnect *ReconnectConfig
 
 	// call handlers
 	handlerLock sync.Mutex
@@ -47,27 +73,54 @@ type stream struct {
 
 	// subscriptions
 	subsLock sync.Mutex
-	subs     map[string]func(b []byte)
+	subs     map[string]*subscription
+
+	// lostSubs are the subscriptions that could not be re-established
+	// because the connection was lost again. They are retried in the
+	// next connection.
+	lostSubs []*subscription
+
+	// events of the subscriptions pending to be delivered
+	eventsLock sync.Mutex
+	events     []codec.Request
+	eventsCh   chan struct{}
 
 	closeCh chan struct{}
-	timer   *time.Timer
 }
 
-func newStream(codec Codec) (*stream, error) {
+// defaultTimeout is the timeout of a request if the context does not have a deadline
+const defaultTimeout = 15 * time.Second
+
+func newStream(dial func() (Codec, error), reconnect *ReconnectConfig) (*stream, error) {
+	conn, err := dial()
+	if err != nil {
+		return nil, err
+	}
 	w := &stream{
-		codec:   codec,
-		closeCh: make(chan struct{}),
-		handler: map[uint64]callback{},
-		subs:    map[string]func(b []byte){},
+		codec:     conn,
+		dial:      dial,
+		reconnect: reconnect,
+		closeCh:   make(chan struct{}),
+		handler:   map[uint64]callback{},
+		subs:      map[string]*subscription{},
+		eventsCh:  make(chan struct{}, 1),
 	}
 
 	go w.listen()
+	go w.dispatchEvents()
 	return w, nil
 }
 
 // Close implements the the transport interface
 func (s *stream) Close() error {
 	close(s.closeCh)
+
+	s.codecLock.Lock()
+	defer s.codecLock.Unlock()
+
+	if s.codec == nil {
+		return nil
+	}
 	return s.codec.Close()
 }
 
@@ -84,19 +137,88 @@ func (s *stream) isClosed() bool {
 	}
 }
 
+func (s *stream) getCodec() Codec {
+	s.codecLock.RLock()
+	defer s.codecLock.RUnlock()
+
+	return s.codec
+}
+
+func (s *stream) setCodec(conn Codec) {
+	s.codecLock.Lock()
+	defer s.codecLock.Unlock()
+
+	s.codec = conn
+}
+
+// write sends a message with the current connection
+func (s *stream) write(b []byte) error {
+	conn := s.getCodec()
+	if conn == nil {
+		return ErrConnectionLost
+	}
+	if err := conn.Write(b); err != nil {
+		return fmt.Errorf("%w: %v", ErrConnectionLost, err)
+	}
+	return nil
+}
+
 func (s *stream) listen() {
+	for {
+		conn := s.getCodec()
+		s.read(conn)
+
+		if s.isClosed() {
+			return
+		}
+
+		// the connection is lost
+		s.setCodec(nil)
+		conn.Close()
+		s.failHandlers()
+
+		if s.reconnect == nil || s.reconnect.Disabled {
+			s.failSubscriptions()
+			return
+		}
+		if conn = s.redial(); conn == nil {
+			if !s.isClosed() {
+				// too many attempts
+				s.failSubscriptions()
+			}
+			return
+		}
+		s.setCodec(conn)
+
+		// resubscribe in the background since the responses
+		// are received in this loop
+		go s.resubscribe()
+	}
+}
+
+// read handles the messages of the connection until it fails
+func (s *stream) read(conn Codec) {
 	buf := []byte{}
 
 	for {
 		var err error
-		buf, err = s.codec.Read(buf[:0])
+		buf, err = conn.Read(buf[:0])
 		if err != nil {
-			if !s.isClosed() {
-				// log error
-			}
 			return
 		}
 
+		if msg := bytes.TrimLeft(buf, " \t\r\n"); len(msg) != 0 && msg[0] == '[' {
+			// response of a batch request
+			var resps []codec.Response
+			if err = json.Unmarshal(msg, &resps); err != nil {
+				return
+			}
+			for _, resp := range resps {
+				go s.handleMsg(resp)
+			}
+			continue
+		}
+
 		var resp codec.Response
 		if err = json.Unmarshal(buf, &resp); err != nil {
 			return
@@ -112,8 +234,130 @@ func (s *stream) listen() {
 			}
 
 			if respSub.Method == "eth_subscription" {
-				go s.handleSubscription(respSub)
+				s.pushEvent(respSub)
+			}
+		}
+	}
+}
+
+// redial connects again with an exponential backoff. It returns
+// nil if the stream is closed or there are too many attempts.
+func (s *stream) redial() Codec {
+	for attempt := 0; s.reconnect.MaxAttempts == 0 || attempt < s.reconnect.MaxAttempts; attempt++ {
+		select {
+		case <-time.After(backoff(s.reconnect.MinBackoff, s.reconnect.MaxBackoff, attempt)):
+		case <-s.closeCh:
+			return nil
+		}
+
+		conn, err := s.dial()
+		if err != nil {
+			continue
+		}
+		if s.isClosed() {
+			conn.Close()
+			return nil
+		}
+		return conn
+	}
+	return nil
+}
+
+// failHandlers fails all the pending requests since
+// their responses are lost with the connection
+func (s *stream) failHandlers() {
+	s.handlerLock.Lock()
+	handlers := s.handler
+	s.handler = map[uint64]callback{}
+	s.handlerLock.Unlock()
+
+	for _, callback := range handlers {
+		callback(nil, ErrConnectionLost)
+	}
+}
+
+// failSubscriptions ends all the subscriptions with ErrConnectionLost
+// when the stream does not reconnect
+func (s *stream) failSubscriptions() {
+	s.subsLock.Lock()
+	subs := s.lostSubs
+	for _, sub := range s.subs {
+		subs = append(subs, sub)
+	}
+	s.subs = map[string]*subscription{}
+	s.lostSubs = nil
+	s.subsLock.Unlock()
+
+	for _, sub := range subs {
+		if sub.notify != nil {
+			sub.notify(ErrConnectionLost)
+		}
+	}
+}
+
+// resubscribe re-establishes the subscriptions in the new connection
+func (s *stream) resubscribe() {
+	s.subsLock.Lock()
+	defer s.subsLock.Unlock()
+
+	subs := s.lostSubs
+	for _, sub := range s.subs {
+		subs = append(subs, sub)
+	}
+	s.subs = map[string]*subscription{}
+	s.lostSubs = nil
+
+	for _, sub := range subs {
+		id, err := s.subscribe(sub.method, sub.params)
+		if errors.Is(err, ErrConnectionLost) {
+			// the connection is lost again, retry in the next one
+			s.lostSubs = append(s.lostSubs, sub)
+			continue
+		}
+		if err != nil {
+			if sub.notify != nil {
+				sub.notify(fmt.Errorf("failed to resubscribe: %w", err))
 			}
+			continue
+		}
+		sub.id = id
+		s.subs[id] = sub
+
+		if sub.notify != nil {
+			sub.notify(nil)
+		}
+	}
+}
+
+// pushEvent queues the event of a subscription to be delivered
+func (s *stream) pushEvent(event codec.Request) {
+	s.eventsLock.Lock()
+	s.events = append(s.events, event)
+	s.eventsLock.Unlock()
+
+	select {
+	case s.eventsCh <- struct{}{}:
+	default:
+	}
+}
+
+// dispatchEvents delivers the events of the subscriptions in order. The
+// events are queued so that a slow callback does not block the responses.
+func (s *stream) dispatchEvents() {
+	for {
+		select {
+		case <-s.eventsCh:
+		case <-s.closeCh:
+			return
+		}
+
+		s.eventsLock.Lock()
+		events := s.events
+		s.events = nil
+		s.eventsLock.Unlock()
+
+		for _, event := range events {
+			s.handleSubscription(event)
 		}
 	}
 }
@@ -125,7 +369,7 @@ func (s *stream) handleSubscription(response codec.Request) {
 	}
 
 	s.subsLock.Lock()
-	callback, ok := s.subs[sub.ID]
+	subscription, ok := s.subs[sub.ID]
 	s.subsLock.Unlock()
 
 	if !ok {
@@ -133,7 +377,7 @@ func (s *stream) handleSubscription(response codec.Request) {
 	}
 
 	// call the callback function
-	callback(sub.Result)
+	subscription.callback(sub.Result)
 }
 
 func (s *stream) handleMsg(response codec.Response) {
@@ -166,21 +410,28 @@ func (s *stream) setHandler(id uint64, ack chan *ackMessage) {
 	s.handlerLock.Lock()
 	s.handler[id] = callback
 	s.handlerLock.Unlock()
+}
 
-	s.timer = time.AfterFunc(15*time.Second, func() {
-		s.handlerLock.Lock()
-		delete(s.handler, id)
-		s.handlerLock.Unlock()
-
-		select {
-		case ack <- &ackMessage{nil, ErrTimeout}:
-		default:
-		}
-	})
+func (s *stream) removeHandler(id uint64) {
+	s.handlerLock.Lock()
+	delete(s.handler, id)
+	s.handlerLock.Unlock()
 }
 
 // Call implements the transport interface
 func (s *stream) Call(method string, out interface{}, params ...interface{}) error {
+	return s.CallContext(context.Background(), method, out, params...)
+}
+
+// CallContext implements the ContextTransport interface
+func (s *stream) CallContext(ctx context.Context, method string, out interface{}, params ...interface{}) error {
+	_, hasDeadline := ctx.Deadline()
+	if !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+
 	seq := s.incSeq()
 	request := codec.Request{
 		JsonRPC: "2.0",
@@ -195,18 +446,32 @@ func (s *stream) Call(method string, out interface{}, params ...interface{}) err
 		request.Params = data
 	}
 
-	ack := make(chan *ackMessage)
-	s.setHandler(seq, ack)
-
 	raw, err := json.Marshal(request)
 	if err != nil {
 		return err
 	}
-	if err := s.codec.Write(raw); err != nil {
+
+	ack := make(chan *ackMessage, 1)
+	s.setHandler(seq, ack)
+
+	if err := s.write(raw); err != nil {
+		s.removeHandler(seq)
 		return err
 	}
 
-	resp := <-ack
+	var resp *ackMessage
+	select {
+	case resp = <-ack:
+	case <-ctx.Done():
+		// drop the handler, the response (if any) is discarded
+		s.removeHandler(seq)
+
+		if !hasDeadline && ctx.Err() == context.DeadlineExceeded {
+			return ErrTimeout
+		}
+		return ctx.Err()
+	}
+
 	if resp.err != nil {
 		return resp.err
 	}
@@ -216,17 +481,80 @@ func (s *stream) Call(method string, out interface{}, params ...interface{}) err
 	return nil
 }
 
-func (s *stream) unsubscribe(id string) error {
+// BatchCallContext implements the BatchTransport interface
+func (s *stream) BatchCallContext(ctx context.Context, elems []BatchElem) error {
+	_, hasDeadline := ctx.Deadline()
+	if !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+
+	ids := make([]uint64, len(elems))
+	for indx := range elems {
+		ids[indx] = s.incSeq()
+	}
+	raw, err := encodeBatch(elems, ids)
+	if err != nil {
+		return err
+	}
+
+	acks := make([]chan *ackMessage, len(elems))
+	for indx, id := range ids {
+		acks[indx] = make(chan *ackMessage, 1)
+		s.setHandler(id, acks[indx])
+	}
+	removeHandlers := func() {
+		for _, id := range ids {
+			s.removeHandler(id)
+		}
+	}
+
+	if err := s.write(raw); err != nil {
+		removeHandlers()
+		return err
+	}
+
+	for indx, ack := range acks {
+		select {
+		case resp := <-ack:
+			if resp.err == ErrConnectionLost {
+				// the whole batch can be retried
+				removeHandlers()
+				return resp.err
+			}
+			setBatchResult(&elems[indx], resp.buf, resp.err)
+		case <-ctx.Done():
+			// drop the pending handlers, the responses (if any) are discarded
+			removeHandlers()
+
+			if !hasDeadline && ctx.Err() == context.DeadlineExceeded {
+				return ErrTimeout
+			}
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (s *stream) unsubscribe(sub *subscription) error {
 	s.subsLock.Lock()
 	defer s.subsLock.Unlock()
 
-	if _, ok := s.subs[id]; !ok {
-		return fmt.Errorf("subscription %s not found", id)
+	for indx, lost := range s.lostSubs {
+		if lost == sub {
+			// the subscription does not exist in the current connection
+			s.lostSubs = append(s.lostSubs[:indx], s.lostSubs[indx+1:]...)
+			return nil
+		}
+	}
+	if current, ok := s.subs[sub.id]; !ok || current != sub {
+		return fmt.Errorf("subscription %s not found", sub.id)
 	}
-	delete(s.subs, id)
+	delete(s.subs, sub.id)
 
 	var result bool
-	if err := s.Call("eth_unsubscribe", &result, id); err != nil {
+	if err := s.Call("eth_unsubscribe", &result, sub.id); err != nil {
 		return err
 	}
 	if !result {
@@ -235,23 +563,43 @@ func (s *stream) unsubscribe(id string) error {
 	return nil
 }
 
-func (s *stream) setSubscription(id string, callback func(b []byte)) {
-	s.subsLock.Lock()
-	defer s.subsLock.Unlock()
-
-	s.subs[id] = callback
+// subscribe sends the eth_subscribe request and returns the id of the subscription
+func (s *stream) subscribe(method string, params []interface{}) (string, error) {
+	var out string
+	if err := s.Call("eth_subscribe", &out, append([]interface{}{method}, params...)...); err != nil {
+		return "", err
+	}
+	return out, nil
 }
 
 // Subscribe implements the PubSubTransport interface
 func (s *stream) Subscribe(method string, callback func(b []byte)) (func() error, error) {
-	var out string
-	if err := s.Call("eth_subscribe", &out, method); err != nil {
+	return s.SubscribeWithNotify(method, nil, callback, nil)
+}
+
+// SubscribeWithNotify implements the ResubscribeTransport interface
+func (s *stream) SubscribeWithNotify(method string, params []interface{}, callback func(b []byte), notify func(err error)) (func() error, error) {
+	// hold the lock until the subscription is registered
+	// to not miss any of its first events
+	s.subsLock.Lock()
+	defer s.subsLock.Unlock()
+
+	id, err := s.subscribe(method, params)
+	if err != nil {
 		return nil, err
 	}
 
-	s.setSubscription(out, callback)
+	sub := &subscription{
+		id:       id,
+		method:   method,
+		params:   params,
+		callback: callback,
+		notify:   notify,
+	}
+	s.subs[id] = sub
+
 	cancel := func() error {
-		return s.unsubscribe(out)
+		return s.unsubscribe(sub)
 	}
 	return cancel, nil
 }
@@ -262,13 +610,59 @@ func (s *stream) SetMaxConnsPerHost(count int) {
 
 type websocketCodec struct {
 	conn *websocket.Conn
+
+	// the connection does not support concurrent writers
+	writeLock sync.Mutex
+
+	closeCh   chan struct{}
+	closeOnce sync.Once
+}
+
+func newWebsocketCodec(conn *websocket.Conn, pingInterval, pongTimeout time.Duration) *websocketCodec {
+	w := &websocketCodec{
+		conn:    conn,
+		closeCh: make(chan struct{}),
+	}
+	if pingInterval != 0 {
+		if pongTimeout != 0 {
+			conn.SetReadDeadline(time.Now().Add(pongTimeout))
+			conn.SetPongHandler(func(string) error {
+				return conn.SetReadDeadline(time.Now().Add(pongTimeout))
+			})
+		}
+		go w.keepalive(pingInterval, pongTimeout)
+	}
+	return w
+}
+
+// keepalive sends pings to the server until the connection is closed
+func (w *websocketCodec) keepalive(pingInterval, pongTimeout time.Duration) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := w.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(pingInterval)); err != nil {
+				return
+			}
+		case <-w.closeCh:
+			return
+		}
+	}
 }
 
 func (w *websocketCodec) Close() error {
+	w.closeOnce.Do(func() {
+		close(w.closeCh)
+	})
 	return w.conn.Close()
 }
 
 func (w *websocketCodec) Write(b []byte) error {
+	w.writeLock.Lock()
+	defer w.writeLock.Unlock()
+
 	return w.conn.WriteMessage(websocket.TextMessage, b)
 }
 
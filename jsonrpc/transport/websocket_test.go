@@ -0,0 +1,289 @@
+package transport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/ethgo/jsonrpc/codec"
+)
+
+// mockWebsocketServer is a websocket server that replies to eth_subscribe
+// and eth_chainId, never replies to any other method and can drop the connections
+type mockWebsocketServer struct {
+	*httptest.Server
+
+	lock  sync.Mutex
+	conns []*websocket.Conn
+	subs  int
+
+	// dropSubscribes is the number of eth_subscribe
+	// requests that close the connection
+	dropSubscribes int
+}
+
+func newMockWebsocketServer(t *testing.T) *mockWebsocketServer {
+	m := &mockWebsocketServer{}
+	upgrader := websocket.Upgrader{}
+
+	m.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		m.lock.Lock()
+		m.conns = append(m.conns, conn)
+		m.lock.Unlock()
+
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req codec.Request
+			assert.NoError(t, json.Unmarshal(data, &req))
+
+			var result string
+			switch req.Method {
+			case "eth_subscribe":
+				m.lock.Lock()
+				if m.dropSubscribes > 0 {
+					m.dropSubscribes--
+					m.lock.Unlock()
+					return
+				}
+				m.subs++
+				result = fmt.Sprintf("0x%d", m.subs)
+				m.lock.Unlock()
+			case "eth_chainId":
+				result = "0x1"
+			default:
+				continue
+			}
+
+			resp := codec.Response{ID: req.ID, Result: json.RawMessage(`"` + result + `"`)}
+			m.lock.Lock()
+			err = conn.WriteJSON(resp)
+			m.lock.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}))
+	return m
+}
+
+func (m *mockWebsocketServer) setDropSubscribes(n int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.dropSubscribes = n
+}
+
+func (m *mockWebsocketServer) url() string {
+	return "ws" + strings.TrimPrefix(m.URL, "http")
+}
+
+// dropConns closes all the open connections
+func (m *mockWebsocketServer) dropConns() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, conn := range m.conns {
+		conn.Close()
+	}
+	m.conns = nil
+}
+
+// notify sends an event of the subscription in the last connection
+func (m *mockWebsocketServer) notify(id string, result string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	params, err := json.Marshal(codec.Subscription{ID: id, Result: json.RawMessage(result)})
+	if err != nil {
+		return err
+	}
+	return m.conns[len(m.conns)-1].WriteJSON(codec.Request{JsonRPC: "2.0", Method: "eth_subscription", Params: params})
+}
+
+func TestWebsocket_Reconnect(t *testing.T) {
+	srv := newMockWebsocketServer(t)
+	defer srv.Close()
+
+	config := DefaultReconnectConfig()
+	config.MinBackoff = 10 * time.Millisecond
+
+	tt, err := newWebsocket(srv.url(), nil, nil, config)
+	assert.NoError(t, err)
+	defer tt.Close()
+
+	events := make(chan string, 1)
+	notifyCh := make(chan error, 1)
+
+	s := tt.(*stream)
+	_, err = s.SubscribeWithNotify("newHeads", nil, func(b []byte) {
+		events <- string(b)
+	}, func(err error) {
+		notifyCh <- err
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, srv.notify("0x1", `"a"`))
+	assert.Equal(t, `"a"`, <-events)
+
+	// the in-flight calls fail when the connection is lost
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- tt.Call("eth_hang", new(string))
+	}()
+	time.Sleep(100 * time.Millisecond)
+	srv.dropConns()
+
+	select {
+	case err := <-errCh:
+		assert.ErrorIs(t, err, ErrConnectionLost)
+		assert.True(t, DefaultRetryConfig().IsRetryable(err))
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+
+	// the subscription is re-established with a new id
+	select {
+	case err := <-notifyCh:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+
+	assert.NoError(t, srv.notify("0x2", `"b"`))
+	assert.Equal(t, `"b"`, <-events)
+
+	// calls work with the new connection
+	var out string
+	assert.NoError(t, tt.Call("eth_chainId", &out))
+	assert.Equal(t, "0x1", out)
+}
+
+func TestWebsocket_ReconnectDisabled(t *testing.T) {
+	srv := newMockWebsocketServer(t)
+	defer srv.Close()
+
+	tt, err := newWebsocket(srv.url(), nil, nil, &ReconnectConfig{Disabled: true})
+	assert.NoError(t, err)
+	defer tt.Close()
+
+	assert.NoError(t, tt.Call("eth_chainId", new(string)))
+	srv.dropConns()
+
+	// wait for the listener to stop
+	time.Sleep(100 * time.Millisecond)
+	assert.ErrorIs(t, tt.Call("eth_chainId", new(string)), ErrConnectionLost)
+}
+
+func TestWebsocket_ReconnectDisabledNotify(t *testing.T) {
+	srv := newMockWebsocketServer(t)
+	defer srv.Close()
+
+	tt, err := newWebsocket(srv.url(), nil, nil, &ReconnectConfig{Disabled: true})
+	assert.NoError(t, err)
+	defer tt.Close()
+
+	notifyCh := make(chan error, 1)
+	_, err = tt.(*stream).SubscribeWithNotify("newHeads", nil, func(b []byte) {}, func(err error) {
+		notifyCh <- err
+	})
+	assert.NoError(t, err)
+
+	// the subscription ends since the connection is not re-established
+	srv.dropConns()
+
+	select {
+	case err := <-notifyCh:
+		assert.ErrorIs(t, err, ErrConnectionLost)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+}
+
+func TestWebsocket_ReconnectMaxAttempts(t *testing.T) {
+	srv := newMockWebsocketServer(t)
+	defer srv.Close()
+
+	config := DefaultReconnectConfig()
+	config.MinBackoff = 10 * time.Millisecond
+	config.MaxAttempts = 2
+
+	tt, err := newWebsocket(srv.url(), nil, nil, config)
+	assert.NoError(t, err)
+	defer tt.Close()
+
+	notifyCh := make(chan error, 1)
+	_, err = tt.(*stream).SubscribeWithNotify("newHeads", nil, func(b []byte) {}, func(err error) {
+		notifyCh <- err
+	})
+	assert.NoError(t, err)
+
+	// the server is down and the transport gives up
+	srv.Close()
+	srv.dropConns()
+
+	select {
+	case err := <-notifyCh:
+		assert.ErrorIs(t, err, ErrConnectionLost)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+}
+
+func TestWebsocket_ResubscribeConnectionLost(t *testing.T) {
+	srv := newMockWebsocketServer(t)
+	defer srv.Close()
+
+	config := DefaultReconnectConfig()
+	config.MinBackoff = 10 * time.Millisecond
+
+	tt, err := newWebsocket(srv.url(), nil, nil, config)
+	assert.NoError(t, err)
+	defer tt.Close()
+
+	events := make(chan string, 1)
+	notifyCh := make(chan error, 1)
+
+	_, err = tt.(*stream).SubscribeWithNotify("newHeads", nil, func(b []byte) {
+		events <- string(b)
+	}, func(err error) {
+		notifyCh <- err
+	})
+	assert.NoError(t, err)
+
+	// the connection is lost again while the subscription is re-established
+	srv.setDropSubscribes(1)
+	srv.dropConns()
+
+	// and the subscription is re-established in the next connection
+	select {
+	case err := <-notifyCh:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+
+	assert.NoError(t, srv.notify("0x2", `"a"`))
+
+	select {
+	case event := <-events:
+		assert.Equal(t, `"a"`, event)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+}
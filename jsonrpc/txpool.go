@@ -0,0 +1,173 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/umbracle/ethgo"
+)
+
+// TxPool is the txpool namespace
+type TxPool struct {
+	c *Client
+}
+
+// TxPool returns the reference to the txpool namespace
+func (c *Client) TxPool() *TxPool {
+	return c.endpoints.p
+}
+
+// WithContext returns the txpool namespace of a client that uses the context for all its requests
+func (t *TxPool) WithContext(ctx context.Context) *TxPool {
+	return t.c.WithContext(ctx).TxPool()
+}
+
+// TxPoolTransactions are the transactions in the pool grouped by sender and nonce
+type TxPoolTransactions map[ethgo.Address]map[uint64]*ethgo.Transaction
+
+func (t *TxPoolTransactions) UnmarshalJSON(data []byte) error {
+	var raw map[ethgo.Address]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	res := TxPoolTransactions{}
+	for addr, elem := range raw {
+		txns, err := decodeTxPoolNonces[*ethgo.Transaction](elem)
+		if err != nil {
+			return err
+		}
+		res[addr] = txns
+	}
+	*t = res
+	return nil
+}
+
+// decodeTxPoolNonces decodes the entries of an account in the
+// pool, which are indexed by the nonce in decimal format
+func decodeTxPoolNonces[T any](data []byte) (map[uint64]T, error) {
+	var raw map[string]T
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	res := map[uint64]T{}
+	for nonceStr, elem := range raw {
+		nonce, err := parseUint64orHex(nonceStr)
+		if err != nil {
+			return nil, err
+		}
+		res[nonce] = elem
+	}
+	return res, nil
+}
+
+// TxPoolContent are the pending and queued transactions in the pool
+type TxPoolContent struct {
+	Pending TxPoolTransactions `json:"pending"`
+	Queued  TxPoolTransactions `json:"queued"`
+}
+
+// Content returns the pending and queued transactions in the pool
+func (t *TxPool) Content() (*TxPoolContent, error) {
+	var out *TxPoolContent
+	if err := t.c.Call("txpool_content", &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TxPoolAccountContent are the pending and queued transactions of an account indexed by nonce
+type TxPoolAccountContent struct {
+	Pending map[uint64]*ethgo.Transaction
+	Queued  map[uint64]*ethgo.Transaction
+}
+
+func (t *TxPoolAccountContent) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Pending json.RawMessage `json:"pending"`
+		Queued  json.RawMessage `json:"queued"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	var err error
+	if t.Pending, err = decodeTxPoolNonces[*ethgo.Transaction](raw.Pending); err != nil {
+		return err
+	}
+	if t.Queued, err = decodeTxPoolNonces[*ethgo.Transaction](raw.Queued); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ContentFrom returns the pending and queued transactions of an account in the pool
+func (t *TxPool) ContentFrom(addr ethgo.Address) (*TxPoolAccountContent, error) {
+	var out *TxPoolAccountContent
+	if err := t.c.Call("txpool_contentFrom", &out, addr); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TxPoolStatus is the number of pending and queued transactions in the pool
+type TxPoolStatus struct {
+	Pending uint64
+	Queued  uint64
+}
+
+func (t *TxPoolStatus) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Pending ethgo.ArgUint64 `json:"pending"`
+		Queued  ethgo.ArgUint64 `json:"queued"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	t.Pending = raw.Pending.Uint64()
+	t.Queued = raw.Queued.Uint64()
+	return nil
+}
+
+// Status returns the number of pending and queued transactions in the pool
+func (t *TxPool) Status() (*TxPoolStatus, error) {
+	var out *TxPoolStatus
+	if err := t.c.Call("txpool_status", &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TxPoolSummaries are the summaries of the transactions in
+// the pool (i.e. 'to: value wei + gas × price wei') grouped by sender and nonce
+type TxPoolSummaries map[ethgo.Address]map[uint64]string
+
+func (t *TxPoolSummaries) UnmarshalJSON(data []byte) error {
+	var raw map[ethgo.Address]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	res := TxPoolSummaries{}
+	for addr, elem := range raw {
+		summaries, err := decodeTxPoolNonces[string](elem)
+		if err != nil {
+			return err
+		}
+		res[addr] = summaries
+	}
+	*t = res
+	return nil
+}
+
+// TxPoolInspect are the summaries of the pending and queued transactions in the pool
+type TxPoolInspect struct {
+	Pending TxPoolSummaries `json:"pending"`
+	Queued  TxPoolSummaries `json:"queued"`
+}
+
+// Inspect returns the summaries of the pending and queued transactions in the pool
+func (t *TxPool) Inspect() (*TxPoolInspect, error) {
+	var out *TxPoolInspect
+	if err := t.c.Call("txpool_inspect", &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
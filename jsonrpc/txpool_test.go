@@ -0,0 +1,62 @@
+package jsonrpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/ethgo"
+)
+
+func TestTxPool_Content(t *testing.T) {
+	txn := readTestsuite(t, "transaction-pending.json")
+
+	c, srv := newMockClient(t, map[string]string{
+		"txpool_content": `{
+			"pending": {"0x0000000000000000000000000000000000000001": {"16": ` + txn + `}},
+			"queued": {"0x0000000000000000000000000000000000000002": {"20": ` + txn + `, "21": ` + txn + `}}
+		}`,
+		"txpool_contentFrom": `{"pending": {"16": ` + txn + `}, "queued": {}}`,
+	})
+
+	content, err := c.TxPool().Content()
+	assert.NoError(t, err)
+	assert.Len(t, content.Pending, 1)
+	assert.Equal(t, uint64(16), content.Pending[ethgo.HexToAddress("0x1")][16].Nonce)
+	assert.Len(t, content.Queued[ethgo.HexToAddress("0x2")], 2)
+	assert.NotNil(t, content.Queued[ethgo.HexToAddress("0x2")][21])
+
+	from, err := c.TxPool().ContentFrom(ethgo.HexToAddress("0x1"))
+	assert.NoError(t, err)
+	assert.Equal(t, `["0x0000000000000000000000000000000000000001"]`, string(srv.params["txpool_contentFrom"]))
+	assert.Len(t, from.Pending, 1)
+	assert.Equal(t, uint64(16), from.Pending[16].Gas)
+	assert.Empty(t, from.Queued)
+}
+
+func TestTxPool_StatusAndInspect(t *testing.T) {
+	c, _ := newMockClient(t, map[string]string{
+		"txpool_status": `{"pending": "0xa", "queued": "0x7"}`,
+		"txpool_inspect": `{
+			"pending": {"0x0000000000000000000000000000000000000001": {"1": "0x0000000000000000000000000000000000000002: 1 wei + 21000 gas × 1 wei"}},
+			"queued": {}
+		}`,
+	})
+
+	status, err := c.TxPool().Status()
+	assert.NoError(t, err)
+	assert.Equal(t, &TxPoolStatus{Pending: 10, Queued: 7}, status)
+
+	inspect, err := c.TxPool().Inspect()
+	assert.NoError(t, err)
+	assert.Contains(t, inspect.Pending[ethgo.HexToAddress("0x1")][1], "21000 gas")
+	assert.Empty(t, inspect.Queued)
+}
+
+func TestTxPool_InvalidNonce(t *testing.T) {
+	c, _ := newMockClient(t, map[string]string{
+		"txpool_inspect": `{"pending": {"0x0000000000000000000000000000000000000001": {"a": ""}}, "queued": {}}`,
+	})
+
+	_, err := c.TxPool().Inspect()
+	assert.Error(t, err)
+}
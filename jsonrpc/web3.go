@@ -1,5 +1,7 @@
 package jsonrpc
 
+import "context"
+
 // Web3 is the web3 namespace
 type Web3 struct {
 	c *Client
@@ -10,6 +12,11 @@ func (c *Client) Web3() *Web3 {
 	return c.endpoints.w
 }
 
+// WithContext returns the web3 namespace of a client that uses the context for all its requests
+func (w *Web3) WithContext(ctx context.Context) *Web3 {
+	return w.c.WithContext(ctx).Web3()
+}
+
 // ClientVersion returns the current client version
 func (w *Web3) ClientVersion() (string, error) {
 	var out string
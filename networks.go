@@ -1,5 +1,13 @@
 package ethgo
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
 // Network is a chain id
 type Network uint64
 
@@ -8,11 +16,348 @@ const (
 	Mainnet Network = 1
 
 	// Ropsten is the POW testnet
+	//
+	// Deprecated: Ropsten has been shut down.
 	Ropsten Network = 3
 
 	// Rinkeby is a POW testnet
+	//
+	// Deprecated: Rinkeby has been shut down.
 	Rinkeby Network = 4
 
 	// Goerli is the Clique testnet
+	//
+	// Deprecated: Goerli has been shut down.
 	Goerli Network = 5
+
+	// Optimism is the OP mainnet L2 network
+	Optimism Network = 10
+
+	// Polygon is the Polygon PoS network
+	Polygon Network = 137
+
+	// ZkSync is the zkSync Era L2 network
+	ZkSync Network = 324
+
+	// Base is the Base L2 network
+	Base Network = 8453
+
+	// Holesky is the proof of stake testnet for staking
+	//
+	// Deprecated: Holesky has been shut down.
+	Holesky Network = 17000
+
+	// Arbitrum is the Arbitrum One L2 network
+	Arbitrum Network = 42161
+
+	// Linea is the Linea L2 network
+	Linea Network = 59144
+
+	// BaseSepolia is the Base L2 testnet
+	BaseSepolia Network = 84532
+
+	// ArbitrumSepolia is the Arbitrum L2 testnet
+	ArbitrumSepolia Network = 421614
+
+	// Scroll is the Scroll L2 network
+	Scroll Network = 534352
+
+	// Sepolia is the proof of stake testnet for applications
+	Sepolia Network = 11155111
+
+	// OptimismSepolia is the OP L2 testnet
+	OptimismSepolia Network = 11155420
 )
+
+// NativeCurrency is the currency used to pay for gas in a chain
+type NativeCurrency struct {
+	Name     string
+	Symbol   string
+	Decimals uint8
+}
+
+// Chain is the metadata of a network
+type Chain struct {
+	// Name is the unique name of the chain (i.e. mainnet)
+	Name string
+
+	// ChainID is the chain id of the network
+	ChainID Network
+
+	// NativeCurrency is the currency used to pay for gas
+	NativeCurrency NativeCurrency
+
+	// EnsRegistry is the address of the ENS registry, if any
+	EnsRegistry Address
+
+	// EtherscanAPI is the url of the Etherscan compatible api, if any. The
+	// Etherscan V2 api is shared by all the chains and the chain is selected
+	// with the 'chainid' query parameter.
+	EtherscanAPI string
+
+	// BlockTime is the average time between blocks
+	BlockTime time.Duration
+
+	// EIP1559 is whether the chain supports dynamic fee transactions
+	EIP1559 bool
+
+	// Finality is whether the chain supports the 'safe'
+	// and 'finalized' block tags
+	Finality bool
+}
+
+// Copy makes a copy of the chain
+func (c *Chain) Copy() *Chain {
+	cc := new(Chain)
+	*cc = *c
+	return cc
+}
+
+var (
+	etherCurrency = NativeCurrency{Name: "Ether", Symbol: "ETH", Decimals: 18}
+
+	ensRegistry = HexToAddress("0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e")
+
+	etherscanV2API = "https://api.etherscan.io/v2"
+)
+
+var builtinChains = []*Chain{
+	{
+		Name:           "mainnet",
+		ChainID:        Mainnet,
+		NativeCurrency: etherCurrency,
+		EnsRegistry:    ensRegistry,
+		EtherscanAPI:   etherscanV2API,
+		BlockTime:      12 * time.Second,
+		EIP1559:        true,
+		Finality:       true,
+	},
+	{
+		Name:           "ropsten",
+		ChainID:        Ropsten,
+		NativeCurrency: etherCurrency,
+		EnsRegistry:    ensRegistry,
+		BlockTime:      12 * time.Second,
+		EIP1559:        true,
+		Finality:       true,
+	},
+	{
+		Name:           "rinkeby",
+		ChainID:        Rinkeby,
+		NativeCurrency: etherCurrency,
+		EnsRegistry:    ensRegistry,
+		BlockTime:      15 * time.Second,
+		EIP1559:        true,
+	},
+	{
+		Name:           "goerli",
+		ChainID:        Goerli,
+		NativeCurrency: etherCurrency,
+		EnsRegistry:    ensRegistry,
+		BlockTime:      12 * time.Second,
+		EIP1559:        true,
+		Finality:       true,
+	},
+	{
+		Name:           "sepolia",
+		ChainID:        Sepolia,
+		NativeCurrency: etherCurrency,
+		EnsRegistry:    ensRegistry,
+		EtherscanAPI:   etherscanV2API,
+		BlockTime:      12 * time.Second,
+		EIP1559:        true,
+		Finality:       true,
+	},
+	{
+		Name:           "holesky",
+		ChainID:        Holesky,
+		NativeCurrency: etherCurrency,
+		EnsRegistry:    ensRegistry,
+		BlockTime:      12 * time.Second,
+		EIP1559:        true,
+		Finality:       true,
+	},
+	{
+		Name:           "optimism",
+		ChainID:        Optimism,
+		NativeCurrency: etherCurrency,
+		EtherscanAPI:   etherscanV2API,
+		BlockTime:      2 * time.Second,
+		EIP1559:        true,
+		Finality:       true,
+	},
+	{
+		Name:           "optimism-sepolia",
+		ChainID:        OptimismSepolia,
+		NativeCurrency: etherCurrency,
+		EtherscanAPI:   etherscanV2API,
+		BlockTime:      2 * time.Second,
+		EIP1559:        true,
+		Finality:       true,
+	},
+	{
+		Name:           "arbitrum",
+		ChainID:        Arbitrum,
+		NativeCurrency: etherCurrency,
+		EtherscanAPI:   etherscanV2API,
+		BlockTime:      250 * time.Millisecond,
+		EIP1559:        true,
+		Finality:       true,
+	},
+	{
+		Name:           "arbitrum-sepolia",
+		ChainID:        ArbitrumSepolia,
+		NativeCurrency: etherCurrency,
+		EtherscanAPI:   etherscanV2API,
+		BlockTime:      250 * time.Millisecond,
+		EIP1559:        true,
+		Finality:       true,
+	},
+	{
+		Name:           "base",
+		ChainID:        Base,
+		NativeCurrency: etherCurrency,
+		EtherscanAPI:   etherscanV2API,
+		BlockTime:      2 * time.Second,
+		EIP1559:        true,
+		Finality:       true,
+	},
+	{
+		Name:           "base-sepolia",
+		ChainID:        BaseSepolia,
+		NativeCurrency: etherCurrency,
+		EtherscanAPI:   etherscanV2API,
+		BlockTime:      2 * time.Second,
+		EIP1559:        true,
+		Finality:       true,
+	},
+	{
+		Name:           "polygon",
+		ChainID:        Polygon,
+		NativeCurrency: NativeCurrency{Name: "POL", Symbol: "POL", Decimals: 18},
+		EtherscanAPI:   etherscanV2API,
+		BlockTime:      2 * time.Second,
+		EIP1559:        true,
+		Finality:       true,
+	},
+	{
+		Name:           "zksync",
+		ChainID:        ZkSync,
+		NativeCurrency: etherCurrency,
+		EtherscanAPI:   etherscanV2API,
+		BlockTime:      time.Second,
+		EIP1559:        true,
+		Finality:       true,
+	},
+	{
+		Name:           "linea",
+		ChainID:        Linea,
+		NativeCurrency: etherCurrency,
+		EtherscanAPI:   etherscanV2API,
+		BlockTime:      2 * time.Second,
+		EIP1559:        true,
+		Finality:       true,
+	},
+	{
+		Name:           "scroll",
+		ChainID:        Scroll,
+		NativeCurrency: etherCurrency,
+		EtherscanAPI:   etherscanV2API,
+		BlockTime:      3 * time.Second,
+		EIP1559:        true,
+		Finality:       true,
+	},
+}
+
+// chainRegistry is the registry of the known chains indexed by id and name
+type chainRegistry struct {
+	lock   sync.RWMutex
+	byID   map[Network]*Chain
+	byName map[string]*Chain
+}
+
+var chains = newChainRegistry()
+
+func newChainRegistry() *chainRegistry {
+	r := &chainRegistry{
+		byID:   map[Network]*Chain{},
+		byName: map[string]*Chain{},
+	}
+	for _, c := range builtinChains {
+		if err := r.register(c); err != nil {
+			panic(fmt.Sprintf("BUG: %v", err))
+		}
+	}
+	return r
+}
+
+func (r *chainRegistry) register(c *Chain) error {
+	name := strings.ToLower(c.Name)
+	if name == "" {
+		return fmt.Errorf("chain name is empty")
+	}
+	if _, ok := r.byID[c.ChainID]; ok {
+		return fmt.Errorf("chain with id %d already registered", c.ChainID)
+	}
+	if _, ok := r.byName[name]; ok {
+		return fmt.Errorf("chain with name '%s' already registered", c.Name)
+	}
+
+	c = c.Copy()
+	r.byID[c.ChainID] = c
+	r.byName[name] = c
+	return nil
+}
+
+// RegisterChain adds a custom chain to the registry. It fails if there is
+// already a chain with the same id or name.
+func RegisterChain(c *Chain) error {
+	chains.lock.Lock()
+	defer chains.lock.Unlock()
+
+	return chains.register(c)
+}
+
+// ChainByID returns the chain with the given id
+func ChainByID(id Network) (*Chain, bool) {
+	chains.lock.RLock()
+	defer chains.lock.RUnlock()
+
+	c, ok := chains.byID[id]
+	if !ok {
+		return nil, false
+	}
+	return c.Copy(), true
+}
+
+// ChainByName returns the chain with the given name (case insensitive)
+func ChainByName(name string) (*Chain, bool) {
+	chains.lock.RLock()
+	defer chains.lock.RUnlock()
+
+	c, ok := chains.byName[strings.ToLower(name)]
+	if !ok {
+		return nil, false
+	}
+	return c.Copy(), true
+}
+
+// Chains returns all the registered chains sorted by chain id
+func Chains() []*Chain {
+	chains.lock.RLock()
+	defer chains.lock.RUnlock()
+
+	res := make([]*Chain, 0, len(chains.byID))
+	for _, c := range chains.byID {
+		res = append(res, c.Copy())
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ChainID < res[j].ChainID
+	})
+	return res
+}
+
+// Chain returns the metadata of the network
+func (n Network) Chain() (*Chain, bool) {
+	return ChainByID(n)
+}
@@ -0,0 +1,65 @@
+package ethgo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworks_Lookup(t *testing.T) {
+	c, ok := ChainByID(Sepolia)
+	assert.True(t, ok)
+	assert.Equal(t, "sepolia", c.Name)
+	assert.Equal(t, "ETH", c.NativeCurrency.Symbol)
+	assert.NotEqual(t, ZeroAddress, c.EnsRegistry)
+
+	c, ok = ChainByName("Base")
+	assert.True(t, ok)
+	assert.Equal(t, Base, c.ChainID)
+	assert.Equal(t, ZeroAddress, c.EnsRegistry)
+
+	c, ok = Mainnet.Chain()
+	assert.True(t, ok)
+	assert.Equal(t, "mainnet", c.Name)
+
+	_, ok = ChainByID(Network(123456789))
+	assert.False(t, ok)
+
+	_, ok = ChainByName("unknown")
+	assert.False(t, ok)
+
+	// the chains in the registry cannot be modified
+	c.Name = "other"
+	c, _ = Mainnet.Chain()
+	assert.Equal(t, "mainnet", c.Name)
+}
+
+func TestNetworks_Register(t *testing.T) {
+	chain := &Chain{
+		Name:    "custom",
+		ChainID: Network(987654321),
+	}
+	assert.NoError(t, RegisterChain(chain))
+
+	c, ok := ChainByName("custom")
+	assert.True(t, ok)
+	assert.Equal(t, chain, c)
+
+	c, ok = ChainByID(Network(987654321))
+	assert.True(t, ok)
+	assert.Equal(t, chain, c)
+
+	// duplicated id or name
+	assert.Error(t, RegisterChain(&Chain{Name: "other", ChainID: Mainnet}))
+	assert.Error(t, RegisterChain(&Chain{Name: "MAINNET", ChainID: Network(987654322)}))
+	assert.Error(t, RegisterChain(&Chain{ChainID: Network(987654323)}))
+}
+
+func TestNetworks_Chains(t *testing.T) {
+	chains := Chains()
+	assert.Equal(t, Mainnet, chains[0].ChainID)
+
+	for i := 1; i < len(chains); i++ {
+		assert.Less(t, chains[i-1].ChainID, chains[i].ChainID)
+	}
+}
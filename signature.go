@@ -0,0 +1,183 @@
+package ethgo
+
+import (
+	"fmt"
+	"math/big"
+)
+
+var (
+	// secp256k1N is the order of the secp256k1 curve
+	secp256k1N, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+
+	// secp256k1HalfN is the maximum value of S in a non malleable signature
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+// Signature is a secp256k1 signature
+type Signature struct {
+	R *big.Int
+	S *big.Int
+
+	// YParity is the recovery id of the signature (either 0 or 1).
+	// It is the V value of the signature without the offset of 27.
+	YParity uint8
+}
+
+// SignatureFromBytes decodes a signature either in its 65 bytes form [R || S || V]
+// with V being 0, 1, 27 or 28, or in the 64 bytes compact form of eip-2098.
+func SignatureFromBytes(b []byte) (*Signature, error) {
+	switch len(b) {
+	case 65:
+		v := b[64]
+		if v >= 27 {
+			v -= 27
+		}
+		if v > 1 {
+			return nil, fmt.Errorf("invalid signature v value %d", b[64])
+		}
+		sig := &Signature{
+			R:       new(big.Int).SetBytes(b[:32]),
+			S:       new(big.Int).SetBytes(b[32:64]),
+			YParity: v,
+		}
+		return sig, nil
+
+	case 64:
+		// the y parity is the top bit of S
+		s := make([]byte, 32)
+		copy(s, b[32:])
+
+		yParity := s[0] >> 7
+		s[0] &= 0x7f
+
+		sig := &Signature{
+			R:       new(big.Int).SetBytes(b[:32]),
+			S:       new(big.Int).SetBytes(s),
+			YParity: yParity,
+		}
+		return sig, nil
+
+	default:
+		return nil, fmt.Errorf("invalid signature length %d", len(b))
+	}
+}
+
+// Bytes returns the 65 bytes form of the signature [R || S || V] with V being
+// either 0 or 1. This is the format used by Key.Sign.
+func (s *Signature) Bytes() ([]byte, error) {
+	if err := s.checkSize(); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 65)
+	s.R.FillBytes(buf[:32])
+	s.S.FillBytes(buf[32:64])
+	buf[64] = s.YParity
+	return buf, nil
+}
+
+// BytesV27 returns the 65 bytes form of the signature [R || S || V] with V being
+// either 27 or 28. This is the format expected by the ecrecover precompile.
+func (s *Signature) BytesV27() ([]byte, error) {
+	buf, err := s.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	buf[64] += 27
+	return buf, nil
+}
+
+// CompactBytes returns the 64 bytes compact form of the signature (eip-2098).
+// The compact form only represents signatures with a low S value, a malleable
+// signature is normalized first.
+func (s *Signature) CompactBytes() ([]byte, error) {
+	if err := s.checkSize(); err != nil {
+		return nil, err
+	}
+	if s.YParity > 1 {
+		return nil, fmt.Errorf("invalid y parity %d", s.YParity)
+	}
+	if s.S.Cmp(secp256k1N) >= 0 {
+		return nil, fmt.Errorf("invalid signature s value")
+	}
+	ss := s.Normalize()
+
+	buf := make([]byte, 64)
+	ss.R.FillBytes(buf[:32])
+	ss.S.FillBytes(buf[32:])
+	buf[32] |= ss.YParity << 7
+	return buf, nil
+}
+
+// checkSize checks that the values of the signature fit in 32 bytes
+func (s *Signature) checkSize() error {
+	if s.R == nil || s.S == nil {
+		return fmt.Errorf("signature values not set")
+	}
+	if s.R.Sign() < 0 || s.R.BitLen() > 256 || s.S.Sign() < 0 || s.S.BitLen() > 256 {
+		return fmt.Errorf("signature values do not fit in 32 bytes")
+	}
+	return nil
+}
+
+// IsMalleable returns true if the S value of the signature is in the upper half
+// of the curve order. Only signatures with a low S value are valid since homestead.
+func (s *Signature) IsMalleable() bool {
+	return s.S.Cmp(secp256k1HalfN) > 0
+}
+
+// Normalize returns the equivalent signature with a low S value
+func (s *Signature) Normalize() *Signature {
+	if !s.IsMalleable() {
+		return s.Copy()
+	}
+	return &Signature{
+		R:       new(big.Int).Set(s.R),
+		S:       new(big.Int).Sub(secp256k1N, s.S),
+		YParity: s.YParity ^ 1,
+	}
+}
+
+// Validate checks that the values of the signature are in range and
+// that the signature is not malleable
+func (s *Signature) Validate() error {
+	if err := s.ValidateValues(); err != nil {
+		return err
+	}
+	if s.IsMalleable() {
+		return fmt.Errorf("malleable signature with high s value")
+	}
+	return nil
+}
+
+// ValidateValues checks that the values of the signature are in range. Unlike
+// Validate, it accepts malleable signatures which are valid in the legacy
+// transactions before homestead.
+func (s *Signature) ValidateValues() error {
+	if s.R == nil || s.S == nil {
+		return fmt.Errorf("signature values not set")
+	}
+	if s.YParity > 1 {
+		return fmt.Errorf("invalid y parity %d", s.YParity)
+	}
+	if s.R.Sign() <= 0 || s.R.Cmp(secp256k1N) >= 0 {
+		return fmt.Errorf("invalid signature r value")
+	}
+	if s.S.Sign() <= 0 || s.S.Cmp(secp256k1N) >= 0 {
+		return fmt.Errorf("invalid signature s value")
+	}
+	return nil
+}
+
+// Copy makes a deep copy of the signature
+func (s *Signature) Copy() *Signature {
+	ss := &Signature{
+		YParity: s.YParity,
+	}
+	if s.R != nil {
+		ss.R = new(big.Int).Set(s.R)
+	}
+	if s.S != nil {
+		ss.S = new(big.Int).Set(s.S)
+	}
+	return ss
+}
@@ -0,0 +1,143 @@
+package ethgo
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignature_Encoding(t *testing.T) {
+	// eip-2098 test cases
+	cases := []struct {
+		r, s    string
+		yParity uint8
+		compact string
+	}{
+		{
+			"68a020a209d3d56c46f38cc50a33f704f4a9a10a59377f8dd762ac66910e9b90",
+			"7e865ad05c4035ab5792787d4a0297a43617ae897930a6fe4d822b8faea52064",
+			0,
+			"68a020a209d3d56c46f38cc50a33f704f4a9a10a59377f8dd762ac66910e9b907e865ad05c4035ab5792787d4a0297a43617ae897930a6fe4d822b8faea52064",
+		},
+		{
+			"9328da16089fcba9bececa81663203989f2df5fe1faa6291a45381c81bd17f76",
+			"139c6d6b623b42da56557e5e734a43dc83345ddfadec52cbe24d0cc64f550793",
+			1,
+			"9328da16089fcba9bececa81663203989f2df5fe1faa6291a45381c81bd17f76939c6d6b623b42da56557e5e734a43dc83345ddfadec52cbe24d0cc64f550793",
+		},
+	}
+	for _, c := range cases {
+		r, _ := new(big.Int).SetString(c.r, 16)
+		s, _ := new(big.Int).SetString(c.s, 16)
+		sig := &Signature{R: r, S: s, YParity: c.yParity}
+
+		compact, err := sig.CompactBytes()
+		assert.NoError(t, err)
+		assert.Equal(t, c.compact, hex.EncodeToString(compact))
+
+		// compact form
+		sig2, err := SignatureFromBytes(compact)
+		assert.NoError(t, err)
+		assert.Equal(t, sig, sig2)
+
+		// the compact form of the malleable signature is the same
+		high := &Signature{R: sig.R, S: new(big.Int).Sub(secp256k1N, sig.S), YParity: sig.YParity ^ 1}
+		compact, err = high.CompactBytes()
+		assert.NoError(t, err)
+		assert.Equal(t, c.compact, hex.EncodeToString(compact))
+
+		// 65 bytes form with v either as 0/1 or 27/28
+		raw, err := sig.Bytes()
+		assert.NoError(t, err)
+		assert.Equal(t, c.yParity, raw[64])
+
+		sig2, err = SignatureFromBytes(raw)
+		assert.NoError(t, err)
+		assert.Equal(t, sig, sig2)
+
+		raw, err = sig.BytesV27()
+		assert.NoError(t, err)
+		assert.Equal(t, c.yParity+27, raw[64])
+
+		sig2, err = SignatureFromBytes(raw)
+		assert.NoError(t, err)
+		assert.Equal(t, sig, sig2)
+	}
+}
+
+func TestSignature_BytesInvalid(t *testing.T) {
+	cases := []*Signature{
+		{},
+		{R: big.NewInt(1)},
+		{R: big.NewInt(-1), S: big.NewInt(1)},
+		{R: big.NewInt(1), S: new(big.Int).Lsh(big.NewInt(1), 256)},
+	}
+	for _, c := range cases {
+		_, err := c.Bytes()
+		assert.Error(t, err)
+
+		_, err = c.BytesV27()
+		assert.Error(t, err)
+
+		_, err = c.CompactBytes()
+		assert.Error(t, err)
+	}
+}
+
+func TestSignature_FromBytesInvalid(t *testing.T) {
+	_, err := SignatureFromBytes(make([]byte, 63))
+	assert.Error(t, err)
+
+	raw := make([]byte, 65)
+	raw[64] = 2
+	_, err = SignatureFromBytes(raw)
+	assert.Error(t, err)
+
+	raw[64] = 29
+	_, err = SignatureFromBytes(raw)
+	assert.Error(t, err)
+}
+
+func TestSignature_Normalize(t *testing.T) {
+	sig := &Signature{
+		R:       big.NewInt(1),
+		S:       new(big.Int).Sub(secp256k1N, big.NewInt(1)),
+		YParity: 0,
+	}
+	assert.True(t, sig.IsMalleable())
+	assert.Error(t, sig.Validate())
+
+	low := sig.Normalize()
+	assert.False(t, low.IsMalleable())
+	assert.NoError(t, low.Validate())
+	assert.Equal(t, big.NewInt(1), low.S)
+	assert.Equal(t, uint8(1), low.YParity)
+
+	// the original signature is not modified
+	assert.True(t, sig.IsMalleable())
+
+	// a low S signature does not change
+	assert.Equal(t, low, low.Normalize())
+}
+
+func TestSignature_Validate(t *testing.T) {
+	cases := []*Signature{
+		{},
+		{R: big.NewInt(0), S: big.NewInt(1)},
+		{R: big.NewInt(1), S: big.NewInt(0)},
+		{R: new(big.Int).Set(secp256k1N), S: big.NewInt(1)},
+		{R: big.NewInt(1), S: big.NewInt(1), YParity: 2},
+	}
+	for _, c := range cases {
+		assert.Error(t, c.Validate())
+		assert.Error(t, c.ValidateValues())
+	}
+	assert.NoError(t, (&Signature{R: big.NewInt(1), S: secp256k1HalfN}).Validate())
+
+	// a malleable signature has valid values
+	high := &Signature{R: big.NewInt(1), S: new(big.Int).Add(secp256k1HalfN, big.NewInt(1))}
+	assert.Error(t, high.Validate())
+	assert.NoError(t, high.ValidateValues())
+}
@@ -1,11 +1,14 @@
 package ethgo
 
 import (
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"math/big"
 	"strconv"
 	"strings"
+
+	"github.com/umbracle/fastrlp"
 )
 
 var (
@@ -63,12 +66,18 @@ func (a Address) Bytes() []byte {
 }
 
 func (a Address) String() string {
-	return a.checksumEncode()
+	return a.checksumEncode("")
+}
+
+// ChecksumWithChainID returns the address with the chain aware
+// checksum of eip-1191
+func (a Address) ChecksumWithChainID(chainID uint64) string {
+	return a.checksumEncode(strconv.FormatUint(chainID, 10) + "0x")
 }
 
-func (a Address) checksumEncode() string {
+func (a Address) checksumEncode(prefix string) string {
 	address := strings.ToLower(hex.EncodeToString(a[:]))
-	hash := hex.EncodeToString(Keccak256([]byte(address)))
+	hash := hex.EncodeToString(Keccak256([]byte(prefix + address)))
 
 	ret := "0x"
 	for i := 0; i < len(address); i++ {
@@ -85,6 +94,67 @@ func (a Address) checksumEncode() string {
 	return ret
 }
 
+// ParseAddress parses an hex string address. It fails if the string is not
+// 20 bytes long or if it has mixed case and the eip-55 checksum is not valid.
+func ParseAddress(str string) (Address, error) {
+	return parseAddress(str, func(a Address) string {
+		return a.String()
+	})
+}
+
+// ParseAddressWithChainID parses an hex string address like ParseAddress
+// but it validates the chain aware checksum of eip-1191
+func ParseAddressWithChainID(str string, chainID uint64) (Address, error) {
+	return parseAddress(str, func(a Address) string {
+		return a.ChecksumWithChainID(chainID)
+	})
+}
+
+func parseAddress(str string, checksum func(a Address) string) (Address, error) {
+	var a Address
+
+	raw := str
+	if strings.HasPrefix(raw, "0x") || strings.HasPrefix(raw, "0X") {
+		raw = raw[2:]
+	}
+	if len(raw) != 40 {
+		return a, fmt.Errorf("invalid address '%s': expected 40 hex characters but found %d", str, len(raw))
+	}
+	buf, err := hex.DecodeString(raw)
+	if err != nil {
+		return a, fmt.Errorf("invalid address '%s': %v", str, err)
+	}
+	copy(a[:], buf)
+
+	// an address in a single case does not have a checksum
+	if raw == strings.ToLower(raw) || raw == strings.ToUpper(raw) {
+		return a, nil
+	}
+	if checksum(a)[2:] != raw {
+		return a, fmt.Errorf("invalid address '%s': bad checksum", str)
+	}
+	return a, nil
+}
+
+// CreateAddress returns the address of a contract deployed
+// by sender with a transaction with the given nonce
+func CreateAddress(sender Address, nonce uint64) Address {
+	a := fastrlp.DefaultArenaPool.Get()
+	defer fastrlp.DefaultArenaPool.Put(a)
+
+	v := a.NewArray()
+	v.Set(a.NewCopyBytes(sender[:]))
+	v.Set(a.NewUint(nonce))
+
+	return BytesToAddress(Keccak256(v.MarshalTo(nil))[12:])
+}
+
+// Create2Address returns the address of a contract deployed by
+// deployer with the CREATE2 opcode (eip-1014)
+func Create2Address(deployer Address, salt Hash, initCodeHash Hash) Address {
+	return BytesToAddress(Keccak256([]byte{0xff}, deployer[:], salt[:], initCodeHash[:])[12:])
+}
+
 // Hash is an Ethereum hash
 type Hash [32]byte
 
@@ -138,6 +208,7 @@ type Block struct {
 	StateRoot          Hash
 	ReceiptsRoot       Hash
 	Miner              Address
+	LogsBloom          []byte
 	Difficulty         *big.Int
 	ExtraData          []byte
 	GasLimit           uint64
@@ -149,6 +220,18 @@ type Block struct {
 	TransactionsHashes []Hash
 	Uncles             []Hash
 	BaseFee            *big.Int
+
+	// eip-4895 values (shanghai)
+	WithdrawalsRoot *Hash
+	Withdrawals     []*Withdrawal
+
+	// eip-4844 and eip-4788 values (cancun)
+	BlobGasUsed           *uint64
+	ExcessBlobGas         *uint64
+	ParentBeaconBlockRoot *Hash
+
+	// eip-7685 values (prague)
+	RequestsHash *Hash
 }
 
 func (b *Block) Copy() *Block {
@@ -158,13 +241,55 @@ func (b *Block) Copy() *Block {
 		bb.Difficulty = new(big.Int).Set(b.Difficulty)
 	}
 	bb.ExtraData = append(bb.ExtraData[:0], b.ExtraData...)
+	if b.LogsBloom != nil {
+		bb.LogsBloom = append([]byte{}, b.LogsBloom...)
+	}
 	bb.Transactions = make([]*Transaction, len(b.Transactions))
 	for indx, txn := range b.Transactions {
 		bb.Transactions[indx] = txn.Copy()
 	}
+	if b.BaseFee != nil {
+		bb.BaseFee = new(big.Int).Set(b.BaseFee)
+	}
+	if b.WithdrawalsRoot != nil {
+		root := *b.WithdrawalsRoot
+		bb.WithdrawalsRoot = &root
+	}
+	if b.Withdrawals != nil {
+		bb.Withdrawals = make([]*Withdrawal, len(b.Withdrawals))
+		for indx, w := range b.Withdrawals {
+			ww := *w
+			bb.Withdrawals[indx] = &ww
+		}
+	}
+	if b.BlobGasUsed != nil {
+		blobGasUsed := *b.BlobGasUsed
+		bb.BlobGasUsed = &blobGasUsed
+	}
+	if b.ExcessBlobGas != nil {
+		excessBlobGas := *b.ExcessBlobGas
+		bb.ExcessBlobGas = &excessBlobGas
+	}
+	if b.ParentBeaconBlockRoot != nil {
+		root := *b.ParentBeaconBlockRoot
+		bb.ParentBeaconBlockRoot = &root
+	}
+	if b.RequestsHash != nil {
+		hash := *b.RequestsHash
+		bb.RequestsHash = &hash
+	}
 	return bb
 }
 
+// Withdrawal is a withdrawal from the consensus layer (eip-4895)
+type Withdrawal struct {
+	Index          uint64
+	ValidatorIndex uint64
+	Address        Address
+	// Amount is denominated in gwei
+	Amount uint64
+}
+
 type TransactionType uint8
 
 const (
@@ -173,6 +298,10 @@ const (
 	TransactionAccessList TransactionType = 1
 	// eip-1559
 	TransactionDynamicFee TransactionType = 2
+	// eip-4844
+	TransactionBlob TransactionType = 3
+	// eip-7702
+	TransactionSetCode TransactionType = 4
 )
 
 type Transaction struct {
@@ -203,6 +332,18 @@ type Transaction struct {
 	// eip-1559 values
 	MaxPriorityFeePerGas *big.Int
 	MaxFeePerGas         *big.Int
+
+	// eip-4844 values
+	MaxFeePerBlobGas    *big.Int
+	BlobVersionedHashes []Hash
+
+	// Sidecar holds the blobs of an eip-4844 transaction. It is only
+	// set for the network form of the transaction (i.e. eth_sendRawTransaction)
+	// and it is not part of the transaction hash.
+	Sidecar *BlobSidecar
+
+	// eip-7702 values
+	AuthorizationList AuthorizationList
 }
 
 func (t *Transaction) Copy() *Transaction {
@@ -228,10 +369,129 @@ func (t *Transaction) Copy() *Transaction {
 	if t.MaxFeePerGas != nil {
 		tt.MaxFeePerGas = new(big.Int).Set(t.MaxFeePerGas)
 	}
+	if t.MaxFeePerBlobGas != nil {
+		tt.MaxFeePerBlobGas = new(big.Int).Set(t.MaxFeePerBlobGas)
+	}
+	if t.BlobVersionedHashes != nil {
+		tt.BlobVersionedHashes = append([]Hash{}, t.BlobVersionedHashes...)
+	}
+	if t.Sidecar != nil {
+		tt.Sidecar = t.Sidecar.Copy()
+	}
 	tt.AccessList = t.AccessList.Copy()
+	if t.AuthorizationList != nil {
+		tt.AuthorizationList = t.AuthorizationList.Copy()
+	}
 	return tt
 }
 
+const (
+	// BlobSize is the size in bytes of an eip-4844 blob
+	BlobSize = 131072
+
+	// BlobCommitmentVersionKZG is the version byte of the versioned hash of a KZG commitment
+	BlobCommitmentVersionKZG byte = 0x01
+
+	// BlobSidecarVersion0 is the version of the eip-4844 sidecar with a proof per blob
+	BlobSidecarVersion0 byte = 0x0
+
+	// BlobSidecarVersion1 is the version of the eip-7594 sidecar with the cell proofs of each blob
+	BlobSidecarVersion1 byte = 0x1
+
+	// CellProofsPerBlob is the number of cell proofs per blob in the eip-7594 sidecar
+	CellProofsPerBlob = 128
+)
+
+// Blob is an eip-4844 blob of BlobSize bytes
+type Blob []byte
+
+// UnmarshalText implements the unmarshal interface
+func (b *Blob) UnmarshalText(buf []byte) error {
+	blob := make([]byte, BlobSize)
+	if err := unmarshalTextByte(blob, buf, BlobSize); err != nil {
+		return err
+	}
+	*b = blob
+	return nil
+}
+
+// MarshalText implements the marshal interface
+func (b Blob) MarshalText() ([]byte, error) {
+	return []byte("0x" + hex.EncodeToString(b)), nil
+}
+
+// KZGCommitment is the KZG commitment of a blob
+type KZGCommitment [48]byte
+
+// UnmarshalText implements the unmarshal interface
+func (k *KZGCommitment) UnmarshalText(buf []byte) error {
+	return unmarshalTextByte(k[:], buf, 48)
+}
+
+// MarshalText implements the marshal interface
+func (k KZGCommitment) MarshalText() ([]byte, error) {
+	return []byte("0x" + hex.EncodeToString(k[:])), nil
+}
+
+// VersionedHash returns the versioned hash of the commitment as
+// referenced in the BlobVersionedHashes field of a transaction
+func (k KZGCommitment) VersionedHash() Hash {
+	h := sha256.Sum256(k[:])
+	h[0] = BlobCommitmentVersionKZG
+	return Hash(h)
+}
+
+// KZGProof is the KZG proof of a blob
+type KZGProof [48]byte
+
+// UnmarshalText implements the unmarshal interface
+func (k *KZGProof) UnmarshalText(buf []byte) error {
+	return unmarshalTextByte(k[:], buf, 48)
+}
+
+// MarshalText implements the marshal interface
+func (k KZGProof) MarshalText() ([]byte, error) {
+	return []byte("0x" + hex.EncodeToString(k[:])), nil
+}
+
+// BlobSidecar are the blobs, commitments and proofs that
+// go along an eip-4844 transaction in its network form
+type BlobSidecar struct {
+	// Version is either BlobSidecarVersion0 with a proof per blob or
+	// BlobSidecarVersion1 (eip-7594) with CellProofsPerBlob proofs per blob
+	Version     byte
+	Blobs       []Blob
+	Commitments []KZGCommitment
+	Proofs      []KZGProof
+}
+
+func (b *BlobSidecar) Copy() *BlobSidecar {
+	bb := new(BlobSidecar)
+	bb.Version = b.Version
+	if b.Blobs != nil {
+		bb.Blobs = make([]Blob, len(b.Blobs))
+		for indx, blob := range b.Blobs {
+			bb.Blobs[indx] = append(Blob{}, blob...)
+		}
+	}
+	if b.Commitments != nil {
+		bb.Commitments = append([]KZGCommitment{}, b.Commitments...)
+	}
+	if b.Proofs != nil {
+		bb.Proofs = append([]KZGProof{}, b.Proofs...)
+	}
+	return bb
+}
+
+// VersionedHashes returns the versioned hashes of the commitments in the sidecar
+func (b *BlobSidecar) VersionedHashes() []Hash {
+	hashes := make([]Hash, len(b.Commitments))
+	for indx, c := range b.Commitments {
+		hashes[indx] = c.VersionedHash()
+	}
+	return hashes
+}
+
 type AccessEntry struct {
 	Address Address `json:"address"`
 	Storage []Hash  `json:"storageKeys"`
@@ -252,13 +512,67 @@ func (a *AccessList) Copy() AccessList {
 	return aa
 }
 
+// Authorization is an eip-7702 authorization to delegate
+// the code of an account (the authority) to the code of Address
+type Authorization struct {
+	ChainID *big.Int
+	Address Address
+	Nonce   uint64
+	YParity uint8
+	R       *big.Int
+	S       *big.Int
+}
+
+// SigningHash returns the hash signed by the authority, keccak256(0x05 || rlp([chain_id, address, nonce]))
+func (a *Authorization) SigningHash() Hash {
+	ar := fastrlp.DefaultArenaPool.Get()
+	defer fastrlp.DefaultArenaPool.Put(ar)
+
+	v := ar.NewArray()
+	v.Set(ar.NewBigInt(a.ChainID))
+	v.Set(ar.NewCopyBytes(a.Address[:]))
+	v.Set(ar.NewUint(a.Nonce))
+
+	dst := v.MarshalTo([]byte{authorizationMagic})
+	return BytesToHash(Keccak256(dst))
+}
+
+// authorizationMagic is the prefix byte of the authorization signing hash
+const authorizationMagic = 0x05
+
+func (a *Authorization) Copy() *Authorization {
+	aa := new(Authorization)
+	*aa = *a
+	if a.ChainID != nil {
+		aa.ChainID = new(big.Int).Set(a.ChainID)
+	}
+	if a.R != nil {
+		aa.R = new(big.Int).Set(a.R)
+	}
+	if a.S != nil {
+		aa.S = new(big.Int).Set(a.S)
+	}
+	return aa
+}
+
+type AuthorizationList []Authorization
+
+func (a *AuthorizationList) Copy() AuthorizationList {
+	aa := AuthorizationList{}
+	for _, i := range *a {
+		aa = append(aa, *i.Copy())
+	}
+	return aa
+}
+
 type CallMsg struct {
-	From     Address
-	To       *Address
-	Data     []byte
-	GasPrice uint64
-	Gas      *big.Int
-	Value    *big.Int
+	From       Address
+	To         *Address
+	Data       []byte
+	GasPrice   uint64
+	Gas        *big.Int
+	Value      *big.Int
+	AccessList AccessList
 }
 
 type LogFilter struct {
@@ -283,6 +597,83 @@ func (l *LogFilter) SetTo(b BlockNumber) {
 	l.To = &b
 }
 
+// Match checks whether a log matches the filter. The log must be emitted by any
+// of the addresses and, for each position, have any of the topics. An empty
+// list of addresses or topics at a position matches everything. The block range
+// is only checked for block numbers (not for tags like latest).
+func (l *LogFilter) Match(log *Log) bool {
+	if l.BlockHash != nil && *l.BlockHash != log.BlockHash {
+		return false
+	}
+	if l.From != nil && *l.From >= 0 && log.BlockNumber < uint64(*l.From) {
+		return false
+	}
+	if l.To != nil && *l.To >= 0 && log.BlockNumber > uint64(*l.To) {
+		return false
+	}
+
+	if len(l.Address) != 0 {
+		found := false
+		for _, addr := range l.Address {
+			if addr == log.Address {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(l.Topics) > len(log.Topics) {
+		return false
+	}
+	for i, topics := range l.Topics {
+		if !matchTopic(topics, func(topic Hash) bool { return topic == log.Topics[i] }) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchBloom checks whether the logs of a block or receipt with the given
+// bloom might match the filter. A false result means that none of the logs
+// match the filter.
+func (l *LogFilter) MatchBloom(b Bloom) bool {
+	if len(l.Address) != 0 {
+		found := false
+		for _, addr := range l.Address {
+			if b.TestAddress(addr) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for _, topics := range l.Topics {
+		if !matchTopic(topics, b.TestTopic) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchTopic checks whether any of the topics of a position matches. An empty
+// list or a nil topic is a wildcard.
+func matchTopic(topics []*Hash, match func(topic Hash) bool) bool {
+	if len(topics) == 0 {
+		return true
+	}
+	for _, topic := range topics {
+		if topic == nil || match(*topic) {
+			return true
+		}
+	}
+	return false
+}
+
 type Receipt struct {
 	TransactionHash   Hash
 	TransactionIndex  uint64
@@ -296,11 +687,29 @@ type Receipt struct {
 	Logs              []*Log
 	Status            uint64
 	To                *Address
+	Type              TransactionType
+	// Root is the post-transaction state root of pre-byzantium receipts
+	Root              *Hash
+	EffectiveGasPrice *big.Int
+
+	// eip-4844 values
+	BlobGasUsed  uint64
+	BlobGasPrice *big.Int
 }
 
 func (r *Receipt) Copy() *Receipt {
 	rr := new(Receipt)
 	*rr = *r
+	if r.EffectiveGasPrice != nil {
+		rr.EffectiveGasPrice = new(big.Int).Set(r.EffectiveGasPrice)
+	}
+	if r.BlobGasPrice != nil {
+		rr.BlobGasPrice = new(big.Int).Set(r.BlobGasPrice)
+	}
+	if r.Root != nil {
+		root := *r.Root
+		rr.Root = &root
+	}
 	rr.LogsBloom = append(rr.LogsBloom[:0], r.LogsBloom...)
 	rr.Logs = make([]*Log, len(r.Logs))
 	for indx, log := range r.Logs {
@@ -328,6 +737,25 @@ func (l *Log) Copy() *Log {
 	return ll
 }
 
+// AccountProof is the merkle proof of an account and some of its
+// storage slots as returned by eth_getProof (eip-1186)
+type AccountProof struct {
+	Address      Address
+	AccountProof [][]byte
+	Balance      *big.Int
+	CodeHash     Hash
+	Nonce        uint64
+	StorageHash  Hash
+	StorageProof []*StorageProof
+}
+
+// StorageProof is the merkle proof of a storage slot of an account
+type StorageProof struct {
+	Key   Hash
+	Value *big.Int
+	Proof [][]byte
+}
+
 type BlockNumber int
 
 const (
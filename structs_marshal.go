@@ -15,6 +15,12 @@ func (l *Log) MarshalJSON() ([]byte, error) {
 	a := defaultArena.Get()
 	defer a.Reset()
 
+	res := l.marshalJSON(a).MarshalTo(nil)
+	defaultArena.Put(a)
+	return res, nil
+}
+
+func (l *Log) marshalJSON(a *fastjson.Arena) *fastjson.Value {
 	o := a.NewObject()
 	if l.Removed {
 		o.Set("removed", a.NewTrue())
@@ -34,10 +40,7 @@ func (l *Log) MarshalJSON() ([]byte, error) {
 		vv.SetArrayItem(indx, a.NewString(topic.String()))
 	}
 	o.Set("topics", vv)
-
-	res := o.MarshalTo(nil)
-	defaultArena.Put(a)
-	return res, nil
+	return o
 }
 
 // MarshalJSON implements the marshal interface
@@ -66,8 +69,33 @@ func (t *Block) MarshalJSON() ([]byte, error) {
 	o.Set("mixHash", a.NewString("0x"+hex.EncodeToString(t.MixHash[:])))
 	o.Set("nonce", a.NewString("0x"+hex.EncodeToString(t.Nonce[:])))
 
+	if len(t.LogsBloom) != 0 {
+		o.Set("logsBloom", a.NewString("0x"+hex.EncodeToString(t.LogsBloom)))
+	}
 	if t.BaseFee != nil {
-		o.Set("baseFee", a.NewString(fmt.Sprintf("0x%x", t.BaseFee)))
+		o.Set("baseFeePerGas", a.NewString(fmt.Sprintf("0x%x", t.BaseFee)))
+	}
+	if t.WithdrawalsRoot != nil {
+		o.Set("withdrawalsRoot", a.NewString(t.WithdrawalsRoot.String()))
+	}
+	if t.Withdrawals != nil {
+		withdrawals := a.NewArray()
+		for indx, w := range t.Withdrawals {
+			withdrawals.SetArrayItem(indx, w.marshalJSON(a))
+		}
+		o.Set("withdrawals", withdrawals)
+	}
+	if t.BlobGasUsed != nil {
+		o.Set("blobGasUsed", a.NewString(fmt.Sprintf("0x%x", *t.BlobGasUsed)))
+	}
+	if t.ExcessBlobGas != nil {
+		o.Set("excessBlobGas", a.NewString(fmt.Sprintf("0x%x", *t.ExcessBlobGas)))
+	}
+	if t.ParentBeaconBlockRoot != nil {
+		o.Set("parentBeaconBlockRoot", a.NewString(t.ParentBeaconBlockRoot.String()))
+	}
+	if t.RequestsHash != nil {
+		o.Set("requestsHash", a.NewString(t.RequestsHash.String()))
 	}
 
 	// uncles
@@ -100,6 +128,102 @@ func (t *Block) MarshalJSON() ([]byte, error) {
 	return res, nil
 }
 
+func (w *Withdrawal) marshalJSON(a *fastjson.Arena) *fastjson.Value {
+	o := a.NewObject()
+	o.Set("index", a.NewString(fmt.Sprintf("0x%x", w.Index)))
+	o.Set("validatorIndex", a.NewString(fmt.Sprintf("0x%x", w.ValidatorIndex)))
+	o.Set("address", a.NewString(w.Address.String()))
+	o.Set("amount", a.NewString(fmt.Sprintf("0x%x", w.Amount)))
+	return o
+}
+
+// MarshalJSON implements the marshal interface
+func (r *Receipt) MarshalJSON() ([]byte, error) {
+	a := defaultArena.Get()
+	defer a.Reset()
+
+	o := a.NewObject()
+	o.Set("transactionHash", a.NewString(r.TransactionHash.String()))
+	o.Set("transactionIndex", a.NewString(fmt.Sprintf("0x%x", r.TransactionIndex)))
+	o.Set("blockHash", a.NewString(r.BlockHash.String()))
+	o.Set("blockNumber", a.NewString(fmt.Sprintf("0x%x", r.BlockNumber)))
+	o.Set("from", a.NewString(r.From.String()))
+	if r.To == nil {
+		o.Set("to", a.NewNull())
+	} else {
+		o.Set("to", a.NewString(r.To.String()))
+	}
+	if r.ContractAddress == ZeroAddress {
+		o.Set("contractAddress", a.NewNull())
+	} else {
+		o.Set("contractAddress", a.NewString(r.ContractAddress.String()))
+	}
+	o.Set("gasUsed", a.NewString(fmt.Sprintf("0x%x", r.GasUsed)))
+	o.Set("cumulativeGasUsed", a.NewString(fmt.Sprintf("0x%x", r.CumulativeGasUsed)))
+	o.Set("logsBloom", a.NewString("0x"+hex.EncodeToString(r.LogsBloom)))
+	if r.Root != nil {
+		o.Set("root", a.NewString(r.Root.String()))
+	} else {
+		o.Set("status", a.NewString(fmt.Sprintf("0x%x", r.Status)))
+	}
+	o.Set("type", a.NewString(fmt.Sprintf("0x%x", r.Type)))
+	if r.EffectiveGasPrice != nil {
+		o.Set("effectiveGasPrice", a.NewString(fmt.Sprintf("0x%x", r.EffectiveGasPrice)))
+	}
+	if r.Type == TransactionBlob {
+		o.Set("blobGasUsed", a.NewString(fmt.Sprintf("0x%x", r.BlobGasUsed)))
+		if r.BlobGasPrice != nil {
+			o.Set("blobGasPrice", a.NewString(fmt.Sprintf("0x%x", r.BlobGasPrice)))
+		}
+	}
+
+	logs := a.NewArray()
+	for indx, log := range r.Logs {
+		logs.SetArrayItem(indx, log.marshalJSON(a))
+	}
+	o.Set("logs", logs)
+
+	res := o.MarshalTo(nil)
+	defaultArena.Put(a)
+	return res, nil
+}
+
+// MarshalJSON implements the marshal interface
+func (p *AccountProof) MarshalJSON() ([]byte, error) {
+	a := defaultArena.Get()
+	defer a.Reset()
+
+	o := a.NewObject()
+	o.Set("address", a.NewString(p.Address.String()))
+	o.Set("accountProof", marshalProof(a, p.AccountProof))
+	o.Set("balance", a.NewString(fmt.Sprintf("0x%x", bigOrZero(p.Balance))))
+	o.Set("codeHash", a.NewString(p.CodeHash.String()))
+	o.Set("nonce", a.NewString(fmt.Sprintf("0x%x", p.Nonce)))
+	o.Set("storageHash", a.NewString(p.StorageHash.String()))
+
+	storage := a.NewArray()
+	for indx, s := range p.StorageProof {
+		oo := a.NewObject()
+		oo.Set("key", a.NewString(s.Key.String()))
+		oo.Set("value", a.NewString(fmt.Sprintf("0x%x", bigOrZero(s.Value))))
+		oo.Set("proof", marshalProof(a, s.Proof))
+		storage.SetArrayItem(indx, oo)
+	}
+	o.Set("storageProof", storage)
+
+	res := o.MarshalTo(nil)
+	defaultArena.Put(a)
+	return res, nil
+}
+
+func marshalProof(a *fastjson.Arena, proof [][]byte) *fastjson.Value {
+	v := a.NewArray()
+	for indx, node := range proof {
+		v.SetArrayItem(indx, a.NewString("0x"+hex.EncodeToString(node)))
+	}
+	return v
+}
+
 // MarshalJSON implements the Marshal interface.
 func (t *Transaction) MarshalJSON() ([]byte, error) {
 	a := defaultArena.Get()
@@ -122,7 +246,7 @@ func (t *Transaction) marshalJSON(a *fastjson.Arena) *fastjson.Value {
 	if t.Value != nil {
 		o.Set("value", a.NewString(fmt.Sprintf("0x%x", t.Value)))
 	}
-	if t.Type == TransactionDynamicFee {
+	if t.Type == TransactionDynamicFee || t.Type == TransactionBlob || t.Type == TransactionSetCode {
 		if t.MaxPriorityFeePerGas != nil {
 			o.Set("maxPriorityFeePerGas", a.NewString(fmt.Sprintf("0x%x", t.MaxPriorityFeePerGas)))
 		}
@@ -168,9 +292,68 @@ func (t *Transaction) marshalJSON(a *fastjson.Arena) *fastjson.Value {
 	if t.AccessList != nil {
 		o.Set("accessList", t.AccessList.marshalJSON(a))
 	}
+	if t.Type == TransactionSetCode {
+		o.Set("authorizationList", t.AuthorizationList.marshalJSON(a))
+	}
+	if t.Type == TransactionBlob {
+		if t.MaxFeePerBlobGas != nil {
+			o.Set("maxFeePerBlobGas", a.NewString(fmt.Sprintf("0x%x", t.MaxFeePerBlobGas)))
+		}
+		hashes := a.NewArray()
+		for indx, hash := range t.BlobVersionedHashes {
+			hashes.SetArrayItem(indx, a.NewString(hash.String()))
+		}
+		o.Set("blobVersionedHashes", hashes)
+
+		if t.Sidecar != nil {
+			t.Sidecar.marshalJSON(a, o)
+		}
+	}
 	return o
 }
 
+func (t *AuthorizationList) marshalJSON(a *fastjson.Arena) *fastjson.Value {
+	arr := a.NewArray()
+	for indx, elem := range *t {
+		arrElem := a.NewObject()
+		arrElem.Set("chainId", a.NewString(fmt.Sprintf("0x%x", bigOrZero(elem.ChainID))))
+		arrElem.Set("address", a.NewString(elem.Address.String()))
+		arrElem.Set("nonce", a.NewString(fmt.Sprintf("0x%x", elem.Nonce)))
+		arrElem.Set("yParity", a.NewString(fmt.Sprintf("0x%x", elem.YParity)))
+		arrElem.Set("r", a.NewString(fmt.Sprintf("0x%x", bigOrZero(elem.R))))
+		arrElem.Set("s", a.NewString(fmt.Sprintf("0x%x", bigOrZero(elem.S))))
+		arr.SetArrayItem(indx, arrElem)
+	}
+	return arr
+}
+
+func bigOrZero(b *big.Int) *big.Int {
+	if b == nil {
+		return new(big.Int)
+	}
+	return b
+}
+
+func (b *BlobSidecar) marshalJSON(a *fastjson.Arena, o *fastjson.Value) {
+	blobs := a.NewArray()
+	for indx, blob := range b.Blobs {
+		blobs.SetArrayItem(indx, a.NewString("0x"+hex.EncodeToString(blob)))
+	}
+	o.Set("blobs", blobs)
+
+	commitments := a.NewArray()
+	for indx, commitment := range b.Commitments {
+		commitments.SetArrayItem(indx, a.NewString("0x"+hex.EncodeToString(commitment[:])))
+	}
+	o.Set("commitments", commitments)
+
+	proofs := a.NewArray()
+	for indx, proof := range b.Proofs {
+		proofs.SetArrayItem(indx, a.NewString("0x"+hex.EncodeToString(proof[:])))
+	}
+	o.Set("proofs", proofs)
+}
+
 func (t *AccessList) marshalJSON(a *fastjson.Arena) *fastjson.Value {
 	arr := a.NewArray()
 	for indx, elem := range *t {
@@ -209,6 +392,9 @@ func (c *CallMsg) MarshalJSON() ([]byte, error) {
 	if c.Gas != nil {
 		o.Set("gas", a.NewString(fmt.Sprintf("0x%x", c.Gas)))
 	}
+	if c.AccessList != nil {
+		o.Set("accessList", c.AccessList.marshalJSON(a))
+	}
 
 	res := o.MarshalTo(nil)
 	defaultArena.Put(a)
@@ -228,6 +414,7 @@ func (l *LogFilter) MarshalJSON() ([]byte, error) {
 		for indx, addr := range l.Address {
 			v.SetArrayItem(indx, a.NewString(addr.String()))
 		}
+		o.Set("address", v)
 	}
 
 	v := a.NewArray()
@@ -1,6 +1,7 @@
 package ethgo
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
 
@@ -9,6 +10,13 @@ import (
 
 // GetHash returns the Hash of the transaction
 func (t *Transaction) GetHash() (hash Hash, err error) {
+	if t.Sidecar != nil {
+		// the blobs of the sidecar are not part of the hash
+		tt := *t
+		tt.Sidecar = nil
+		t = &tt
+	}
+
 	var rlpEncode []byte
 	if rlpEncode, err = t.MarshalRLPTo(nil); err != nil {
 		return Hash{}, err
@@ -16,7 +24,8 @@ func (t *Transaction) GetHash() (hash Hash, err error) {
 	return BytesToHash(Keccak256(rlpEncode)), nil
 }
 
-// MarshalRLPTo marshals the transaction to a []byte destination
+// MarshalRLPTo marshals the transaction to a []byte destination. Blob transactions
+// with a sidecar are encoded in the network form used by eth_sendRawTransaction.
 func (t *Transaction) MarshalRLPTo(dst []byte) ([]byte, error) {
 	raw, err := fastrlp.MarshalRLP(t)
 	if err != nil {
@@ -31,6 +40,50 @@ func (t *Transaction) MarshalRLPTo(dst []byte) ([]byte, error) {
 
 // MarshalRLPWith marshals the transaction to RLP with a specific fastrlp.Arena
 func (t *Transaction) MarshalRLPWith(arena *fastrlp.Arena) (*fastrlp.Value, error) {
+	vv, err := t.marshalRLPWith(arena)
+	if err != nil {
+		return nil, err
+	}
+	if t.Type != TransactionBlob || t.Sidecar == nil {
+		return vv, nil
+	}
+
+	// network form of the blob transaction
+	// [tx_payload_body, blobs, commitments, proofs] or
+	// [tx_payload_body, wrapper_version, blobs, commitments, cell_proofs] (eip-7594)
+	wrapper := arena.NewArray()
+	wrapper.Set(vv)
+
+	switch t.Sidecar.Version {
+	case BlobSidecarVersion0:
+	case BlobSidecarVersion1:
+		wrapper.Set(arena.NewUint(uint64(t.Sidecar.Version)))
+	default:
+		return nil, fmt.Errorf("blob sidecar version %d not supported", t.Sidecar.Version)
+	}
+
+	blobs := arena.NewArray()
+	for _, blob := range t.Sidecar.Blobs {
+		blobs.Set(arena.NewCopyBytes(blob))
+	}
+	wrapper.Set(blobs)
+
+	commitments := arena.NewArray()
+	for indx := range t.Sidecar.Commitments {
+		commitments.Set(arena.NewBytes(t.Sidecar.Commitments[indx][:]))
+	}
+	wrapper.Set(commitments)
+
+	proofs := arena.NewArray()
+	for indx := range t.Sidecar.Proofs {
+		proofs.Set(arena.NewBytes(t.Sidecar.Proofs[indx][:]))
+	}
+	wrapper.Set(proofs)
+
+	return wrapper, nil
+}
+
+func (t *Transaction) marshalRLPWith(arena *fastrlp.Arena) (*fastrlp.Value, error) {
 	vv := arena.NewArray()
 
 	if t.Type != 0 {
@@ -40,7 +93,7 @@ func (t *Transaction) MarshalRLPWith(arena *fastrlp.Arena) (*fastrlp.Value, erro
 
 	vv.Set(arena.NewUint(t.Nonce))
 
-	if t.Type == TransactionDynamicFee {
+	if t.Type == TransactionDynamicFee || t.Type == TransactionBlob || t.Type == TransactionSetCode {
 		// dynamic fee uses
 		vv.Set(arena.NewBigInt(t.MaxPriorityFeePerGas))
 		vv.Set(arena.NewBigInt(t.MaxFeePerGas))
@@ -52,6 +105,12 @@ func (t *Transaction) MarshalRLPWith(arena *fastrlp.Arena) (*fastrlp.Value, erro
 	vv.Set(arena.NewUint(t.Gas))
 
 	// Address may be empty
+	if t.To == nil && t.Type == TransactionBlob {
+		return nil, fmt.Errorf("blob transaction cannot create a contract")
+	}
+	if t.To == nil && t.Type == TransactionSetCode {
+		return nil, fmt.Errorf("set code transaction cannot create a contract")
+	}
 	if t.To != nil {
 		vv.Set(arena.NewBytes((*t.To)[:]))
 	} else {
@@ -70,14 +129,33 @@ func (t *Transaction) MarshalRLPWith(arena *fastrlp.Arena) (*fastrlp.Value, erro
 		vv.Set(accessList)
 	}
 
-	// signature values
-	vv.Set(arena.NewCopyBytes(t.V))
-	vv.Set(arena.NewCopyBytes(t.R))
-	vv.Set(arena.NewCopyBytes(t.S))
+	if t.Type == TransactionSetCode {
+		if len(t.AuthorizationList) == 0 {
+			return nil, fmt.Errorf("set code transaction with an empty authorization list")
+		}
+		authList, err := t.AuthorizationList.MarshalRLPWith(arena)
+		if err != nil {
+			return nil, err
+		}
+		vv.Set(authList)
+	}
+
+	if t.Type == TransactionBlob {
+		vv.Set(arena.NewBigInt(t.MaxFeePerBlobGas))
 
-	if t.Type == TransactionLegacy {
-		return vv, nil
+		hashes := arena.NewArray()
+		for _, hash := range t.BlobVersionedHashes {
+			hashes.Set(arena.NewCopyBytes(hash[:]))
+		}
+		vv.Set(hashes)
 	}
+
+	// signature values, they are quantities and the leading zeros
+	// of the JSON form (i.e. '0x00') are not part of the encoding
+	vv.Set(arena.NewCopyBytes(bytes.TrimLeft(t.V, "\x00")))
+	vv.Set(arena.NewCopyBytes(bytes.TrimLeft(t.R, "\x00")))
+	vv.Set(arena.NewCopyBytes(bytes.TrimLeft(t.S, "\x00")))
+
 	return vv, nil
 }
 
@@ -94,6 +172,10 @@ func (t *Transaction) UnmarshalRLP(buf []byte) error {
 			t.Type = TransactionAccessList
 		case 2:
 			t.Type = TransactionDynamicFee
+		case 3:
+			t.Type = TransactionBlob
+		case 4:
+			t.Type = TransactionSetCode
 		default:
 			return fmt.Errorf("type byte %d not found", typ)
 		}
@@ -102,6 +184,14 @@ func (t *Transaction) UnmarshalRLP(buf []byte) error {
 	if err := fastrlp.UnmarshalRLP(buf, t); err != nil {
 		return err
 	}
+	if t.Sidecar != nil {
+		// the hash of the network form does not include the sidecar
+		hash, err := t.GetHash()
+		if err != nil {
+			return err
+		}
+		t.Hash = hash
+	}
 	return nil
 }
 
@@ -111,6 +201,38 @@ func (t *Transaction) UnmarshalRLPWith(v *fastrlp.Value) error {
 		return err
 	}
 
+	t.Sidecar = nil
+	if t.Type == TransactionBlob && len(elems) != 0 && elems[0].Type() == fastrlp.TypeArray {
+		// network form of the blob transaction
+		// [tx_payload_body, blobs, commitments, proofs] or
+		// [tx_payload_body, wrapper_version, blobs, commitments, cell_proofs] (eip-7594)
+		t.Sidecar = new(BlobSidecar)
+		switch len(elems) {
+		case 4:
+			t.Sidecar.Version = BlobSidecarVersion0
+			if err := t.Sidecar.unmarshalRLPWith(elems[1], elems[2], elems[3]); err != nil {
+				return err
+			}
+		case 5:
+			version, err := elems[1].GetUint64()
+			if err != nil {
+				return err
+			}
+			if version != uint64(BlobSidecarVersion1) {
+				return fmt.Errorf("blob sidecar version %d not supported", version)
+			}
+			t.Sidecar.Version = BlobSidecarVersion1
+			if err := t.Sidecar.unmarshalRLPWith(elems[2], elems[3], elems[4]); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("expected 4 or 5 elements for the blob transaction network form but found %d", len(elems))
+		}
+		if elems, err = elems[0].GetElems(); err != nil {
+			return err
+		}
+	}
+
 	getElem := func() *fastrlp.Value {
 		v := elems[0]
 		elems = elems[1:]
@@ -127,6 +249,12 @@ func (t *Transaction) UnmarshalRLPWith(v *fastrlp.Value) error {
 	case TransactionDynamicFee:
 		// access list txn + gas fee 1 + gas fee 2 - gas price
 		num = 12
+	case TransactionBlob:
+		// dynamic fee txn + blob gas fee + blob hashes
+		num = 14
+	case TransactionSetCode:
+		// dynamic fee txn + authorization list
+		num = 13
 	default:
 		return fmt.Errorf("transaction type %d not found", t.Type)
 	}
@@ -146,7 +274,7 @@ func (t *Transaction) UnmarshalRLPWith(v *fastrlp.Value) error {
 		return err
 	}
 
-	if t.Type == TransactionDynamicFee {
+	if t.Type == TransactionDynamicFee || t.Type == TransactionBlob || t.Type == TransactionSetCode {
 		// dynamic fee uses
 		t.MaxPriorityFeePerGas = new(big.Int)
 		if err := getElem().GetBigInt(t.MaxPriorityFeePerGas); err != nil {
@@ -177,6 +305,12 @@ func (t *Transaction) UnmarshalRLPWith(v *fastrlp.Value) error {
 		// reset To
 		t.To = nil
 	}
+	if t.To == nil && t.Type == TransactionBlob {
+		return fmt.Errorf("blob transaction cannot create a contract")
+	}
+	if t.To == nil && t.Type == TransactionSetCode {
+		return fmt.Errorf("set code transaction cannot create a contract")
+	}
 	// value
 	t.Value = new(big.Int)
 	if err := getElem().GetBigInt(t.Value); err != nil {
@@ -193,6 +327,39 @@ func (t *Transaction) UnmarshalRLPWith(v *fastrlp.Value) error {
 		}
 	}
 
+	if t.Type == TransactionSetCode {
+		t.AuthorizationList = t.AuthorizationList[:0]
+		if err := t.AuthorizationList.UnmarshalRLPWith(getElem()); err != nil {
+			return err
+		}
+		if len(t.AuthorizationList) == 0 {
+			return fmt.Errorf("set code transaction with an empty authorization list")
+		}
+	}
+
+	if t.Type == TransactionBlob {
+		t.MaxFeePerBlobGas = new(big.Int)
+		if err := getElem().GetBigInt(t.MaxFeePerBlobGas); err != nil {
+			return err
+		}
+
+		hashesElem := getElem()
+		t.BlobVersionedHashes = t.BlobVersionedHashes[:0]
+		if hashesElem.Type() != fastrlp.TypeArrayNull {
+			hashes, err := hashesElem.GetElems()
+			if err != nil {
+				return err
+			}
+			for _, elem := range hashes {
+				var hash Hash
+				if err := elem.GetHash(hash[:]); err != nil {
+					return err
+				}
+				t.BlobVersionedHashes = append(t.BlobVersionedHashes, hash)
+			}
+		}
+	}
+
 	// V
 	if t.V, err = getElem().GetBytes(t.V); err != nil {
 		return err
@@ -209,6 +376,52 @@ func (t *Transaction) UnmarshalRLPWith(v *fastrlp.Value) error {
 	return nil
 }
 
+func (b *BlobSidecar) unmarshalRLPWith(blobsV, commitmentsV, proofsV *fastrlp.Value) error {
+	forEachBytes := func(v *fastrlp.Value, size int, fn func(buf []byte)) error {
+		if v.Type() == fastrlp.TypeArrayNull {
+			return nil
+		}
+		elems, err := v.GetElems()
+		if err != nil {
+			return err
+		}
+		for _, elem := range elems {
+			buf, err := elem.Bytes()
+			if err != nil {
+				return err
+			}
+			if len(buf) != size {
+				return fmt.Errorf("bad length, expected %d but found %d", size, len(buf))
+			}
+			fn(buf)
+		}
+		return nil
+	}
+
+	b.Blobs, b.Commitments, b.Proofs = nil, nil, nil
+
+	if err := forEachBytes(blobsV, BlobSize, func(buf []byte) {
+		b.Blobs = append(b.Blobs, append(Blob{}, buf...))
+	}); err != nil {
+		return err
+	}
+	if err := forEachBytes(commitmentsV, 48, func(buf []byte) {
+		var commitment KZGCommitment
+		copy(commitment[:], buf)
+		b.Commitments = append(b.Commitments, commitment)
+	}); err != nil {
+		return err
+	}
+	if err := forEachBytes(proofsV, 48, func(buf []byte) {
+		var proof KZGProof
+		copy(proof[:], buf)
+		b.Proofs = append(b.Proofs, proof)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (a *AccessList) MarshalRLPTo(dst []byte) ([]byte, error) {
 	return fastrlp.MarshalRLP(a)
 }
@@ -284,3 +497,313 @@ func (a *AccessList) UnmarshalRLPWith(v *fastrlp.Value) error {
 	}
 	return nil
 }
+
+func (a *AuthorizationList) MarshalRLPTo(dst []byte) ([]byte, error) {
+	return fastrlp.MarshalRLP(a)
+}
+
+func (a *AuthorizationList) MarshalRLPWith(arena *fastrlp.Arena) (*fastrlp.Value, error) {
+	if len(*a) == 0 {
+		return arena.NewNullArray(), nil
+	}
+	v := arena.NewArray()
+	for _, i := range *a {
+		auth := arena.NewArray()
+		auth.Set(arena.NewBigInt(i.ChainID))
+		auth.Set(arena.NewCopyBytes(i.Address[:]))
+		auth.Set(arena.NewUint(i.Nonce))
+		auth.Set(arena.NewUint(uint64(i.YParity)))
+		auth.Set(arena.NewBigInt(i.R))
+		auth.Set(arena.NewBigInt(i.S))
+		v.Set(auth)
+	}
+	return v, nil
+}
+
+func (a *AuthorizationList) UnmarshalRLP(buf []byte) error {
+	return fastrlp.UnmarshalRLP(buf, a)
+}
+
+func (a *AuthorizationList) UnmarshalRLPWith(v *fastrlp.Value) error {
+	if v.Type() == fastrlp.TypeArrayNull {
+		// empty
+		return nil
+	}
+
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		entry := Authorization{}
+
+		authElems, err := elem.GetElems()
+		if err != nil {
+			return err
+		}
+		if len(authElems) != 6 {
+			return fmt.Errorf("six elems expected but %d found", len(authElems))
+		}
+
+		entry.ChainID = new(big.Int)
+		if err = authElems[0].GetBigInt(entry.ChainID); err != nil {
+			return err
+		}
+		if err = authElems[1].GetAddr(entry.Address[:]); err != nil {
+			return err
+		}
+		if entry.Nonce, err = authElems[2].GetUint64(); err != nil {
+			return err
+		}
+		yParity, err := authElems[3].GetUint64()
+		if err != nil {
+			return err
+		}
+		if yParity > 0xff {
+			return fmt.Errorf("y parity %d overflows uint8", yParity)
+		}
+		entry.YParity = uint8(yParity)
+		entry.R = new(big.Int)
+		if err = authElems[4].GetBigInt(entry.R); err != nil {
+			return err
+		}
+		entry.S = new(big.Int)
+		if err = authElems[5].GetBigInt(entry.S); err != nil {
+			return err
+		}
+		(*a) = append((*a), entry)
+	}
+	return nil
+}
+
+// ComputeHash computes the hash of the block from the RLP encoding of its header.
+// It can be used to check that the Hash returned by an untrusted endpoint is correct.
+func (b *Block) ComputeHash() (Hash, error) {
+	raw, err := b.MarshalRLPHeaderTo(nil)
+	if err != nil {
+		return Hash{}, err
+	}
+	return BytesToHash(Keccak256(raw)), nil
+}
+
+// MarshalRLPHeaderTo marshals the header of the block to a []byte destination
+func (b *Block) MarshalRLPHeaderTo(dst []byte) ([]byte, error) {
+	a := fastrlp.DefaultArenaPool.Get()
+	defer fastrlp.DefaultArenaPool.Put(a)
+
+	v, err := b.MarshalRLPHeaderWith(a)
+	if err != nil {
+		return nil, err
+	}
+	return v.MarshalTo(dst), nil
+}
+
+// MarshalRLPHeaderWith marshals the header of the block to RLP with a specific fastrlp.Arena.
+// The optional fields of each fork (london, shanghai, cancun and prague) are encoded if set.
+func (b *Block) MarshalRLPHeaderWith(arena *fastrlp.Arena) (*fastrlp.Value, error) {
+	logsBloom := b.LogsBloom
+	if len(logsBloom) == 0 {
+		logsBloom = make([]byte, 256)
+	}
+	if len(logsBloom) != 256 {
+		return nil, fmt.Errorf("logs bloom must be 256 bytes but %d found", len(logsBloom))
+	}
+
+	vv := arena.NewArray()
+	vv.Set(arena.NewCopyBytes(b.ParentHash[:]))
+	vv.Set(arena.NewCopyBytes(b.Sha3Uncles[:]))
+	vv.Set(arena.NewCopyBytes(b.Miner[:]))
+	vv.Set(arena.NewCopyBytes(b.StateRoot[:]))
+	vv.Set(arena.NewCopyBytes(b.TransactionsRoot[:]))
+	vv.Set(arena.NewCopyBytes(b.ReceiptsRoot[:]))
+	vv.Set(arena.NewCopyBytes(logsBloom))
+	vv.Set(arena.NewBigInt(b.Difficulty))
+	vv.Set(arena.NewUint(b.Number))
+	vv.Set(arena.NewUint(b.GasLimit))
+	vv.Set(arena.NewUint(b.GasUsed))
+	vv.Set(arena.NewUint(b.Timestamp))
+	vv.Set(arena.NewCopyBytes(b.ExtraData))
+	vv.Set(arena.NewCopyBytes(b.MixHash[:]))
+	vv.Set(arena.NewCopyBytes(b.Nonce[:]))
+
+	// the fields of each fork are appended in order and a field
+	// cannot be set if any of the previous ones is missing.
+	optional := []struct {
+		name  string
+		isSet bool
+		value func() *fastrlp.Value
+	}{
+		{"baseFee", b.BaseFee != nil, func() *fastrlp.Value { return arena.NewBigInt(b.BaseFee) }},
+		{"withdrawalsRoot", b.WithdrawalsRoot != nil, func() *fastrlp.Value { return arena.NewCopyBytes(b.WithdrawalsRoot[:]) }},
+		{"blobGasUsed", b.BlobGasUsed != nil, func() *fastrlp.Value { return arena.NewUint(*b.BlobGasUsed) }},
+		{"excessBlobGas", b.ExcessBlobGas != nil, func() *fastrlp.Value { return arena.NewUint(*b.ExcessBlobGas) }},
+		{"parentBeaconBlockRoot", b.ParentBeaconBlockRoot != nil, func() *fastrlp.Value { return arena.NewCopyBytes(b.ParentBeaconBlockRoot[:]) }},
+		{"requestsHash", b.RequestsHash != nil, func() *fastrlp.Value { return arena.NewCopyBytes(b.RequestsHash[:]) }},
+	}
+
+	missing := ""
+	for _, field := range optional {
+		if !field.isSet {
+			if missing == "" {
+				missing = field.name
+			}
+			continue
+		}
+		if missing != "" {
+			return nil, fmt.Errorf("header field '%s' is set but '%s' is missing", field.name, missing)
+		}
+		vv.Set(field.value())
+	}
+	return vv, nil
+}
+
+// MarshalRLPTo marshals the consensus encoding of the receipt to a []byte destination.
+// Typed receipts are prefixed with the type of the transaction.
+func (r *Receipt) MarshalRLPTo(dst []byte) ([]byte, error) {
+	raw, err := fastrlp.MarshalRLP(r)
+	if err != nil {
+		return nil, err
+	}
+	if r.Type == TransactionLegacy {
+		return append(dst, raw...), nil
+	}
+	dst = append(dst, byte(r.Type))
+	return append(dst, raw...), nil
+}
+
+// MarshalRLPWith marshals the receipt to RLP with a specific fastrlp.Arena
+func (r *Receipt) MarshalRLPWith(arena *fastrlp.Arena) (*fastrlp.Value, error) {
+	if len(r.LogsBloom) != 256 {
+		return nil, fmt.Errorf("logs bloom must be 256 bytes but %d found", len(r.LogsBloom))
+	}
+
+	vv := arena.NewArray()
+	if r.Root != nil {
+		// pre-byzantium receipts
+		vv.Set(arena.NewCopyBytes(r.Root[:]))
+	} else {
+		vv.Set(arena.NewUint(r.Status))
+	}
+	vv.Set(arena.NewUint(r.CumulativeGasUsed))
+	vv.Set(arena.NewCopyBytes(r.LogsBloom))
+
+	logs := arena.NewArray()
+	for _, log := range r.Logs {
+		logs.Set(log.marshalRLPWith(arena))
+	}
+	vv.Set(logs)
+
+	return vv, nil
+}
+
+func (l *Log) marshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+	vv.Set(arena.NewCopyBytes(l.Address[:]))
+
+	topics := arena.NewArray()
+	for _, topic := range l.Topics {
+		topics.Set(arena.NewCopyBytes(topic[:]))
+	}
+	vv.Set(topics)
+	vv.Set(arena.NewCopyBytes(l.Data))
+
+	return vv
+}
+
+// UnmarshalRLP unmarshals the consensus encoding of a receipt
+func (r *Receipt) UnmarshalRLP(buf []byte) error {
+	if len(buf) < 1 {
+		return fmt.Errorf("expecting 1 byte but 0 byte provided")
+	}
+	r.Type = TransactionLegacy
+	if buf[0] <= 0x7f {
+		// it includes a type byte
+		if typ := buf[0]; typ > byte(TransactionSetCode) {
+			return fmt.Errorf("type byte %d not found", typ)
+		}
+		r.Type = TransactionType(buf[0])
+		buf = buf[1:]
+	}
+	return fastrlp.UnmarshalRLP(buf, r)
+}
+
+func (r *Receipt) UnmarshalRLPWith(v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+	if len(elems) != 4 {
+		return fmt.Errorf("expected 4 elements to decode receipt but found %d", len(elems))
+	}
+
+	// status or root
+	buf, err := elems[0].Bytes()
+	if err != nil {
+		return err
+	}
+	r.Root = nil
+	r.Status = 0
+	if len(buf) == 32 {
+		root := BytesToHash(buf)
+		r.Root = &root
+	} else if r.Status, err = elems[0].GetUint64(); err != nil {
+		return err
+	}
+
+	if r.CumulativeGasUsed, err = elems[1].GetUint64(); err != nil {
+		return err
+	}
+	if r.LogsBloom, err = elems[2].GetBytes(r.LogsBloom[:0], 256); err != nil {
+		return err
+	}
+
+	r.Logs = r.Logs[:0]
+	if elems[3].Type() == fastrlp.TypeArrayNull {
+		return nil
+	}
+	logElems, err := elems[3].GetElems()
+	if err != nil {
+		return err
+	}
+	for _, elem := range logElems {
+		log := new(Log)
+		if err := log.unmarshalRLPWith(elem); err != nil {
+			return err
+		}
+		r.Logs = append(r.Logs, log)
+	}
+	return nil
+}
+
+func (l *Log) unmarshalRLPWith(v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+	if len(elems) != 3 {
+		return fmt.Errorf("expected 3 elements to decode log but found %d", len(elems))
+	}
+	if err := elems[0].GetAddr(l.Address[:]); err != nil {
+		return err
+	}
+
+	l.Topics = l.Topics[:0]
+	if elems[1].Type() != fastrlp.TypeArrayNull {
+		topics, err := elems[1].GetElems()
+		if err != nil {
+			return err
+		}
+		for _, topic := range topics {
+			var hash Hash
+			if err := topic.GetHash(hash[:]); err != nil {
+				return err
+			}
+			l.Topics = append(l.Topics, hash)
+		}
+	}
+
+	if l.Data, err = elems[2].GetBytes(l.Data[:0]); err != nil {
+		return err
+	}
+	return nil
+}
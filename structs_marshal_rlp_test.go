@@ -2,9 +2,11 @@ package ethgo
 
 import (
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/umbracle/fastrlp"
 )
 
@@ -14,6 +16,19 @@ func TestEncodingRLP_Transaction_Fuzz(t *testing.T) {
 		err := fastrlp.Fuzz(100, obj,
 			fastrlp.WithDefaults(func(obj fastrlp.FuzzObject) {
 				obj.(*Transaction).Type = typ
+				// the blobs in the sidecar have a fixed size, it is tested
+				// with the network form of the transaction
+				obj.(*Transaction).Sidecar = nil
+				// blob and set code transactions cannot create contracts
+				if (typ == TransactionBlob || typ == TransactionSetCode) && obj.(*Transaction).To == nil {
+					obj.(*Transaction).To = &Address{}
+				}
+				// set code transactions require an authorization
+				if typ == TransactionSetCode && len(obj.(*Transaction).AuthorizationList) == 0 {
+					obj.(*Transaction).AuthorizationList = AuthorizationList{
+						{ChainID: big.NewInt(1), Nonce: 1, R: big.NewInt(1), S: big.NewInt(1)},
+					}
+				}
 			}),
 			fastrlp.WithPostHook(func(obj fastrlp.FuzzObject) error {
 				// Test that the hash from unmarshal is the same as the one computed
@@ -40,6 +55,12 @@ func TestEncodingRLP_Transaction_Fuzz(t *testing.T) {
 	t.Run("dynamicfee", func(t *testing.T) {
 		testTransaction(t, TransactionDynamicFee)
 	})
+	t.Run("blob", func(t *testing.T) {
+		testTransaction(t, TransactionBlob)
+	})
+	t.Run("setcode", func(t *testing.T) {
+		testTransaction(t, TransactionSetCode)
+	})
 }
 
 func TestEncodingRLP_AccessList_Fuzz(t *testing.T) {
@@ -48,3 +69,230 @@ func TestEncodingRLP_AccessList_Fuzz(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestEncodingRLP_AuthorizationList_Fuzz(t *testing.T) {
+	obj := &AuthorizationList{}
+	if err := fastrlp.Fuzz(100, obj); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEncodingRLP_BlobTransaction_NetworkForm(t *testing.T) {
+	to := Address{0x1}
+	commitment := KZGCommitment{0x1}
+
+	cellProofs := make([]KZGProof, CellProofsPerBlob)
+	for indx := range cellProofs {
+		cellProofs[indx] = KZGProof{byte(indx)}
+	}
+
+	cases := []struct {
+		name    string
+		sidecar *BlobSidecar
+		elems   int
+	}{
+		{
+			"eip-4844",
+			&BlobSidecar{
+				Version:     BlobSidecarVersion0,
+				Blobs:       []Blob{make(Blob, BlobSize)},
+				Commitments: []KZGCommitment{commitment},
+				Proofs:      []KZGProof{{0x2}},
+			},
+			4,
+		},
+		{
+			"eip-7594",
+			&BlobSidecar{
+				Version:     BlobSidecarVersion1,
+				Blobs:       []Blob{make(Blob, BlobSize)},
+				Commitments: []KZGCommitment{commitment},
+				Proofs:      cellProofs,
+			},
+			5,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			txn := &Transaction{
+				Type:                 TransactionBlob,
+				ChainID:              big.NewInt(1),
+				Nonce:                1,
+				MaxPriorityFeePerGas: big.NewInt(1),
+				MaxFeePerGas:         big.NewInt(2),
+				Gas:                  21000,
+				To:                   &to,
+				Value:                big.NewInt(0),
+				MaxFeePerBlobGas:     big.NewInt(3),
+				BlobVersionedHashes:  []Hash{commitment.VersionedHash()},
+				V:                    []byte{0x1},
+				R:                    []byte{0x1},
+				S:                    []byte{0x1},
+			}
+			hash, err := txn.GetHash()
+			require.NoError(t, err)
+
+			txn.Sidecar = c.sidecar
+
+			// the sidecar does not change the hash of the transaction
+			networkHash, err := txn.GetHash()
+			require.NoError(t, err)
+			require.Equal(t, hash, networkHash)
+
+			data, err := txn.MarshalRLPTo(nil)
+			require.NoError(t, err)
+			require.Equal(t, byte(TransactionBlob), data[0])
+
+			p := &fastrlp.Parser{}
+			v, err := p.Parse(data[1:])
+			require.NoError(t, err)
+			elems, err := v.GetElems()
+			require.NoError(t, err)
+			require.Len(t, elems, c.elems)
+
+			txn2 := &Transaction{}
+			require.NoError(t, txn2.UnmarshalRLP(data))
+			require.Equal(t, hash, txn2.Hash)
+			require.Equal(t, txn.Sidecar, txn2.Sidecar)
+			require.Equal(t, txn.BlobVersionedHashes, txn2.BlobVersionedHashes)
+			require.Equal(t, txn.Sidecar.VersionedHashes(), txn2.BlobVersionedHashes)
+
+			data2, err := txn2.MarshalRLPTo(nil)
+			require.NoError(t, err)
+			require.Equal(t, data, data2)
+		})
+	}
+}
+
+func TestEncodingRLP_BlobTransaction_NoTo(t *testing.T) {
+	txn := &Transaction{
+		Type:                 TransactionBlob,
+		ChainID:              big.NewInt(1),
+		MaxPriorityFeePerGas: big.NewInt(1),
+		MaxFeePerGas:         big.NewInt(2),
+		Value:                big.NewInt(0),
+		MaxFeePerBlobGas:     big.NewInt(3),
+	}
+	_, err := txn.MarshalRLPTo(nil)
+	require.Error(t, err)
+
+	// encode it as a transaction with a destination and remove it
+	txn.To = &Address{0x1}
+	data, err := txn.MarshalRLPTo(nil)
+	require.NoError(t, err)
+
+	p := &fastrlp.Parser{}
+	v, err := p.Parse(data[1:])
+	require.NoError(t, err)
+	elems, err := v.GetElems()
+	require.NoError(t, err)
+
+	a := &fastrlp.Arena{}
+	vv := a.NewArray()
+	for indx, elem := range elems {
+		if indx == 5 {
+			// the 'to' field
+			vv.Set(a.NewNull())
+		} else {
+			vv.Set(elem)
+		}
+	}
+	data = append([]byte{byte(TransactionBlob)}, vv.MarshalTo(nil)...)
+
+	err = new(Transaction).UnmarshalRLP(data)
+	require.Error(t, err)
+}
+
+func TestEncodingRLP_SetCodeTransaction_Invalid(t *testing.T) {
+	newTxn := func() *Transaction {
+		return &Transaction{
+			Type:                 TransactionSetCode,
+			ChainID:              big.NewInt(1),
+			MaxPriorityFeePerGas: big.NewInt(1),
+			MaxFeePerGas:         big.NewInt(2),
+			Value:                big.NewInt(0),
+			To:                   &Address{0x1},
+			AuthorizationList: AuthorizationList{
+				{ChainID: big.NewInt(1), Address: Address{0x2}, Nonce: 1, R: big.NewInt(1), S: big.NewInt(1)},
+			},
+		}
+	}
+	data, err := newTxn().MarshalRLPTo(nil)
+	require.NoError(t, err)
+
+	// replaceElem re-encodes the transaction with a different field
+	replaceElem := func(index int, fn func(a *fastrlp.Arena) *fastrlp.Value) []byte {
+		p := &fastrlp.Parser{}
+		v, err := p.Parse(data[1:])
+		require.NoError(t, err)
+		elems, err := v.GetElems()
+		require.NoError(t, err)
+
+		a := &fastrlp.Arena{}
+		vv := a.NewArray()
+		for indx, elem := range elems {
+			if indx == index {
+				vv.Set(fn(a))
+			} else {
+				vv.Set(elem)
+			}
+		}
+		return append([]byte{byte(TransactionSetCode)}, vv.MarshalTo(nil)...)
+	}
+
+	t.Run("no to", func(t *testing.T) {
+		txn := newTxn()
+		txn.To = nil
+		_, err := txn.MarshalRLPTo(nil)
+		require.Error(t, err)
+
+		// the 'to' field
+		raw := replaceElem(5, func(a *fastrlp.Arena) *fastrlp.Value {
+			return a.NewNull()
+		})
+		require.Error(t, new(Transaction).UnmarshalRLP(raw))
+	})
+
+	t.Run("empty authorization list", func(t *testing.T) {
+		txn := newTxn()
+		txn.AuthorizationList = nil
+		_, err := txn.MarshalRLPTo(nil)
+		require.Error(t, err)
+
+		// the authorization list field
+		raw := replaceElem(9, func(a *fastrlp.Arena) *fastrlp.Value {
+			return a.NewNullArray()
+		})
+		require.Error(t, new(Transaction).UnmarshalRLP(raw))
+	})
+}
+
+func TestEncodingRLP_Receipt_Fuzz(t *testing.T) {
+	testReceipt := func(t *testing.T, typ TransactionType) {
+		obj := &Receipt{}
+		err := fastrlp.Fuzz(100, obj,
+			fastrlp.WithDefaults(func(obj fastrlp.FuzzObject) {
+				obj.(*Receipt).Type = typ
+				obj.(*Receipt).LogsBloom = make([]byte, 256)
+
+				// remove the nil logs created by the fuzzer
+				logs := []*Log{}
+				for _, log := range obj.(*Receipt).Logs {
+					if log != nil {
+						logs = append(logs, log)
+					}
+				}
+				obj.(*Receipt).Logs = logs
+			}),
+		)
+		assert.NoError(t, err)
+	}
+
+	t.Run("legacy", func(t *testing.T) {
+		testReceipt(t, TransactionLegacy)
+	})
+	t.Run("dynamicfee", func(t *testing.T) {
+		testReceipt(t, TransactionDynamicFee)
+	})
+}
@@ -97,6 +97,27 @@ func TestLogFilter_MarshalJSON(t *testing.T) {
 	}
 }
 
+func TestLogFilter_MarshalJSON_Addresses(t *testing.T) {
+	filter := &LogFilter{}
+
+	output, err := filter.MarshalJSON()
+	require.NoError(t, err)
+	assert.NotContains(t, string(output), `"address"`)
+
+	filter.Address = []Address{{0x1}}
+
+	output, err = filter.MarshalJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(output), `"address":"0x0100000000000000000000000000000000000000"`)
+
+	// more than one address is encoded as an array
+	filter.Address = []Address{{0x1}, {0x2}}
+
+	output, err = filter.MarshalJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(output), `"address":["0x0100000000000000000000000000000000000000","0x0200000000000000000000000000000000000000"]`)
+}
+
 func TestMarshal_StateOverride(t *testing.T) {
 	nonce := uint64(1)
 	code := []byte{0x1}
@@ -2,6 +2,7 @@ package ethgo
 
 import (
 	_ "embed"
+	"encoding/hex"
 	"encoding/json"
 	"math/big"
 	"reflect"
@@ -43,6 +44,124 @@ func TestAddress_HexToString(t *testing.T) {
 	assert.Equal(t, HexToAddress("0000000000000000000000000000000000000001").String(), "0x0000000000000000000000000000000000000001")
 }
 
+func TestAddress_Parse(t *testing.T) {
+	addr, err := ParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	assert.NoError(t, err)
+	assert.Equal(t, HexToAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"), addr)
+
+	// single case addresses do not have a checksum
+	for _, str := range []string{
+		"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+		"0x5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED",
+		"5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+	} {
+		addr, err = ParseAddress(str)
+		assert.NoError(t, err)
+		assert.Equal(t, HexToAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"), addr)
+	}
+
+	for _, str := range []string{
+		"",
+		"0x",
+		"0x1",
+		// bad checksum
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD",
+		// too short
+		"0x5aaeb6053f3e94c9b9a09f33669435e7ef1bea",
+		// too long
+		"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed00",
+		// not hex
+		"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaeg",
+	} {
+		_, err := ParseAddress(str)
+		assert.Error(t, err, str)
+	}
+}
+
+func TestAddress_ChecksumWithChainID(t *testing.T) {
+	// eip-1191 test cases
+	cases := []struct {
+		chainID uint64
+		addr    string
+	}{
+		{30, "0x5aaEB6053f3e94c9b9a09f33669435E7ef1bEAeD"},
+		{30, "0xFb6916095cA1Df60bb79ce92cE3EA74c37c5d359"},
+		{30, "0xDBF03B407c01E7CD3cBea99509D93F8Dddc8C6FB"},
+		{30, "0xD1220A0Cf47c7B9BE7a2e6ba89F429762E7B9adB"},
+		{31, "0x5aAeb6053F3e94c9b9A09F33669435E7EF1BEaEd"},
+		{31, "0xFb6916095CA1dF60bb79CE92ce3Ea74C37c5D359"},
+		{31, "0xdbF03B407C01E7cd3cbEa99509D93f8dDDc8C6fB"},
+		{31, "0xd1220a0CF47c7B9Be7A2E6Ba89f429762E7b9adB"},
+	}
+	for _, c := range cases {
+		addr := HexToAddress(c.addr)
+		assert.Equal(t, c.addr, addr.ChecksumWithChainID(c.chainID))
+
+		parsed, err := ParseAddressWithChainID(c.addr, c.chainID)
+		assert.NoError(t, err)
+		assert.Equal(t, addr, parsed)
+
+		// the eip-55 checksum is not valid
+		_, err = ParseAddress(c.addr)
+		assert.Error(t, err)
+	}
+}
+
+func TestAddress_CreateAddress(t *testing.T) {
+	sender := HexToAddress("0x6ac7ea33f8831ea9dcc53393aaa88b25a785dbf0")
+
+	cases := []struct {
+		nonce uint64
+		addr  string
+	}{
+		{0, "0xcd234A471b72ba2F1Ccf0A70FCABA648a5eeCD8d"},
+		{1, "0x343c43A37D37dfF08AE8C4A11544c718AbB4fCF8"},
+		{127, "0x06d9a77f5E4b311Bae8D559DB9CDB4dF94104aA0"},
+		{128, "0x08e190dcB7b73F5fcDAbb43e102215c83659A76D"},
+		{256, "0x3837C1Ae70354f670550C746580199Ac6a73Cb0a"},
+		{18446744073709551615, "0x9bc924993b60399DF164c3763a964301D3dB95Ca"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.addr, CreateAddress(sender, c.nonce).String())
+	}
+}
+
+func TestAddress_Create2Address(t *testing.T) {
+	// eip-1014 test cases
+	cases := []struct {
+		deployer string
+		salt     string
+		initCode string
+		addr     string
+	}{
+		{
+			"0x0000000000000000000000000000000000000000",
+			"0x0000000000000000000000000000000000000000000000000000000000000000",
+			"00",
+			"0x4D1A2e2bB4F88F0250f26Ffff098B0b30B26BF38",
+		},
+		{
+			"0xdeadbeef00000000000000000000000000000000",
+			"0x000000000000000000000000feed000000000000000000000000000000000000",
+			"00",
+			"0xD04116cDd17beBE565EB2422F2497E06cC1C9833",
+		},
+		{
+			"0x00000000000000000000000000000000deadbeef",
+			"0x00000000000000000000000000000000000000000000000000000000cafebabe",
+			"deadbeef",
+			"0x60f3f640a8508fC6a86d45DF051962668E1e8AC7",
+		},
+	}
+	for _, c := range cases {
+		initCode, err := hex.DecodeString(c.initCode)
+		assert.NoError(t, err)
+
+		addr := Create2Address(HexToAddress(c.deployer), HexToHash(c.salt), BytesToHash(Keccak256(initCode)))
+		assert.Equal(t, c.addr, addr.String())
+	}
+}
+
 func TestHash_HexToString(t *testing.T) {
 	assert.Equal(t, HexToHash("1").String(), "0x0000000000000000000000000000000000000000000000000000000000000001")
 }
@@ -106,6 +225,41 @@ func TestLog_Copy(t *testing.T) {
 	}
 }
 
+func TestLogFilter_Match(t *testing.T) {
+	topic0, topic1, topic2 := Hash{0x1}, Hash{0x2}, Hash{0x3}
+
+	log := &Log{
+		Address:     Address{0x1},
+		Topics:      []Hash{topic0, topic1},
+		BlockHash:   Hash{0x1},
+		BlockNumber: 10,
+	}
+
+	from, to := BlockNumber(11), BlockNumber(9)
+
+	cases := []struct {
+		filter *LogFilter
+		match  bool
+	}{
+		{&LogFilter{}, true},
+		{&LogFilter{Address: []Address{{0x1}}}, true},
+		{&LogFilter{Address: []Address{{0x2}, {0x1}}}, true},
+		{&LogFilter{Address: []Address{{0x2}}}, false},
+		{&LogFilter{Topics: [][]*Hash{{&topic0}}}, true},
+		{&LogFilter{Topics: [][]*Hash{{&topic1}}}, false},
+		{&LogFilter{Topics: [][]*Hash{nil, {&topic2, &topic1}}}, true},
+		{&LogFilter{Topics: [][]*Hash{{nil}, {&topic1}}}, true},
+		{&LogFilter{Topics: [][]*Hash{nil, nil, nil}}, false},
+		{&LogFilter{BlockHash: &Hash{0x2}}, false},
+		{&LogFilter{From: &from}, false},
+		{&LogFilter{To: &to}, false},
+		{&LogFilter{To: func() *BlockNumber { b := Latest; return &b }()}, true},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.match, c.filter.Match(log))
+	}
+}
+
 //go:embed testsuite/receipts.json
 var receiptsFixtures []byte
 
@@ -117,4 +271,50 @@ func TestReceipt_Unmarshal(t *testing.T) {
 		receipt := &Receipt{}
 		assert.NoError(t, receipt.UnmarshalJSON(c))
 	}
+
+	// the last receipt is from a blob transaction
+	receipt := &Receipt{}
+	assert.NoError(t, receipt.UnmarshalJSON(cases[len(cases)-1]))
+	assert.Equal(t, TransactionBlob, receipt.Type)
+	assert.Equal(t, uint64(0x20000), receipt.BlobGasUsed)
+	assert.Equal(t, big.NewInt(1), receipt.BlobGasPrice)
+	assert.Equal(t, big.NewInt(0x1b09d63b), receipt.EffectiveGasPrice)
+}
+
+func TestBlock_ComputeHash(t *testing.T) {
+	for _, c := range readTestsuite(t, "./testsuite/header-*.json") {
+		b := new(Block)
+		assert.NoError(t, b.UnmarshalJSON(c.content))
+
+		hash, err := b.ComputeHash()
+		assert.NoError(t, err)
+		assert.Equal(t, b.Hash, hash, c.name)
+	}
+}
+
+func TestBlock_ComputeHash_MissingForkField(t *testing.T) {
+	root := Hash{0x1}
+	b := &Block{
+		Difficulty:            big.NewInt(0),
+		ParentBeaconBlockRoot: &root,
+	}
+	_, err := b.ComputeHash()
+	assert.Error(t, err)
+}
+
+func TestReceipt_MarshalJSON(t *testing.T) {
+	var cases []json.RawMessage
+	assert.NoError(t, json.Unmarshal(receiptsFixtures, &cases))
+
+	for _, c := range cases {
+		receipt := &Receipt{}
+		assert.NoError(t, receipt.UnmarshalJSON(c))
+
+		data, err := receipt.MarshalJSON()
+		assert.NoError(t, err)
+
+		receipt2 := &Receipt{}
+		assert.NoError(t, receipt2.UnmarshalJSON(data))
+		assert.Equal(t, receipt, receipt2)
+	}
 }
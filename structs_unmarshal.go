@@ -67,12 +67,56 @@ func (b *Block) UnmarshalJSON(buf []byte) error {
 	if b.ExtraData, err = decodeBytes(b.ExtraData[:0], v, "extraData"); err != nil {
 		return err
 	}
-	if b.BaseFee, err = decodeBigInt(b.BaseFee, v, "baseFee"); err != nil {
-		if err.Error() != "field 'baseFee' not found" {
+	if isKeySet(v, "logsBloom") {
+		if b.LogsBloom, err = decodeBytes(b.LogsBloom[:0], v, "logsBloom", 256); err != nil {
 			return err
 		}
 	}
 
+	// london
+	b.BaseFee = nil
+	if isKeySet(v, "baseFeePerGas") {
+		if b.BaseFee, err = decodeBigInt(b.BaseFee, v, "baseFeePerGas"); err != nil {
+			return err
+		}
+	} else if isKeySet(v, "baseFee") {
+		if b.BaseFee, err = decodeBigInt(b.BaseFee, v, "baseFee"); err != nil {
+			return err
+		}
+	}
+
+	// shanghai
+	if b.WithdrawalsRoot, err = decodeOptionalHash(v, "withdrawalsRoot"); err != nil {
+		return err
+	}
+	b.Withdrawals = nil
+	if isKeySet(v, "withdrawals") {
+		b.Withdrawals = []*Withdrawal{}
+		for _, elem := range v.GetArray("withdrawals") {
+			w := new(Withdrawal)
+			if err := w.unmarshalJSON(elem); err != nil {
+				return err
+			}
+			b.Withdrawals = append(b.Withdrawals, w)
+		}
+	}
+
+	// cancun
+	if b.BlobGasUsed, err = decodeOptionalUint(v, "blobGasUsed"); err != nil {
+		return err
+	}
+	if b.ExcessBlobGas, err = decodeOptionalUint(v, "excessBlobGas"); err != nil {
+		return err
+	}
+	if b.ParentBeaconBlockRoot, err = decodeOptionalHash(v, "parentBeaconBlockRoot"); err != nil {
+		return err
+	}
+
+	// prague
+	if b.RequestsHash, err = decodeOptionalHash(v, "requestsHash"); err != nil {
+		return err
+	}
+
 	b.TransactionsHashes = b.TransactionsHashes[:0]
 	b.Transactions = b.Transactions[:0]
 
@@ -112,6 +156,23 @@ func (b *Block) UnmarshalJSON(buf []byte) error {
 	return nil
 }
 
+func (w *Withdrawal) unmarshalJSON(v *fastjson.Value) error {
+	var err error
+	if w.Index, err = decodeUint(v, "index"); err != nil {
+		return err
+	}
+	if w.ValidatorIndex, err = decodeUint(v, "validatorIndex"); err != nil {
+		return err
+	}
+	if err = decodeAddr(&w.Address, v, "address"); err != nil {
+		return err
+	}
+	if w.Amount, err = decodeUint(v, "amount"); err != nil {
+		return err
+	}
+	return nil
+}
+
 // UnmarshalJSON implements the unmarshal interface
 func (t *Transaction) UnmarshalJSON(buf []byte) error {
 	p := defaultPool.Get()
@@ -149,7 +210,11 @@ func (t *Transaction) unmarshalJSON(v *fastjson.Value) error {
 		t.Type = TransactionType(txnType)
 	} else {
 		if isKeySet(v, "chainId") {
-			if isKeySet(v, "maxFeePerGas") {
+			if isKeySet(v, "authorizationList") {
+				t.Type = TransactionSetCode
+			} else if isKeySet(v, "maxFeePerBlobGas") {
+				t.Type = TransactionBlob
+			} else if isKeySet(v, "maxFeePerGas") {
 				t.Type = TransactionDynamicFee
 			} else {
 				t.Type = TransactionAccessList
@@ -166,7 +231,7 @@ func (t *Transaction) unmarshalJSON(v *fastjson.Value) error {
 	if err = decodeAddr(&t.From, v, "from"); err != nil {
 		return err
 	}
-	if t.Type == TransactionDynamicFee {
+	if t.Type == TransactionDynamicFee || t.Type == TransactionBlob || t.Type == TransactionSetCode {
 		if t.MaxPriorityFeePerGas, err = decodeBigInt(t.MaxPriorityFeePerGas, v, "maxPriorityFeePerGas"); err != nil {
 			return err
 		}
@@ -211,7 +276,7 @@ func (t *Transaction) unmarshalJSON(v *fastjson.Value) error {
 		return err
 	}
 
-	if t.Type == TransactionDynamicFee || t.Type == TransactionAccessList {
+	if t.Type == TransactionDynamicFee || t.Type == TransactionAccessList || t.Type == TransactionBlob || t.Type == TransactionSetCode {
 		if t.ChainID, err = decodeBigInt(t.ChainID, v, "chainId"); err != nil {
 			return err
 		}
@@ -222,6 +287,38 @@ func (t *Transaction) unmarshalJSON(v *fastjson.Value) error {
 		}
 	}
 
+	if t.Type == TransactionSetCode {
+		t.AuthorizationList = t.AuthorizationList[:0]
+		if isKeySet(v, "authorizationList") {
+			if err := t.AuthorizationList.unmarshalJSON(v.Get("authorizationList")); err != nil {
+				return err
+			}
+		}
+	}
+
+	if t.Type == TransactionBlob {
+		if t.MaxFeePerBlobGas, err = decodeBigInt(t.MaxFeePerBlobGas, v, "maxFeePerBlobGas"); err != nil {
+			return err
+		}
+		t.BlobVersionedHashes = t.BlobVersionedHashes[:0]
+		for _, elem := range v.GetArray("blobVersionedHashes") {
+			var h Hash
+			if err := h.UnmarshalText(elem.GetStringBytes()); err != nil {
+				return err
+			}
+			t.BlobVersionedHashes = append(t.BlobVersionedHashes, h)
+		}
+
+		// the sidecar is only available on the network form of the transaction
+		t.Sidecar = nil
+		if isKeySet(v, "blobs") {
+			t.Sidecar = new(BlobSidecar)
+			if err := t.Sidecar.unmarshalJSON(v); err != nil {
+				return err
+			}
+		}
+	}
+
 	if t.Gas, err = decodeUint(v, "gas"); err != nil {
 		return err
 	}
@@ -251,6 +348,68 @@ func (t *Transaction) unmarshalJSON(v *fastjson.Value) error {
 	return nil
 }
 
+func (t *AuthorizationList) unmarshalJSON(v *fastjson.Value) error {
+	elems, err := v.Array()
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		entry := Authorization{}
+		if entry.ChainID, err = decodeBigInt(entry.ChainID, elem, "chainId"); err != nil {
+			return err
+		}
+		if err = decodeAddr(&entry.Address, elem, "address"); err != nil {
+			return err
+		}
+		if entry.Nonce, err = decodeUint(elem, "nonce"); err != nil {
+			return err
+		}
+		yParity, err := decodeUint(elem, "yParity")
+		if err != nil {
+			return err
+		}
+		if yParity > 0xff {
+			return fmt.Errorf("field 'yParity' overflows uint8: %d", yParity)
+		}
+		entry.YParity = uint8(yParity)
+		if entry.R, err = decodeBigInt(entry.R, elem, "r"); err != nil {
+			return err
+		}
+		if entry.S, err = decodeBigInt(entry.S, elem, "s"); err != nil {
+			return err
+		}
+		*t = append(*t, entry)
+	}
+	return nil
+}
+
+func (b *BlobSidecar) unmarshalJSON(v *fastjson.Value) error {
+	blobs := v.GetArray("blobs")
+	b.Blobs = make([]Blob, len(blobs))
+	for indx, elem := range blobs {
+		if err := b.Blobs[indx].UnmarshalText(elem.GetStringBytes()); err != nil {
+			return err
+		}
+	}
+
+	commitments := v.GetArray("commitments")
+	b.Commitments = make([]KZGCommitment, len(commitments))
+	for indx, elem := range commitments {
+		if err := b.Commitments[indx].UnmarshalText(elem.GetStringBytes()); err != nil {
+			return err
+		}
+	}
+
+	proofs := v.GetArray("proofs")
+	b.Proofs = make([]KZGProof, len(proofs))
+	for indx, elem := range proofs {
+		if err := b.Proofs[indx].UnmarshalText(elem.GetStringBytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (t *AccessList) unmarshalJSON(v *fastjson.Value) error {
 	elems, err := v.Array()
 	if err != nil {
@@ -326,6 +485,9 @@ func (r *Receipt) UnmarshalJSON(buf []byte) error {
 			return err
 		}
 	}
+	if r.Root, err = decodeOptionalHash(v, "root"); err != nil {
+		return err
+	}
 
 	if v.Exists("to") {
 		// Do not decode 'to' if it doesn't exist.
@@ -338,6 +500,30 @@ func (r *Receipt) UnmarshalJSON(buf []byte) error {
 		}
 	}
 
+	r.Type = TransactionLegacy
+	if isKeySet(v, "type") {
+		typ, err := decodeUint(v, "type")
+		if err != nil {
+			return err
+		}
+		r.Type = TransactionType(typ)
+	}
+	if isKeySet(v, "effectiveGasPrice") {
+		if r.EffectiveGasPrice, err = decodeBigInt(r.EffectiveGasPrice, v, "effectiveGasPrice"); err != nil {
+			return err
+		}
+	}
+	if isKeySet(v, "blobGasUsed") {
+		if r.BlobGasUsed, err = decodeUint(v, "blobGasUsed"); err != nil {
+			return err
+		}
+	}
+	if isKeySet(v, "blobGasPrice") {
+		if r.BlobGasPrice, err = decodeBigInt(r.BlobGasPrice, v, "blobGasPrice"); err != nil {
+			return err
+		}
+	}
+
 	// logs
 	r.Logs = r.Logs[:0]
 	for _, elem := range v.GetArray("logs") {
@@ -527,6 +713,87 @@ func (r *Log) unmarshalJSON(v *fastjson.Value) error {
 	return nil
 }
 
+// UnmarshalJSON implements the unmarshal interface
+func (a *AccountProof) UnmarshalJSON(buf []byte) error {
+	p := defaultPool.Get()
+	defer defaultPool.Put(p)
+
+	v, err := p.Parse(string(buf))
+	if err != nil {
+		return err
+	}
+
+	if err := decodeAddr(&a.Address, v, "address"); err != nil {
+		return err
+	}
+	if a.AccountProof, err = decodeProof(v, "accountProof"); err != nil {
+		return err
+	}
+	if a.Balance, err = decodeBigInt(a.Balance, v, "balance"); err != nil {
+		return err
+	}
+	if err := decodeHash(&a.CodeHash, v, "codeHash"); err != nil {
+		return err
+	}
+	if a.Nonce, err = decodeUint(v, "nonce"); err != nil {
+		return err
+	}
+	if err := decodeHash(&a.StorageHash, v, "storageHash"); err != nil {
+		return err
+	}
+
+	a.StorageProof = a.StorageProof[:0]
+	for _, elem := range v.GetArray("storageProof") {
+		s := new(StorageProof)
+		// the key is returned as a quantity if it was not
+		// sent as a full 32 bytes hash
+		key, err := decodeBytes(nil, elem, "key")
+		if err != nil {
+			return err
+		}
+		if len(key) > 32 {
+			return fmt.Errorf("storage key too long: %d bytes", len(key))
+		}
+		s.Key = BytesToHash(key)
+		if s.Value, err = decodeBigInt(nil, elem, "value"); err != nil {
+			return err
+		}
+		if s.Proof, err = decodeProof(elem, "proof"); err != nil {
+			return err
+		}
+		a.StorageProof = append(a.StorageProof, s)
+	}
+	return nil
+}
+
+func decodeProof(v *fastjson.Value, key string) ([][]byte, error) {
+	vv := v.Get(key)
+	if vv == nil {
+		return nil, fmt.Errorf("field '%s' not found", key)
+	}
+	elems, err := vv.Array()
+	if err != nil {
+		return nil, fmt.Errorf("field '%s' is not an array: %v", key, err)
+	}
+	proof := make([][]byte, 0, len(elems))
+	for _, elem := range elems {
+		b, err := elem.StringBytes()
+		if err != nil {
+			return nil, err
+		}
+		str := string(b)
+		if !strings.HasPrefix(str, "0x") {
+			return nil, fmt.Errorf("field '%s' does not have 0x prefix: '%s'", key, str)
+		}
+		buf, err := hex.DecodeString(str[2:])
+		if err != nil {
+			return nil, err
+		}
+		proof = append(proof, buf)
+	}
+	return proof, nil
+}
+
 func fieldNotFull(v *fastjson.Value, key string) bool {
 	vv := v.Get(key)
 	if vv == nil {
@@ -610,6 +877,28 @@ func decodeUint(v *fastjson.Value, key string) (uint64, error) {
 	return num, nil
 }
 
+func decodeOptionalUint(v *fastjson.Value, key string) (*uint64, error) {
+	if !isKeySet(v, key) {
+		return nil, nil
+	}
+	num, err := decodeUint(v, key)
+	if err != nil {
+		return nil, err
+	}
+	return &num, nil
+}
+
+func decodeOptionalHash(v *fastjson.Value, key string) (*Hash, error) {
+	if !isKeySet(v, key) {
+		return nil, nil
+	}
+	h := new(Hash)
+	if err := decodeHash(h, v, key); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
 func decodeInt64(v *fastjson.Value, key string) (int64, error) {
 	vv := v.Get(key)
 	if vv == nil {
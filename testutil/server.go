@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"math/big"
@@ -276,7 +277,7 @@ func (t *TestServer) WaitForReceipt(hash ethgo.Hash) (*ethgo.Receipt, error) {
 	for {
 		err := t.client.call("eth_getTransactionReceipt", &receipt, hash)
 		if err != nil {
-			if err.Error() != "not found" {
+			if !errors.Is(err, errNotFound) {
 				return nil, err
 			}
 		}
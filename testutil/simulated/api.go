@@ -0,0 +1,566 @@
+package simulated
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc/server"
+)
+
+// blockParam is a block number, a tag or a block hash (eip-1898)
+type blockParam struct {
+	num  ethgo.BlockNumber
+	hash *ethgo.Hash
+}
+
+func (b *blockParam) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		return b.parse(str)
+	}
+
+	var obj struct {
+		BlockNumber *string     `json:"blockNumber"`
+		BlockHash   *ethgo.Hash `json:"blockHash"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	if obj.BlockHash != nil {
+		b.hash = obj.BlockHash
+		return nil
+	}
+	if obj.BlockNumber != nil {
+		return b.parse(*obj.BlockNumber)
+	}
+	return fmt.Errorf("invalid block parameter")
+}
+
+func (b *blockParam) parse(str string) error {
+	switch str {
+	case "latest", "safe", "finalized":
+		b.num = ethgo.Latest
+	case "pending":
+		b.num = ethgo.Pending
+	case "earliest":
+		b.num = ethgo.Earliest
+	default:
+		if len(str) == 66 {
+			var hash ethgo.Hash
+			if err := hash.UnmarshalText([]byte(str)); err != nil {
+				return err
+			}
+			b.hash = &hash
+			return nil
+		}
+		var num ethgo.ArgUint64
+		if err := num.UnmarshalText([]byte(str)); err != nil {
+			return fmt.Errorf("invalid block parameter %q", str)
+		}
+		b.num = ethgo.BlockNumber(num)
+	}
+	return nil
+}
+
+// blockByParam returns the block of the param or the latest one if not set
+func (b *Backend) blockByParam(p *blockParam) (*ethgo.Block, error) {
+	if p == nil {
+		return b.head(), nil
+	}
+	if p.hash != nil {
+		block, ok := b.blocksByHash[*p.hash]
+		if !ok {
+			return nil, fmt.Errorf("block %s not found", p.hash)
+		}
+		return block, nil
+	}
+
+	switch p.num {
+	case ethgo.Latest, ethgo.Pending:
+		return b.head(), nil
+	case ethgo.Earliest:
+		return b.blocks[0], nil
+	}
+	if int(p.num) >= len(b.blocks) {
+		return nil, fmt.Errorf("header not found")
+	}
+	return b.blocks[p.num], nil
+}
+
+// stateByParam returns the state at the block of the param. The pending
+// state includes the nonces and the maximum cost of the pending transactions.
+func (b *Backend) stateByParam(p *blockParam) (state, *ethgo.Block, error) {
+	if p != nil && p.hash == nil && p.num == ethgo.Pending {
+		return b.pendingState, b.head(), nil
+	}
+	block, err := b.blockByParam(p)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b.states[block.Number], block, nil
+}
+
+// callArgs are the arguments of eth_call and eth_estimateGas
+type callArgs struct {
+	From                 *ethgo.Address   `json:"from"`
+	To                   *ethgo.Address   `json:"to"`
+	Gas                  *ethgo.ArgUint64 `json:"gas"`
+	GasPrice             *ethgo.ArgBig    `json:"gasPrice"`
+	MaxFeePerGas         *ethgo.ArgBig    `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *ethgo.ArgBig    `json:"maxPriorityFeePerGas"`
+	Value                *ethgo.ArgBig    `json:"value"`
+	Data                 *ethgo.ArgBytes  `json:"data"`
+	Input                *ethgo.ArgBytes  `json:"input"`
+	AccessList           ethgo.AccessList `json:"accessList"`
+}
+
+func (c *callArgs) from() ethgo.Address {
+	if c.From == nil {
+		return ethgo.ZeroAddress
+	}
+	return *c.From
+}
+
+func (c *callArgs) input() []byte {
+	if c.Input != nil {
+		return c.Input.Bytes()
+	}
+	if c.Data != nil {
+		return c.Data.Bytes()
+	}
+	return nil
+}
+
+// filterArgs are the arguments of eth_getLogs and the logs subscription
+type filterArgs struct {
+	BlockHash *ethgo.Hash
+	FromBlock *blockParam
+	ToBlock   *blockParam
+	Address   []ethgo.Address
+	Topics    [][]*ethgo.Hash
+}
+
+func (f *filterArgs) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		BlockHash *ethgo.Hash       `json:"blockHash"`
+		FromBlock *blockParam       `json:"fromBlock"`
+		ToBlock   *blockParam       `json:"toBlock"`
+		Address   json.RawMessage   `json:"address"`
+		Topics    []json.RawMessage `json:"topics"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	f.BlockHash, f.FromBlock, f.ToBlock = raw.BlockHash, raw.FromBlock, raw.ToBlock
+
+	// the address and the topics can be a single value or a list
+	if len(raw.Address) != 0 && string(raw.Address) != "null" {
+		if raw.Address[0] == '[' {
+			if err := json.Unmarshal(raw.Address, &f.Address); err != nil {
+				return err
+			}
+		} else {
+			var addr ethgo.Address
+			if err := json.Unmarshal(raw.Address, &addr); err != nil {
+				return err
+			}
+			f.Address = []ethgo.Address{addr}
+		}
+	}
+	for _, topic := range raw.Topics {
+		switch {
+		case string(topic) == "null":
+			f.Topics = append(f.Topics, nil)
+		case topic[0] == '[':
+			var topics []*ethgo.Hash
+			if err := json.Unmarshal(topic, &topics); err != nil {
+				return err
+			}
+			f.Topics = append(f.Topics, topics)
+		default:
+			var hash ethgo.Hash
+			if err := json.Unmarshal(topic, &hash); err != nil {
+				return err
+			}
+			f.Topics = append(f.Topics, []*ethgo.Hash{&hash})
+		}
+	}
+	return nil
+}
+
+// logFilter returns the filter of the addresses and topics
+func (f *filterArgs) logFilter() *ethgo.LogFilter {
+	return &ethgo.LogFilter{Address: f.Address, Topics: f.Topics}
+}
+
+// ethAPI is the eth namespace of the backend
+type ethAPI struct {
+	b *Backend
+}
+
+func (e *ethAPI) ChainId() ethgo.ArgUint64 {
+	return ethgo.ArgUint64(e.b.config.ChainID)
+}
+
+func (e *ethAPI) BlockNumber() ethgo.ArgUint64 {
+	return ethgo.ArgUint64(e.b.Head().Number)
+}
+
+func (e *ethAPI) GasPrice() *ethgo.ArgBig {
+	price := new(big.Int).Add(e.b.config.BaseFee, e.b.config.GasTipCap)
+	return (*ethgo.ArgBig)(price)
+}
+
+func (e *ethAPI) MaxPriorityFeePerGas() *ethgo.ArgBig {
+	return (*ethgo.ArgBig)(new(big.Int).Set(e.b.config.GasTipCap))
+}
+
+func (e *ethAPI) Accounts() []ethgo.Address {
+	return []ethgo.Address{}
+}
+
+func (e *ethAPI) Syncing() bool {
+	return false
+}
+
+func (e *ethAPI) GetBalance(addr ethgo.Address, block *blockParam) (*ethgo.ArgBig, error) {
+	e.b.lock.Lock()
+	defer e.b.lock.Unlock()
+
+	st, _, err := e.b.stateByParam(block)
+	if err != nil {
+		return nil, err
+	}
+	balance := new(big.Int)
+	if acct, ok := st[addr]; ok {
+		balance.Set(acct.Balance)
+	}
+	return (*ethgo.ArgBig)(balance), nil
+}
+
+func (e *ethAPI) GetTransactionCount(addr ethgo.Address, block *blockParam) (ethgo.ArgUint64, error) {
+	e.b.lock.Lock()
+	defer e.b.lock.Unlock()
+
+	st, _, err := e.b.stateByParam(block)
+	if err != nil {
+		return 0, err
+	}
+	var nonce uint64
+	if acct, ok := st[addr]; ok {
+		nonce = acct.Nonce
+	}
+	return ethgo.ArgUint64(nonce), nil
+}
+
+func (e *ethAPI) GetCode(addr ethgo.Address, block *blockParam) (ethgo.ArgBytes, error) {
+	e.b.lock.Lock()
+	defer e.b.lock.Unlock()
+
+	st, _, err := e.b.stateByParam(block)
+	if err != nil {
+		return nil, err
+	}
+	code := ethgo.ArgBytes{}
+	if acct, ok := st[addr]; ok {
+		code = append(code, acct.Code...)
+	}
+	return code, nil
+}
+
+func (e *ethAPI) GetBlockByNumber(block blockParam, full bool) (*rpcBlock, error) {
+	e.b.lock.Lock()
+	defer e.b.lock.Unlock()
+
+	b, err := e.b.blockByParam(&block)
+	if err != nil {
+		// unknown blocks are returned as null
+		return nil, nil
+	}
+	return newRPCBlock(b, full), nil
+}
+
+func (e *ethAPI) GetBlockByHash(hash ethgo.Hash, full bool) *rpcBlock {
+	e.b.lock.Lock()
+	defer e.b.lock.Unlock()
+
+	return newRPCBlock(e.b.blocksByHash[hash], full)
+}
+
+func (e *ethAPI) GetBlockReceipts(block blockParam) ([]*ethgo.Receipt, error) {
+	e.b.lock.Lock()
+	defer e.b.lock.Unlock()
+
+	b, err := e.b.blockByParam(&block)
+	if err != nil {
+		return nil, nil
+	}
+	return append([]*ethgo.Receipt{}, e.b.receipts[b.Number]...), nil
+}
+
+func (e *ethAPI) GetTransactionByHash(hash ethgo.Hash) *rpcTransaction {
+	e.b.lock.Lock()
+	defer e.b.lock.Unlock()
+
+	return newRPCTransaction(e.b.txns[hash])
+}
+
+func (e *ethAPI) GetTransactionReceipt(hash ethgo.Hash) *ethgo.Receipt {
+	e.b.lock.Lock()
+	defer e.b.lock.Unlock()
+
+	return e.b.txReceipts[hash]
+}
+
+func (e *ethAPI) SendRawTransaction(data ethgo.ArgBytes) (ethgo.Hash, error) {
+	tx := new(ethgo.Transaction)
+	if err := tx.UnmarshalRLP(data); err != nil {
+		return ethgo.Hash{}, fmt.Errorf("failed to decode transaction: %v", err)
+	}
+	return e.b.SendTransaction(tx)
+}
+
+func (e *ethAPI) Call(msg callArgs, block *blockParam) (ethgo.ArgBytes, error) {
+	e.b.lock.Lock()
+	defer e.b.lock.Unlock()
+
+	st, b, err := e.b.stateByParam(block)
+	if err != nil {
+		return nil, err
+	}
+	return e.b.call(&msg, st, b)
+}
+
+func (e *ethAPI) EstimateGas(msg callArgs, block *blockParam) (ethgo.ArgUint64, error) {
+	e.b.lock.Lock()
+	defer e.b.lock.Unlock()
+
+	st, b, err := e.b.stateByParam(block)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := e.b.call(&msg, st, b); err != nil {
+		return 0, err
+	}
+	return ethgo.ArgUint64(intrinsicGas(msg.input(), msg.AccessList)), nil
+}
+
+func (e *ethAPI) GetLogs(filter filterArgs) ([]*ethgo.Log, error) {
+	e.b.lock.Lock()
+	defer e.b.lock.Unlock()
+
+	var blocks []*ethgo.Block
+	if filter.BlockHash != nil {
+		block, ok := e.b.blocksByHash[*filter.BlockHash]
+		if !ok {
+			return nil, fmt.Errorf("block %s not found", filter.BlockHash)
+		}
+		blocks = append(blocks, block)
+	} else {
+		from, err := e.b.blockByParam(filter.FromBlock)
+		if err != nil {
+			return nil, err
+		}
+		to, err := e.b.blockByParam(filter.ToBlock)
+		if err != nil {
+			return nil, err
+		}
+		if from.Number <= to.Number {
+			blocks = e.b.blocks[from.Number : to.Number+1]
+		}
+	}
+
+	lf := filter.logFilter()
+	logs := []*ethgo.Log{}
+	for _, block := range blocks {
+		for _, receipt := range e.b.receipts[block.Number] {
+			for _, log := range receipt.Logs {
+				if lf.Match(log) {
+					logs = append(logs, log)
+				}
+			}
+		}
+	}
+	return logs, nil
+}
+
+func (e *ethAPI) NewHeads(ctx context.Context) (*server.Subscription, error) {
+	return e.subscribe(ctx, func(notify func(data interface{})) *subscriber {
+		return &subscriber{
+			block: func(block *ethgo.Block, logs []*ethgo.Log) {
+				notify(newRPCBlock(block, false))
+			},
+		}
+	})
+}
+
+func (e *ethAPI) Logs(ctx context.Context, filter *filterArgs) (*server.Subscription, error) {
+	lf := &ethgo.LogFilter{}
+	if filter != nil {
+		lf = filter.logFilter()
+	}
+	return e.subscribe(ctx, func(notify func(data interface{})) *subscriber {
+		return &subscriber{
+			block: func(block *ethgo.Block, logs []*ethgo.Log) {
+				for _, log := range logs {
+					if lf.Match(log) {
+						notify(log)
+					}
+				}
+			},
+		}
+	})
+}
+
+func (e *ethAPI) NewPendingTransactions(ctx context.Context) (*server.Subscription, error) {
+	return e.subscribe(ctx, func(notify func(data interface{})) *subscriber {
+		return &subscriber{
+			tx: func(hash ethgo.Hash) {
+				notify(hash)
+			},
+		}
+	})
+}
+
+// subscribe creates a subscription that notifies the events of the subscriber
+func (e *ethAPI) subscribe(ctx context.Context, fn func(notify func(data interface{})) *subscriber) (*server.Subscription, error) {
+	notifier, ok := server.NotifierFromContext(ctx)
+	if !ok {
+		return nil, server.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+
+	cancel := e.b.subscribe(fn(func(data interface{}) {
+		notifier.Notify(data)
+	}))
+	go func() {
+		<-sub.Err()
+		cancel()
+	}()
+	return sub, nil
+}
+
+// netAPI is the net namespace of the backend
+type netAPI struct {
+	b *Backend
+}
+
+func (n *netAPI) Version() string {
+	return strconv.FormatUint(n.b.config.ChainID, 10)
+}
+
+func (n *netAPI) Listening() bool {
+	return true
+}
+
+func (n *netAPI) PeerCount() ethgo.ArgUint64 {
+	return 0
+}
+
+// web3API is the web3 namespace of the backend
+type web3API struct{}
+
+func (w *web3API) ClientVersion() string {
+	return "ethgo/simulated"
+}
+
+func (w *web3API) Sha3(data ethgo.ArgBytes) ethgo.Hash {
+	return ethgo.BytesToHash(ethgo.Keccak256(data))
+}
+
+// evmAPI has the methods to mine blocks on demand used by
+// other development nodes (i.e. evm_mine)
+type evmAPI struct {
+	b *Backend
+}
+
+func (e *evmAPI) Mine() string {
+	e.b.Commit()
+	return "0x0"
+}
+
+// rpcTransaction is the jsonrpc form of a transaction. Unlike the marshaling of
+// ethgo.Transaction (used for eth_sendTransaction), it always includes the nonce,
+// the gas, the value and the input. The block values are null while it is pending.
+type rpcTransaction struct {
+	tx *ethgo.Transaction
+}
+
+func newRPCTransaction(tx *ethgo.Transaction) *rpcTransaction {
+	if tx == nil {
+		return nil
+	}
+	return &rpcTransaction{tx: tx}
+}
+
+func (r *rpcTransaction) MarshalJSON() ([]byte, error) {
+	data, err := r.tx.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	value := new(big.Int)
+	if r.tx.Value != nil {
+		value = r.tx.Value
+	}
+	obj["nonce"], _ = json.Marshal(ethgo.ArgUint64(r.tx.Nonce))
+	obj["gas"], _ = json.Marshal(ethgo.ArgUint64(r.tx.Gas))
+	obj["value"], _ = json.Marshal((*ethgo.ArgBig)(value))
+	obj["input"], _ = json.Marshal(ethgo.ArgBytes(r.tx.Input))
+
+	if r.tx.BlockHash == (ethgo.Hash{}) {
+		obj["blockHash"] = json.RawMessage("null")
+		obj["blockNumber"] = json.RawMessage("null")
+		obj["transactionIndex"] = json.RawMessage("null")
+	}
+	return json.Marshal(obj)
+}
+
+// rpcBlock is the jsonrpc form of a block with either the full
+// transactions or their hashes
+type rpcBlock struct {
+	block *ethgo.Block
+	full  bool
+}
+
+func newRPCBlock(block *ethgo.Block, full bool) *rpcBlock {
+	if block == nil {
+		return nil
+	}
+	return &rpcBlock{block: block, full: full}
+}
+
+func (r *rpcBlock) MarshalJSON() ([]byte, error) {
+	b := *r.block
+	b.Transactions = nil
+	data, err := b.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	txns := []interface{}{}
+	for _, tx := range r.block.Transactions {
+		if r.full {
+			txns = append(txns, newRPCTransaction(tx))
+		} else {
+			txns = append(txns, tx.Hash)
+		}
+	}
+	if obj["transactions"], err = json.Marshal(txns); err != nil {
+		return nil, err
+	}
+	obj["uncles"] = json.RawMessage("[]")
+	return json.Marshal(obj)
+}
@@ -0,0 +1,795 @@
+package simulated
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc/codec"
+	"github.com/umbracle/ethgo/jsonrpc/server"
+	"github.com/umbracle/ethgo/trie"
+	"github.com/umbracle/ethgo/wallet"
+)
+
+var (
+	// ErrContractCreation is returned for the transactions and calls that
+	// create a contract since the backend does not execute evm code
+	ErrContractCreation = errors.New("contract creation not supported")
+
+	// ErrTxTypeNotSupported is returned for the blob and set code transactions
+	ErrTxTypeNotSupported = errors.New("transaction type not supported")
+
+	// emptyUncleHash is the hash of an empty list of uncles
+	emptyUncleHash = ethgo.HexToHash("0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347")
+)
+
+const (
+	txGas                 = 21000
+	txDataZeroGas         = 4
+	txDataNonZeroGas      = 16
+	txAccessListAddrGas   = 2400
+	txAccessListStoreGas  = 1900
+	defaultGasLimit       = 30_000_000
+	defaultChainID        = 1337
+	defaultBaseFee        = 1_000_000_000
+	defaultGasTipCap      = 1_000_000_000
+	replacementPriceBumps = 10
+)
+
+// Account is the genesis state of an account
+type Account struct {
+	Balance *big.Int
+	Nonce   uint64
+	Code    []byte
+}
+
+func (a *Account) copy() *Account {
+	acct := &Account{
+		Balance: new(big.Int),
+		Nonce:   a.Nonce,
+		Code:    a.Code,
+	}
+	if a.Balance != nil {
+		acct.Balance.Set(a.Balance)
+	}
+	return acct
+}
+
+// state is the set of accounts after a block
+type state map[ethgo.Address]*Account
+
+func (s state) copy() state {
+	ss := state{}
+	for addr, acct := range s {
+		ss[addr] = acct.copy()
+	}
+	return ss
+}
+
+// get returns the account of the address and creates it if it does not exist
+func (s state) get(addr ethgo.Address) *Account {
+	acct, ok := s[addr]
+	if !ok {
+		acct = &Account{Balance: new(big.Int)}
+		s[addr] = acct
+	}
+	return acct
+}
+
+type Config struct {
+	// ChainID is the chain id used to validate the signatures
+	ChainID uint64
+
+	// GasLimit is the gas limit of the blocks
+	GasLimit uint64
+
+	// BaseFee is the (fixed) base fee of the blocks
+	BaseFee *big.Int
+
+	// GasTipCap is the priority fee suggested by eth_maxPriorityFeePerGas
+	GasTipCap *big.Int
+
+	// Coinbase receives the priority fees of the transactions
+	Coinbase ethgo.Address
+
+	// AutoMine mines a block with each transaction
+	AutoMine bool
+
+	// Alloc is the genesis state
+	Alloc map[ethgo.Address]*Account
+
+	// Contracts are the contracts implemented in go by address
+	Contracts map[ethgo.Address]Contract
+}
+
+type ConfigOption func(*Config)
+
+// WithChainID sets the chain id of the backend
+func WithChainID(chainID uint64) ConfigOption {
+	return func(c *Config) {
+		c.ChainID = chainID
+	}
+}
+
+// WithGasLimit sets the gas limit of the blocks
+func WithGasLimit(gasLimit uint64) ConfigOption {
+	return func(c *Config) {
+		c.GasLimit = gasLimit
+	}
+}
+
+// WithBaseFee sets the base fee of the blocks
+func WithBaseFee(baseFee *big.Int) ConfigOption {
+	return func(c *Config) {
+		c.BaseFee = baseFee
+	}
+}
+
+// WithAutoMine mines a block with each transaction received
+func WithAutoMine() ConfigOption {
+	return func(c *Config) {
+		c.AutoMine = true
+	}
+}
+
+// WithAlloc adds an account to the genesis state
+func WithAlloc(addr ethgo.Address, acct *Account) ConfigOption {
+	return func(c *Config) {
+		c.Alloc[addr] = acct
+	}
+}
+
+// WithContract deploys a contract implemented in go at the address
+func WithContract(addr ethgo.Address, contract Contract) ConfigOption {
+	return func(c *Config) {
+		c.Contracts[addr] = contract
+	}
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		ChainID:   defaultChainID,
+		GasLimit:  defaultGasLimit,
+		BaseFee:   big.NewInt(defaultBaseFee),
+		GasTipCap: big.NewInt(defaultGasTipCap),
+		Alloc:     map[ethgo.Address]*Account{},
+		Contracts: map[ethgo.Address]Contract{},
+	}
+}
+
+// Backend is an in-memory simulated chain served over http and websocket.
+// It validates and mines signed transactions but it does not include an
+// evm, the calls to the contracts are executed by go implementations.
+type Backend struct {
+	config *Config
+	signer *wallet.EIP1155Signer
+
+	lock         sync.Mutex
+	blocks       []*ethgo.Block
+	states       []state
+	receipts     [][]*ethgo.Receipt
+	blocksByHash map[ethgo.Hash]*ethgo.Block
+	txns         map[ethgo.Hash]*ethgo.Transaction
+	txReceipts   map[ethgo.Hash]*ethgo.Receipt
+	pending      []*ethgo.Transaction
+	pendingState state
+
+	subsLock sync.Mutex
+	subs     map[*subscriber]struct{}
+
+	// events pending to be delivered to the subscribers
+	eventsLock sync.Mutex
+	events     []func()
+	eventsCh   chan struct{}
+
+	srv       *server.Server
+	listener  net.Listener
+	closeCh   chan struct{}
+	closeOnce sync.Once
+}
+
+// NewBackend creates a simulated chain and starts its jsonrpc server
+func NewBackend(opts ...ConfigOption) (*Backend, error) {
+	config := DefaultConfig()
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	b := &Backend{
+		config:       config,
+		signer:       wallet.NewEIP155Signer(config.ChainID),
+		blocksByHash: map[ethgo.Hash]*ethgo.Block{},
+		txns:         map[ethgo.Hash]*ethgo.Transaction{},
+		txReceipts:   map[ethgo.Hash]*ethgo.Receipt{},
+		subs:         map[*subscriber]struct{}{},
+		eventsCh:     make(chan struct{}, 1),
+		closeCh:      make(chan struct{}),
+	}
+
+	genesisState := state{}
+	for addr, acct := range config.Alloc {
+		genesisState[addr] = acct.copy()
+	}
+	genesis := &ethgo.Block{
+		Number:     0,
+		Timestamp:  uint64(time.Now().Unix()),
+		GasLimit:   config.GasLimit,
+		BaseFee:    new(big.Int).Set(config.BaseFee),
+		Difficulty: new(big.Int),
+		LogsBloom:  make([]byte, ethgo.BloomByteLength),
+	}
+	if err := sealBlock(genesis, nil); err != nil {
+		return nil, err
+	}
+	b.addBlock(genesis, genesisState, nil)
+	b.pendingState = genesisState.copy()
+
+	b.srv = server.NewServer()
+	for namespace, api := range map[string]interface{}{
+		"eth":  &ethAPI{b: b},
+		"net":  &netAPI{b: b},
+		"web3": &web3API{},
+		"evm":  &evmAPI{b: b},
+	} {
+		if err := b.srv.Register(namespace, api); err != nil {
+			return nil, err
+		}
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	b.listener = listener
+	go b.srv.ServeHTTPListener(listener)
+	go b.dispatchEvents()
+
+	return b, nil
+}
+
+// NewTestBackend creates a simulated chain that is closed at the end of the test
+func NewTestBackend(t *testing.T, opts ...ConfigOption) *Backend {
+	b, err := NewBackend(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(b.Close)
+	return b
+}
+
+// Close stops the jsonrpc server. It can be called more than once
+// (i.e. in a test that also closes it on cleanup)
+func (b *Backend) Close() {
+	b.closeOnce.Do(func() {
+		close(b.closeCh)
+		b.srv.Stop()
+		b.listener.Close()
+	})
+}
+
+// Server returns the jsonrpc server of the backend (i.e. to serve ipc)
+func (b *Backend) Server() *server.Server {
+	return b.srv
+}
+
+// HTTPAddr returns the http address of the jsonrpc server
+func (b *Backend) HTTPAddr() string {
+	return "http://" + b.listener.Addr().String()
+}
+
+// WSAddr returns the websocket address of the jsonrpc server
+func (b *Backend) WSAddr() string {
+	return "ws://" + b.listener.Addr().String()
+}
+
+// ChainID returns the chain id of the backend
+func (b *Backend) ChainID() uint64 {
+	return b.config.ChainID
+}
+
+// Head returns the last mined block
+func (b *Backend) Head() *ethgo.Block {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.head()
+}
+
+func (b *Backend) head() *ethgo.Block {
+	return b.blocks[len(b.blocks)-1]
+}
+
+// PendingTransactions returns the transactions waiting to be mined
+func (b *Backend) PendingTransactions() []*ethgo.Transaction {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return append([]*ethgo.Transaction{}, b.pending...)
+}
+
+// SendTransaction validates a signed transaction and adds it to the pending
+// transactions. It returns an error if the nonce is not the next one of the
+// sender or if the sender cannot pay for the gas and the value.
+func (b *Backend) SendTransaction(tx *ethgo.Transaction) (ethgo.Hash, error) {
+	tx = tx.Copy()
+	tx.BlockHash = ethgo.Hash{}
+
+	hash, err := tx.GetHash()
+	if err != nil {
+		return ethgo.Hash{}, err
+	}
+	tx.Hash = hash
+
+	from, err := b.signer.RecoverSender(tx)
+	if err != nil {
+		return ethgo.Hash{}, fmt.Errorf("invalid sender: %v", err)
+	}
+	tx.From = from
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if _, ok := b.txns[hash]; ok {
+		return ethgo.Hash{}, fmt.Errorf("already known")
+	}
+
+	// replace the pending transaction with the same nonce if any
+	pending := append([]*ethgo.Transaction{}, b.pending...)
+	replaced := -1
+	for indx, p := range pending {
+		if p.From == tx.From && p.Nonce == tx.Nonce {
+			if !isReplacement(p, tx) {
+				return ethgo.Hash{}, codec.ErrReplacementUnderpriced
+			}
+			replaced = indx
+			pending[indx] = tx
+			break
+		}
+	}
+	if replaced == -1 {
+		pending = append(pending, tx)
+	}
+
+	pendingState, err := b.applyPending(pending)
+	if err != nil {
+		return ethgo.Hash{}, err
+	}
+	if replaced != -1 {
+		delete(b.txns, b.pending[replaced].Hash)
+	}
+	b.pending = pending
+	b.pendingState = pendingState
+	b.txns[hash] = tx
+
+	b.notify(func(s *subscriber) {
+		if s.tx != nil {
+			s.tx(hash)
+		}
+	})
+	if b.config.AutoMine {
+		b.mine()
+	}
+	return hash, nil
+}
+
+// applyPending validates the pending transactions on top of the head state. The
+// state is debited with the maximum cost of the transactions since the contracts
+// are only executed when the transactions are mined.
+func (b *Backend) applyPending(pending []*ethgo.Transaction) (state, error) {
+	st := b.states[len(b.states)-1].copy()
+	for _, tx := range pending {
+		if err := b.validateTx(st, tx); err != nil {
+			return nil, err
+		}
+		debitTx(st, tx)
+	}
+	return st, nil
+}
+
+// debitTx increases the nonce of the sender and debits the maximum cost of the transaction
+func debitTx(st state, tx *ethgo.Transaction) {
+	acct := st.get(tx.From)
+	acct.Nonce++
+	acct.Balance.Sub(acct.Balance, txCost(tx))
+}
+
+func (b *Backend) validateTx(st state, tx *ethgo.Transaction) error {
+	switch tx.Type {
+	case ethgo.TransactionLegacy, ethgo.TransactionAccessList, ethgo.TransactionDynamicFee:
+	default:
+		return ErrTxTypeNotSupported
+	}
+	if tx.To == nil {
+		return ErrContractCreation
+	}
+	if tx.Type != ethgo.TransactionLegacy && tx.ChainID != nil && tx.ChainID.Uint64() != b.config.ChainID {
+		return fmt.Errorf("invalid chain id: have %d want %d", tx.ChainID, b.config.ChainID)
+	}
+	if tx.Gas > b.config.GasLimit {
+		return fmt.Errorf("exceeds block gas limit")
+	}
+	if gas := intrinsicGas(tx.Input, tx.AccessList); tx.Gas < gas {
+		return fmt.Errorf("intrinsic gas too low: have %d, want %d", tx.Gas, gas)
+	}
+	if tx.Type == ethgo.TransactionDynamicFee && gasTipCap(tx).Cmp(gasFeeCap(tx)) > 0 {
+		return fmt.Errorf("max priority fee per gas higher than max fee per gas")
+	}
+	if gasFeeCap(tx).Cmp(b.config.BaseFee) < 0 {
+		return fmt.Errorf("max fee per gas less than block base fee: address %s, maxFeePerGas: %s baseFee: %s", tx.From, gasFeeCap(tx), b.config.BaseFee)
+	}
+
+	acct := st.get(tx.From)
+	if tx.Nonce < acct.Nonce {
+		return fmt.Errorf("%w: address %s, tx: %d state: %d", codec.ErrNonceTooLow, tx.From, tx.Nonce, acct.Nonce)
+	}
+	if tx.Nonce > acct.Nonce {
+		return fmt.Errorf("nonce too high: address %s, tx: %d state: %d", tx.From, tx.Nonce, acct.Nonce)
+	}
+	if cost := txCost(tx); acct.Balance.Cmp(cost) < 0 {
+		return fmt.Errorf("%w for gas * price + value: address %s have %s want %s", codec.ErrInsufficientFunds, tx.From, acct.Balance, cost)
+	}
+	return nil
+}
+
+// Commit mines a block with the pending transactions
+func (b *Backend) Commit() *ethgo.Block {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.mine()
+}
+
+// mine mines a block with the pending transactions that fit in the gas limit
+// and notifies the subscribers
+func (b *Backend) mine() *ethgo.Block {
+	parent := b.head()
+	st := b.states[len(b.states)-1].copy()
+
+	timestamp := uint64(time.Now().Unix())
+	if timestamp <= parent.Timestamp {
+		timestamp = parent.Timestamp + 1
+	}
+	block := &ethgo.Block{
+		Number:     parent.Number + 1,
+		ParentHash: parent.Hash,
+		Miner:      b.config.Coinbase,
+		GasLimit:   b.config.GasLimit,
+		Timestamp:  timestamp,
+		BaseFee:    new(big.Int).Set(b.config.BaseFee),
+		Difficulty: new(big.Int),
+	}
+
+	receipts := []*ethgo.Receipt{}
+	for _, tx := range b.pending {
+		if block.GasUsed+tx.Gas > block.GasLimit {
+			break
+		}
+		receipt := b.applyTransaction(st, block, tx)
+		block.GasUsed += receipt.GasUsed
+		receipt.CumulativeGasUsed = block.GasUsed
+		receipts = append(receipts, receipt)
+
+		block.Transactions = append(block.Transactions, tx)
+	}
+	b.pending = b.pending[len(block.Transactions):]
+
+	logs := []*ethgo.Log{}
+	for _, receipt := range receipts {
+		receipt.LogsBloom = bloomBytes(receipt.Logs)
+		logs = append(logs, receipt.Logs...)
+	}
+	block.LogsBloom = bloomBytes(logs)
+
+	if err := sealBlock(block, receipts); err != nil {
+		// the transactions and receipts are validated before
+		panic(err)
+	}
+
+	// fill the block values of the transactions, receipts and logs
+	for indx, tx := range block.Transactions {
+		tx = tx.Copy()
+		tx.BlockHash = block.Hash
+		tx.BlockNumber = block.Number
+		tx.TxnIndex = uint64(indx)
+		block.Transactions[indx] = tx
+		b.txns[tx.Hash] = tx
+
+		receipt := receipts[indx]
+		receipt.BlockHash = block.Hash
+		receipt.BlockNumber = block.Number
+		receipt.TransactionIndex = uint64(indx)
+		for _, log := range receipt.Logs {
+			log.BlockHash = block.Hash
+			log.BlockNumber = block.Number
+			log.TransactionHash = tx.Hash
+			log.TransactionIndex = uint64(indx)
+		}
+		b.txReceipts[tx.Hash] = receipt
+	}
+	for indx, log := range logs {
+		log.LogIndex = uint64(indx)
+	}
+
+	b.addBlock(block, st, receipts)
+
+	// the transactions that did not fit are validated again on the new state
+	pendingState := st.copy()
+	pending := []*ethgo.Transaction{}
+	for _, tx := range b.pending {
+		if err := b.validateTx(pendingState, tx); err != nil {
+			delete(b.txns, tx.Hash)
+			continue
+		}
+		debitTx(pendingState, tx)
+		pending = append(pending, tx)
+	}
+	b.pending = pending
+	b.pendingState = pendingState
+
+	b.notify(func(s *subscriber) {
+		if s.block != nil {
+			s.block(block, logs)
+		}
+	})
+	return block
+}
+
+// applyTransaction executes the transaction on the state and returns its receipt.
+// The gas used is the intrinsic gas since there is no evm.
+func (b *Backend) applyTransaction(st state, block *ethgo.Block, tx *ethgo.Transaction) *ethgo.Receipt {
+	gasUsed := intrinsicGas(tx.Input, tx.AccessList)
+	price := effectiveGasPrice(tx, block.BaseFee)
+
+	sender := st.get(tx.From)
+	sender.Nonce++
+	sender.Balance.Sub(sender.Balance, new(big.Int).Mul(price, new(big.Int).SetUint64(gasUsed)))
+
+	tip := new(big.Int).Sub(price, block.BaseFee)
+	coinbase := st.get(block.Miner)
+	coinbase.Balance.Add(coinbase.Balance, tip.Mul(tip, new(big.Int).SetUint64(gasUsed)))
+
+	receipt := &ethgo.Receipt{
+		TransactionHash:   tx.Hash,
+		From:              tx.From,
+		To:                tx.To,
+		GasUsed:           gasUsed,
+		Status:            1,
+		Type:              tx.Type,
+		EffectiveGasPrice: price,
+		Logs:              []*ethgo.Log{},
+	}
+
+	value := tx.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	if contract, ok := b.config.Contracts[*tx.To]; ok {
+		ctx := &CallContext{
+			From:        tx.From,
+			To:          *tx.To,
+			Value:       new(big.Int).Set(value),
+			Input:       append([]byte{}, tx.Input...),
+			BlockNumber: block.Number,
+			Timestamp:   block.Timestamp,
+		}
+		if _, err := contract.Call(ctx); err != nil {
+			receipt.Status = 0
+			return receipt
+		}
+		receipt.Logs = ctx.logs
+	}
+
+	sender.Balance.Sub(sender.Balance, value)
+	to := st.get(*tx.To)
+	to.Balance.Add(to.Balance, value)
+
+	return receipt
+}
+
+func (b *Backend) addBlock(block *ethgo.Block, st state, receipts []*ethgo.Receipt) {
+	b.blocks = append(b.blocks, block)
+	b.states = append(b.states, st)
+	b.receipts = append(b.receipts, receipts)
+	b.blocksByHash[block.Hash] = block
+}
+
+// call executes a message with the contract of the destination address
+func (b *Backend) call(msg *callArgs, st state, block *ethgo.Block) ([]byte, error) {
+	if msg.To == nil {
+		return nil, ErrContractCreation
+	}
+
+	value := new(big.Int)
+	if msg.Value != nil {
+		value = (*big.Int)(msg.Value)
+	}
+	if value.Sign() != 0 && st.get(msg.from()).Balance.Cmp(value) < 0 {
+		return nil, fmt.Errorf("%w for transfer", codec.ErrInsufficientFunds)
+	}
+
+	contract, ok := b.config.Contracts[*msg.To]
+	if !ok {
+		return []byte{}, nil
+	}
+	ctx := &CallContext{
+		From:        msg.from(),
+		To:          *msg.To,
+		Value:       new(big.Int).Set(value),
+		Input:       msg.input(),
+		BlockNumber: block.Number,
+		Timestamp:   block.Timestamp,
+		Static:      true,
+	}
+	res, err := contract.Call(ctx)
+	if err != nil {
+		return nil, revertError(err)
+	}
+	return res, nil
+}
+
+// revertError converts the error of a contract into a revert error
+func revertError(err error) error {
+	var revertErr *codec.RevertError
+	if errors.As(err, &revertErr) {
+		return revertErr
+	}
+	msg := err.Error()
+	if !strings.HasPrefix(msg, codec.ErrExecutionReverted.Error()) {
+		msg = codec.ErrExecutionReverted.Error() + ": " + msg
+	}
+	return &codec.RevertError{Message: msg}
+}
+
+// isReplacement returns whether the new transaction pays enough
+// to replace the pending transaction with the same nonce
+func isReplacement(old, tx *ethgo.Transaction) bool {
+	bump := func(v *big.Int) *big.Int {
+		v = new(big.Int).Mul(v, big.NewInt(100+replacementPriceBumps))
+		return v.Div(v, big.NewInt(100))
+	}
+	return gasFeeCap(tx).Cmp(bump(gasFeeCap(old))) >= 0 && gasTipCap(tx).Cmp(bump(gasTipCap(old))) >= 0
+}
+
+// intrinsicGas returns the gas of a transaction with the data and the access list
+func intrinsicGas(data []byte, accessList ethgo.AccessList) uint64 {
+	gas := uint64(txGas)
+	for _, b := range data {
+		if b == 0 {
+			gas += txDataZeroGas
+		} else {
+			gas += txDataNonZeroGas
+		}
+	}
+	for _, entry := range accessList {
+		gas += txAccessListAddrGas + uint64(len(entry.Storage))*txAccessListStoreGas
+	}
+	return gas
+}
+
+func gasFeeCap(tx *ethgo.Transaction) *big.Int {
+	if tx.Type == ethgo.TransactionDynamicFee {
+		if tx.MaxFeePerGas == nil {
+			return new(big.Int)
+		}
+		return tx.MaxFeePerGas
+	}
+	return new(big.Int).SetUint64(tx.GasPrice)
+}
+
+func gasTipCap(tx *ethgo.Transaction) *big.Int {
+	if tx.Type == ethgo.TransactionDynamicFee {
+		if tx.MaxPriorityFeePerGas == nil {
+			return new(big.Int)
+		}
+		return tx.MaxPriorityFeePerGas
+	}
+	return new(big.Int).SetUint64(tx.GasPrice)
+}
+
+// effectiveGasPrice returns the price per gas paid by the transaction
+func effectiveGasPrice(tx *ethgo.Transaction, baseFee *big.Int) *big.Int {
+	price := new(big.Int).Add(baseFee, gasTipCap(tx))
+	if feeCap := gasFeeCap(tx); price.Cmp(feeCap) > 0 {
+		price.Set(feeCap)
+	}
+	return price
+}
+
+// txCost returns the maximum cost of the transaction (gas * fee cap + value)
+func txCost(tx *ethgo.Transaction) *big.Int {
+	cost := new(big.Int).Mul(gasFeeCap(tx), new(big.Int).SetUint64(tx.Gas))
+	if tx.Value != nil {
+		cost.Add(cost, tx.Value)
+	}
+	return cost
+}
+
+// sealBlock sets the roots of the transactions and receipts and the hash of
+// the header. The state root is zero since the state is not kept in a trie.
+func sealBlock(block *ethgo.Block, receipts []*ethgo.Receipt) error {
+	var err error
+	if block.TransactionsRoot, err = trie.TransactionsRoot(block.Transactions); err != nil {
+		return err
+	}
+	if block.ReceiptsRoot, err = trie.ReceiptsRoot(receipts); err != nil {
+		return err
+	}
+	block.Sha3Uncles = emptyUncleHash
+
+	block.Hash, err = block.ComputeHash()
+	return err
+}
+
+func bloomBytes(logs []*ethgo.Log) []byte {
+	return ethgo.CreateBloom(logs).Bytes()
+}
+
+// subscriber receives the events of the backend
+type subscriber struct {
+	block func(block *ethgo.Block, logs []*ethgo.Log)
+	tx    func(hash ethgo.Hash)
+}
+
+func (b *Backend) subscribe(s *subscriber) func() {
+	b.subsLock.Lock()
+	defer b.subsLock.Unlock()
+
+	b.subs[s] = struct{}{}
+	return func() {
+		b.subsLock.Lock()
+		defer b.subsLock.Unlock()
+
+		delete(b.subs, s)
+	}
+}
+
+// notify queues an event for the subscribers. It is called with the backend
+// locked so that the events are queued in order, but they are delivered in
+// the background so that the subscribers can call the backend.
+func (b *Backend) notify(fn func(s *subscriber)) {
+	b.subsLock.Lock()
+	subs := make([]*subscriber, 0, len(b.subs))
+	for s := range b.subs {
+		subs = append(subs, s)
+	}
+	b.subsLock.Unlock()
+
+	b.eventsLock.Lock()
+	b.events = append(b.events, func() {
+		for _, s := range subs {
+			fn(s)
+		}
+	})
+	b.eventsLock.Unlock()
+
+	select {
+	case b.eventsCh <- struct{}{}:
+	default:
+	}
+}
+
+// dispatchEvents delivers the queued events to the subscribers in order
+func (b *Backend) dispatchEvents() {
+	for {
+		select {
+		case <-b.eventsCh:
+		case <-b.closeCh:
+			return
+		}
+
+		b.eventsLock.Lock()
+		events := b.events
+		b.events = nil
+		b.eventsLock.Unlock()
+
+		for _, event := range events {
+			event()
+		}
+	}
+}
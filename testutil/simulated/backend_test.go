@@ -0,0 +1,410 @@
+package simulated
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+	"github.com/umbracle/ethgo/blocktracker"
+	"github.com/umbracle/ethgo/contract"
+	"github.com/umbracle/ethgo/jsonrpc"
+	"github.com/umbracle/ethgo/trie"
+	"github.com/umbracle/ethgo/wallet"
+)
+
+var (
+	storageABI = abi.MustNewABI(`[
+		{"type": "function", "name": "get", "stateMutability": "view", "inputs": [], "outputs": [{"name": "", "type": "uint256"}]},
+		{"type": "function", "name": "set", "stateMutability": "nonpayable", "inputs": [{"name": "val", "type": "uint256"}], "outputs": []},
+		{"type": "event", "name": "Set", "anonymous": false, "inputs": [{"name": "val", "type": "uint256", "indexed": true}]}
+	]`)
+
+	storageAddr = ethgo.Address{0x10}
+)
+
+// storageContract stores a value and emits the Set event when it changes
+type storageContract struct {
+	lock sync.Mutex
+	val  *big.Int
+}
+
+func (s *storageContract) Call(ctx *CallContext) ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	method := storageABI.GetMethod("get")
+	if string(ctx.Input[:4]) == string(method.ID()) {
+		return abi.Encode([]interface{}{s.val}, method.Outputs)
+	}
+
+	method = storageABI.GetMethod("set")
+	if string(ctx.Input[:4]) != string(method.ID()) {
+		return nil, fmt.Errorf("method not found")
+	}
+	args, err := method.Inputs.Decode(ctx.Input[4:])
+	if err != nil {
+		return nil, err
+	}
+	val := args.(map[string]interface{})["val"].(*big.Int)
+	if val.Sign() == 0 {
+		return nil, &jsonrpc.RevertError{Data: []byte{0x1}}
+	}
+	if !ctx.Static {
+		s.val = val
+		ctx.EmitLog([]ethgo.Hash{storageABI.Events["Set"].ID(), ethgo.BytesToHash(val.Bytes())}, nil)
+	}
+	return nil, nil
+}
+
+func newTestKey(t *testing.T) *wallet.Key {
+	key, err := wallet.GenerateKey()
+	require.NoError(t, err)
+	return key
+}
+
+func signTx(t *testing.T, b *Backend, key *wallet.Key, tx *ethgo.Transaction) []byte {
+	signed, err := wallet.NewEIP155Signer(b.ChainID()).SignTx(tx, key)
+	require.NoError(t, err)
+
+	data, err := signed.MarshalRLPTo(nil)
+	require.NoError(t, err)
+	return data
+}
+
+func TestBackend_Transfer(t *testing.T) {
+	key := newTestKey(t)
+	b := NewTestBackend(t, WithAlloc(key.Address(), &Account{Balance: ethgo.Ether(1)}))
+
+	c, err := jsonrpc.NewClient(b.HTTPAddr())
+	require.NoError(t, err)
+
+	chainID, err := c.Eth().ChainID()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1337), chainID.Uint64())
+
+	receiver := ethgo.Address{0x1}
+	tx := &ethgo.Transaction{
+		Type:     ethgo.TransactionDynamicFee,
+		ChainID:  chainID,
+		To:       &receiver,
+		Value:    big.NewInt(1000),
+		Gas:      21000,
+		Nonce:    0,
+		GasPrice: 0,
+
+		MaxFeePerGas:         big.NewInt(3_000_000_000),
+		MaxPriorityFeePerGas: big.NewInt(1_000_000_000),
+	}
+	hash, err := c.Eth().SendRawTransaction(signTx(t, b, key, tx))
+	require.NoError(t, err)
+
+	// the transaction is pending until the block is mined
+	receipt, err := c.Eth().GetTransactionReceipt(hash)
+	require.NoError(t, err)
+	assert.Nil(t, receipt)
+
+	var pendingTxn map[string]interface{}
+	require.NoError(t, c.Call("eth_getTransactionByHash", &pendingTxn, hash))
+	assert.Nil(t, pendingTxn["blockHash"])
+	assert.Nil(t, pendingTxn["blockNumber"])
+	assert.Nil(t, pendingTxn["transactionIndex"])
+
+	nonce, err := c.Eth().GetNonce(key.Address(), ethgo.Pending)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), nonce)
+
+	// the nonce is already used by the pending transaction
+	_, err = c.Eth().SendRawTransaction(signTx(t, b, key, &ethgo.Transaction{To: &receiver, Gas: 21000, GasPrice: 2_000_000_000}))
+	assert.True(t, errors.Is(err, jsonrpc.ErrReplacementUnderpriced))
+
+	block := b.Commit()
+	assert.Equal(t, uint64(1), block.Number)
+
+	receipt, err = c.Eth().GetTransactionReceipt(hash)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), receipt.Status)
+	assert.Equal(t, uint64(21000), receipt.GasUsed)
+	assert.Equal(t, block.Hash, receipt.BlockHash)
+	assert.Equal(t, big.NewInt(2_000_000_000), receipt.EffectiveGasPrice)
+
+	balance, err := c.Eth().GetBalance(receiver, ethgo.Latest)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1000), balance)
+
+	balance, err = c.Eth().GetBalance(key.Address(), ethgo.Latest)
+	require.NoError(t, err)
+	expected := new(big.Int).Sub(ethgo.Ether(1), big.NewInt(1000+21000*2_000_000_000))
+	assert.Equal(t, expected, balance)
+
+	// the balance at the genesis
+	balance, err = c.Eth().GetBalance(key.Address(), ethgo.BlockNumber(0))
+	require.NoError(t, err)
+	assert.Equal(t, ethgo.Ether(1), balance)
+
+	num, err := c.Eth().BlockNumber()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), num)
+
+	blk, err := c.Eth().GetBlockByNumber(1, true)
+	require.NoError(t, err)
+	assert.Equal(t, block.Hash, blk.Hash)
+	assert.Equal(t, hash, blk.Transactions[0].Hash)
+	assert.Equal(t, key.Address(), blk.Transactions[0].From)
+
+	txn, err := c.Eth().GetTransactionByHash(hash)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), txn.BlockNumber)
+
+	// the nonce is already mined
+	_, err = c.Eth().SendRawTransaction(signTx(t, b, key, &ethgo.Transaction{To: &receiver, Gas: 21000, GasPrice: 2_000_000_000}))
+	assert.True(t, errors.Is(err, jsonrpc.ErrNonceTooLow))
+
+	// not enough balance for the value
+	_, err = c.Eth().SendRawTransaction(signTx(t, b, key, &ethgo.Transaction{To: &receiver, Nonce: 1, Gas: 21000, GasPrice: 2_000_000_000, Value: ethgo.Ether(2)}))
+	assert.True(t, errors.Is(err, jsonrpc.ErrInsufficientFunds))
+
+	// wrong nonce
+	_, err = c.Eth().SendRawTransaction(signTx(t, b, key, &ethgo.Transaction{To: &receiver, Nonce: 5, Gas: 21000, GasPrice: 2_000_000_000}))
+	assert.Error(t, err)
+
+	// gas limit below the intrinsic gas
+	_, err = c.Eth().SendRawTransaction(signTx(t, b, key, &ethgo.Transaction{To: &receiver, Nonce: 1, Input: []byte{0x1}, Gas: 21000, GasPrice: 2_000_000_000}))
+	assert.Error(t, err)
+
+	// contract creations are not supported
+	_, err = c.Eth().SendRawTransaction(signTx(t, b, key, &ethgo.Transaction{Nonce: 1, Gas: 100000, GasPrice: 2_000_000_000}))
+	assert.Error(t, err)
+}
+
+func TestBackend_Contract(t *testing.T) {
+	key := newTestKey(t)
+	b := NewTestBackend(t,
+		WithAutoMine(),
+		WithAlloc(key.Address(), &Account{Balance: ethgo.Ether(1)}),
+		WithContract(storageAddr, &storageContract{val: big.NewInt(0)}),
+	)
+
+	c, err := jsonrpc.NewClient(b.HTTPAddr())
+	require.NoError(t, err)
+
+	cc := contract.NewContract(storageAddr, storageABI, contract.WithJsonRPC(c.Eth()), contract.WithSender(key))
+
+	txn, err := cc.Txn("set", big.NewInt(10))
+	require.NoError(t, err)
+	require.NoError(t, txn.Do())
+
+	receipt, err := txn.Wait()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), receipt.Status)
+	assert.Len(t, receipt.Logs, 1)
+	assert.Equal(t, storageAddr, receipt.Logs[0].Address)
+
+	res, err := cc.Call("get", ethgo.Latest)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(10), res["0"])
+
+	logs, err := c.Eth().GetLogs(&ethgo.LogFilter{
+		Address: []ethgo.Address{storageAddr},
+		Topics:  [][]*ethgo.Hash{{ptr(storageABI.Events["Set"].ID())}},
+	})
+	require.NoError(t, err)
+	assert.Len(t, logs, 1)
+	assert.Equal(t, receipt.TransactionHash, logs[0].TransactionHash)
+
+	// the call reverts with the data of the contract
+	_, err = cc.Call("set", ethgo.Latest, big.NewInt(0))
+	assert.True(t, errors.Is(err, jsonrpc.ErrExecutionReverted))
+
+	var revertErr *jsonrpc.RevertError
+	assert.True(t, errors.As(err, &revertErr))
+	assert.Equal(t, []byte{0x1}, revertErr.Data)
+}
+
+func TestBackend_ContractWait(t *testing.T) {
+	key := newTestKey(t)
+	b := NewTestBackend(t,
+		WithAlloc(key.Address(), &Account{Balance: ethgo.Ether(1)}),
+		WithContract(storageAddr, &storageContract{val: big.NewInt(0)}),
+	)
+
+	c, err := jsonrpc.NewClient(b.HTTPAddr())
+	require.NoError(t, err)
+
+	cc := contract.NewContract(storageAddr, storageABI, contract.WithJsonRPC(c.Eth()), contract.WithSender(key))
+
+	txn, err := cc.Txn("set", big.NewInt(10))
+	require.NoError(t, err)
+	require.NoError(t, txn.Do())
+
+	// the receipt is polled until the transaction is mined
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		b.Commit()
+	}()
+
+	receipt, err := txn.Wait()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), receipt.Status)
+}
+
+func TestBackend_Subscriptions(t *testing.T) {
+	key := newTestKey(t)
+	b := NewTestBackend(t,
+		WithAlloc(key.Address(), &Account{Balance: ethgo.Ether(1)}),
+		WithContract(storageAddr, &storageContract{val: big.NewInt(0)}),
+	)
+
+	c, err := jsonrpc.NewClient(b.WSAddr())
+	require.NoError(t, err)
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	headCh, headSub := c.Eth().SubscribeNewHeads(ctx)
+	defer headSub.Unsubscribe()
+
+	logCh, logSub := c.Eth().SubscribeLogs(ctx, &ethgo.LogFilter{Address: []ethgo.Address{storageAddr}})
+	defer logSub.Unsubscribe()
+
+	// wait for the subscriptions to be created
+	time.Sleep(100 * time.Millisecond)
+
+	input, err := storageABI.GetMethod("set").Encode([]interface{}{big.NewInt(5)})
+	require.NoError(t, err)
+
+	hash, err := c.Eth().SendRawTransaction(signTx(t, b, key, &ethgo.Transaction{To: &storageAddr, Input: input, Gas: 100000, GasPrice: 2_000_000_000}))
+	require.NoError(t, err)
+
+	block := b.Commit()
+
+	select {
+	case head := <-headCh:
+		assert.Equal(t, block.Hash, head.Hash)
+		assert.Equal(t, []ethgo.Hash{hash}, head.TransactionsHashes)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+
+	select {
+	case log := <-logCh:
+		assert.Equal(t, hash, log.TransactionHash)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+}
+
+func TestBackend_BlockHash(t *testing.T) {
+	key := newTestKey(t)
+	b := NewTestBackend(t,
+		WithAlloc(key.Address(), &Account{Balance: ethgo.Ether(1)}),
+		WithContract(storageAddr, &storageContract{val: big.NewInt(0)}),
+	)
+
+	c, err := jsonrpc.NewClient(b.HTTPAddr())
+	require.NoError(t, err)
+
+	input, err := storageABI.GetMethod("set").Encode([]interface{}{big.NewInt(5)})
+	require.NoError(t, err)
+
+	_, err = c.Eth().SendRawTransaction(signTx(t, b, key, &ethgo.Transaction{To: &storageAddr, Input: input, Gas: 100000, GasPrice: 2_000_000_000}))
+	require.NoError(t, err)
+	b.Commit()
+
+	for _, num := range []ethgo.BlockNumber{0, 1} {
+		block, err := c.Eth().GetBlockByNumber(num, true)
+		require.NoError(t, err)
+
+		// the hash is the hash of the header with the roots of the block
+		hash, err := block.ComputeHash()
+		require.NoError(t, err)
+		assert.Equal(t, block.Hash, hash)
+
+		txRoot, err := trie.TransactionsRoot(block.Transactions)
+		require.NoError(t, err)
+		assert.Equal(t, block.TransactionsRoot, txRoot)
+
+		receipts, err := c.Eth().GetBlockReceipts(num)
+		require.NoError(t, err)
+
+		receiptsRoot, err := trie.ReceiptsRoot(receipts)
+		require.NoError(t, err)
+		assert.Equal(t, block.ReceiptsRoot, receiptsRoot)
+	}
+}
+
+func TestBackend_BlockTracker(t *testing.T) {
+	b := NewTestBackend(t)
+
+	c, err := jsonrpc.NewClient(b.WSAddr())
+	require.NoError(t, err)
+	defer c.Close()
+
+	sub, err := blocktracker.NewSubscriptionBlockTracker(c)
+	require.NoError(t, err)
+
+	tracker := blocktracker.NewBlockTracker(c.Eth(), blocktracker.WithTracker(sub))
+	require.NoError(t, tracker.Init())
+	defer tracker.Close()
+
+	eventCh := tracker.Subscribe()
+	go tracker.Start()
+
+	// wait for the subscription to be created
+	time.Sleep(100 * time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		block := b.Commit()
+
+		select {
+		case event := <-eventCh:
+			require.Len(t, event.Added, 1)
+			assert.Equal(t, block.Hash, event.Added[0].Hash)
+			assert.Equal(t, block.Number, event.Added[0].Number)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+		}
+	}
+}
+
+func TestBackend_GasLimit(t *testing.T) {
+	key := newTestKey(t)
+	b := NewTestBackend(t,
+		WithGasLimit(50000),
+		WithAlloc(key.Address(), &Account{Balance: ethgo.Ether(1)}),
+	)
+
+	for i := uint64(0); i < 3; i++ {
+		tx := &ethgo.Transaction{To: &ethgo.Address{0x1}, Nonce: i, Gas: 21000, GasPrice: 2_000_000_000}
+		signed, err := wallet.NewEIP155Signer(b.ChainID()).SignTx(tx, key)
+		require.NoError(t, err)
+
+		_, err = b.SendTransaction(signed)
+		require.NoError(t, err)
+	}
+
+	// only two transactions fit in a block
+	assert.Len(t, b.Commit().Transactions, 2)
+	assert.Len(t, b.PendingTransactions(), 1)
+	assert.Len(t, b.Commit().Transactions, 1)
+}
+
+func TestBackend_Close(t *testing.T) {
+	b := NewTestBackend(t)
+
+	// the backend is closed again on the cleanup of the test
+	b.Close()
+	b.Close()
+}
+
+func ptr(h ethgo.Hash) *ethgo.Hash {
+	return &h
+}
@@ -0,0 +1,57 @@
+package simulated
+
+import (
+	"math/big"
+
+	"github.com/umbracle/ethgo"
+)
+
+// Contract is a contract implemented in go. The backend does not include an EVM,
+// the transactions and the calls to the address of the contract are executed
+// by the contract instead. An error reverts the execution, a *jsonrpc.RevertError
+// sets the revert data.
+type Contract interface {
+	Call(ctx *CallContext) ([]byte, error)
+}
+
+// ContractFunc is an adapter to use a function as a Contract
+type ContractFunc func(ctx *CallContext) ([]byte, error)
+
+// Call implements the Contract interface
+func (f ContractFunc) Call(ctx *CallContext) ([]byte, error) {
+	return f(ctx)
+}
+
+// CallContext is the context of the execution of a contract
+type CallContext struct {
+	// From is the sender of the transaction or call
+	From ethgo.Address
+
+	// To is the address of the contract
+	To ethgo.Address
+
+	// Value is the value sent to the contract
+	Value *big.Int
+
+	// Input is the input data
+	Input []byte
+
+	// BlockNumber and Timestamp are the values of the block
+	BlockNumber uint64
+	Timestamp   uint64
+
+	// Static is true for the executions that do not modify the
+	// state (i.e. eth_call and eth_estimateGas)
+	Static bool
+
+	logs []*ethgo.Log
+}
+
+// EmitLog adds a log with the topics and the data to the execution
+func (c *CallContext) EmitLog(topics []ethgo.Hash, data []byte) {
+	c.logs = append(c.logs, &ethgo.Log{
+		Address: c.To,
+		Topics:  append([]ethgo.Hash{}, topics...),
+		Data:    append([]byte{}, data...),
+	})
+}
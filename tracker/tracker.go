@@ -6,8 +6,8 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"log"
+	"io"
+	"log/slog"
 	"math/big"
 	"strconv"
 	"strings"
@@ -18,6 +18,7 @@ import (
 	"github.com/umbracle/ethgo"
 	"github.com/umbracle/ethgo/blocktracker"
 	"github.com/umbracle/ethgo/etherscan"
+	"github.com/umbracle/ethgo/jsonrpc"
 	"github.com/umbracle/ethgo/jsonrpc/codec"
 	"github.com/umbracle/ethgo/tracker/store"
 	"github.com/umbracle/ethgo/tracker/store/inmem"
@@ -34,6 +35,17 @@ const (
 	defaultBatchSize = 100
 )
 
+const (
+	// MetricLastBlock is the gauge of the last block synced by the tracker
+	MetricLastBlock = "tracker_last_block"
+
+	// MetricLogs is the counter of logs added and removed by the tracker
+	MetricLogs = "tracker_logs_total"
+
+	// MetricReorgs is the counter of reorgs found by the tracker
+	MetricReorgs = "tracker_reorgs_total"
+)
+
 // BlockTracking defines interface for block tracker implementations
 type BlockTracking interface {
 	BlocksBlocked() []*ethgo.Block
@@ -94,6 +106,20 @@ func (f *FilterConfig) getFilterSearch() *ethgo.LogFilter {
 	return filter
 }
 
+// matchLogs returns the logs that match the filter. Some providers
+// do not apply all the fields of the query (i.e. topics).
+func (f *FilterConfig) matchLogs(logs []*ethgo.Log) []*ethgo.Log {
+	filter := f.getFilterSearch()
+
+	res := make([]*ethgo.Log, 0, len(logs))
+	for _, log := range logs {
+		if filter.Match(log) {
+			res = append(res, log)
+		}
+	}
+	return res
+}
+
 // Config is the configuration of the tracker
 type Config struct {
 	BatchSize       uint64
@@ -101,6 +127,8 @@ type Config struct {
 	EtherscanAPIKey string
 	Filter          *FilterConfig
 	Store           store.Store
+	Logger          *slog.Logger
+	Metrics         jsonrpc.Metrics
 }
 
 type ConfigOption func(*Config)
@@ -135,6 +163,18 @@ func WithEtherscan(k string) ConfigOption {
 	}
 }
 
+func WithLogger(l *slog.Logger) ConfigOption {
+	return func(c *Config) {
+		c.Logger = l
+	}
+}
+
+func WithMetrics(m jsonrpc.Metrics) ConfigOption {
+	return func(c *Config) {
+		c.Metrics = m
+	}
+}
+
 // DefaultConfig returns the default tracker config
 func DefaultConfig() *Config {
 	return &Config{
@@ -142,6 +182,8 @@ func DefaultConfig() *Config {
 		Store:           inmem.NewInmemStore(),
 		Filter:          &FilterConfig{},
 		EtherscanAPIKey: "",
+		Logger:          slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Metrics:         jsonrpc.NoopMetrics{},
 	}
 }
 
@@ -156,7 +198,8 @@ type Provider interface {
 
 // Tracker is a contract event tracker
 type Tracker struct {
-	logger       *log.Logger
+	logger       *slog.Logger
+	metrics      jsonrpc.Metrics
 	provider     Provider
 	config       *Config
 	store        store.Store
@@ -182,7 +225,8 @@ func NewTracker(provider Provider, opts ...ConfigOption) (*Tracker, error) {
 		provider:     provider,
 		config:       config,
 		BlockCh:      make(chan *blocktracker.BlockEvent, 1),
-		logger:       log.New(ioutil.Discard, "", log.LstdFlags),
+		logger:       config.Logger,
+		metrics:      config.Metrics,
 		ReadyCh:      make(chan struct{}),
 		store:        config.Store,
 		blockTracker: config.BlockTracker,
@@ -267,13 +311,23 @@ func (t *Tracker) storeLastBlock(b *ethgo.Block) error {
 		return err
 	}
 	raw := hex.EncodeToString(buf)
-	return t.store.Set(dbLastBlock+"_"+t.config.Filter.Hash, raw)
+	if err := t.store.Set(dbLastBlock+"_"+t.config.Filter.Hash, raw); err != nil {
+		return err
+	}
+	t.metrics.SetGauge(MetricLastBlock, float64(b.Number))
+	return nil
 }
 
 func (t *Tracker) emitEvent(evnt *Event) {
 	if evnt == nil {
 		return
 	}
+	if len(evnt.Added) != 0 {
+		t.metrics.IncCounter(MetricLogs, float64(len(evnt.Added)), "type", "added")
+	}
+	if len(evnt.Removed) != 0 {
+		t.metrics.IncCounter(MetricLogs, float64(len(evnt.Removed)), "type", "removed")
+	}
 	if t.config.Filter.Async {
 		select {
 		case t.EventCh <- evnt:
@@ -384,10 +438,13 @@ START:
 		if tooMuchDataRequestedError(err) {
 			// multiplicative decrease
 			batchSize = batchSize / 2
+			t.logger.Debug("too many logs, decrease the batch size", "from", i, "to", dst, "batchSize", batchSize)
 			goto START
 		}
 		return err
 	}
+	logs = t.config.Filter.matchLogs(logs)
+	t.logger.Debug("sync batch", "from", i, "to", dst, "logs", len(logs))
 
 	if t.SyncCh != nil {
 		select {
@@ -685,6 +742,8 @@ func (t *Tracker) syncImpl(ctx context.Context) error {
 			if err != nil {
 				return err
 			}
+			t.logger.Info("reorg since last sync", "last", last.Number, "ancestor", ancestor)
+			t.metrics.IncCounter(MetricReorgs, 1)
 
 			origin = ancestor + 1
 			logs, err := t.removeLogs(ancestor+1, nil)
@@ -805,6 +864,11 @@ func (t *Tracker) handleBlockEvnt(blockEvnt *blocktracker.BlockEvent) error {
 	default:
 	}
 
+	if len(blockEvnt.Removed) != 0 {
+		t.logger.Info("reorg", "removed", len(blockEvnt.Removed), "added", len(blockEvnt.Added))
+		t.metrics.IncCounter(MetricReorgs, 1)
+	}
+
 	if t.IsSynced() {
 		evnt, err := t.doFilter(blockEvnt.Added, blockEvnt.Removed)
 		if err != nil {
@@ -833,6 +897,11 @@ func (t *Tracker) doFilter(added []*ethgo.Block, removed []*ethgo.Block) (*Event
 		query := t.config.Filter.getFilterSearch()
 		query.BlockHash = &block.Hash
 
+		if len(block.LogsBloom) == ethgo.BloomByteLength && !query.MatchBloom(ethgo.BytesToBloom(block.LogsBloom)) {
+			// none of the logs in the block match the filter
+			continue
+		}
+
 		// We check the hash, we need to do a retry to let unsynced nodes get the block
 		var logs []*ethgo.Log
 		var err error
@@ -847,6 +916,7 @@ func (t *Tracker) doFilter(added []*ethgo.Block, removed []*ethgo.Block) (*Event
 		if err != nil {
 			return nil, err
 		}
+		logs = t.config.Filter.matchLogs(logs)
 
 		// add logs to the store
 		if err := t.entry.StoreLogs(logs); err != nil {
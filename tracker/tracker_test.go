@@ -773,3 +773,19 @@ func TestTooMuchDataRequested(t *testing.T) {
 		t.Fatal("not the same count")
 	}
 }
+
+func TestFilterConfig_MatchLogs(t *testing.T) {
+	topic := ethgo.Hash{0x1}
+
+	f := &FilterConfig{
+		Address: []ethgo.Address{{0x1}},
+		Topics:  [][]*ethgo.Hash{{&topic}},
+	}
+	logs := []*ethgo.Log{
+		{Address: ethgo.Address{0x1}, Topics: []ethgo.Hash{topic}},
+		{Address: ethgo.Address{0x2}, Topics: []ethgo.Hash{topic}},
+		{Address: ethgo.Address{0x1}, Topics: []ethgo.Hash{{0x2}}},
+		{Address: ethgo.Address{0x1}},
+	}
+	require.Equal(t, logs[:1], f.matchLogs(logs))
+}
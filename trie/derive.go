@@ -0,0 +1,64 @@
+package trie
+
+import (
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/fastrlp"
+)
+
+// IndexKey returns the key of the i-th element of a list in the
+// transactions and receipts tries, the RLP encoding of the index.
+func IndexKey(i uint64) []byte {
+	a := fastrlp.DefaultArenaPool.Get()
+	defer fastrlp.DefaultArenaPool.Put(a)
+
+	return a.NewUint(i).MarshalTo(nil)
+}
+
+// NewTransactionsTrie creates the trie of the transactions of a block
+func NewTransactionsTrie(txns []*ethgo.Transaction) (*Trie, error) {
+	t := NewTrie()
+	for indx, txn := range txns {
+		if txn.Sidecar != nil {
+			// the blobs are not part of the block
+			txn = txn.Copy()
+			txn.Sidecar = nil
+		}
+		data, err := txn.MarshalRLPTo(nil)
+		if err != nil {
+			return nil, err
+		}
+		t.Put(IndexKey(uint64(indx)), data)
+	}
+	return t, nil
+}
+
+// NewReceiptsTrie creates the trie of the receipts of a block
+func NewReceiptsTrie(receipts []*ethgo.Receipt) (*Trie, error) {
+	t := NewTrie()
+	for indx, receipt := range receipts {
+		data, err := receipt.MarshalRLPTo(nil)
+		if err != nil {
+			return nil, err
+		}
+		t.Put(IndexKey(uint64(indx)), data)
+	}
+	return t, nil
+}
+
+// TransactionsRoot computes the transactions root of a list of transactions
+func TransactionsRoot(txns []*ethgo.Transaction) (ethgo.Hash, error) {
+	t, err := NewTransactionsTrie(txns)
+	if err != nil {
+		return ethgo.Hash{}, err
+	}
+	return t.Hash(), nil
+}
+
+// ReceiptsRoot computes the receipts root of a list of receipts
+func ReceiptsRoot(receipts []*ethgo.Receipt) (ethgo.Hash, error) {
+	t, err := NewReceiptsTrie(receipts)
+	if err != nil {
+		return ethgo.Hash{}, err
+	}
+	return t.Hash(), nil
+}
@@ -0,0 +1,219 @@
+package trie
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/fastrlp"
+)
+
+// emptyCodeHash is the code hash of an account without code
+var emptyCodeHash = ethgo.BytesToHash(ethgo.Keccak256(nil))
+
+// VerifyProof verifies the merkle proof of a key against the root of a trie.
+// It returns the value of the key or nil if the proof shows that the key
+// is not in the trie.
+func VerifyProof(root ethgo.Hash, key []byte, proof [][]byte) ([]byte, error) {
+	if root == EmptyRoot {
+		return nil, nil
+	}
+
+	nodes := map[ethgo.Hash][]byte{}
+	for _, raw := range proof {
+		nodes[ethgo.BytesToHash(ethgo.Keccak256(raw))] = raw
+	}
+
+	p := &fastrlp.Parser{}
+	k := keyToNibbles(key)
+	hash := root
+	for {
+		raw, ok := nodes[hash]
+		if !ok {
+			return nil, fmt.Errorf("proof node %s not found", hash)
+		}
+		v, err := p.Parse(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		// walk the node and its embedded children
+		// until a reference to another node is found
+		for {
+			var child *fastrlp.Value
+			if child, k, err = nextNode(v, k); err != nil {
+				return nil, err
+			}
+			if child == nil {
+				return nil, nil
+			}
+			if len(k) == 0 {
+				val, err := child.Bytes()
+				if err != nil {
+					return nil, fmt.Errorf("invalid value node: %v", err)
+				}
+				if len(val) == 0 {
+					return nil, nil
+				}
+				return append([]byte{}, val...), nil
+			}
+			if child.Type() == fastrlp.TypeArray {
+				v = child
+				continue
+			}
+
+			ref, err := child.Bytes()
+			if err != nil {
+				return nil, err
+			}
+			if len(ref) == 0 {
+				return nil, nil
+			}
+			if len(ref) != 32 {
+				return nil, fmt.Errorf("invalid node reference of %d bytes", len(ref))
+			}
+			hash = ethgo.BytesToHash(ref)
+			break
+		}
+	}
+}
+
+// nextNode returns the child of the node in the path of the key and the
+// remaining of the key. It returns a nil child if the key is not in the node.
+func nextNode(v *fastrlp.Value, key []byte) (*fastrlp.Value, []byte, error) {
+	elems, err := v.GetElems()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch len(elems) {
+	case 2:
+		compact, err := elems[0].Bytes()
+		if err != nil {
+			return nil, nil, err
+		}
+		nibbles, err := compactToNibbles(compact)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(key) < len(nibbles) || !bytes.Equal(nibbles, key[:len(nibbles)]) {
+			return nil, nil, nil
+		}
+		return elems[1], key[len(nibbles):], nil
+
+	case 17:
+		if len(key) == 0 {
+			return nil, nil, fmt.Errorf("key consumed before reaching a value")
+		}
+		if key[0] == terminator {
+			return elems[16], key[1:], nil
+		}
+		return elems[key[0]], key[1:], nil
+
+	default:
+		return nil, nil, fmt.Errorf("invalid node with %d elements", len(elems))
+	}
+}
+
+// compactToNibbles decodes a hex-prefix encoded key into nibbles,
+// appending the terminator if it is a leaf key
+func compactToNibbles(compact []byte) ([]byte, error) {
+	if len(compact) == 0 {
+		return nil, fmt.Errorf("empty compact key")
+	}
+	flag := compact[0] >> 4
+	if flag > 3 {
+		return nil, fmt.Errorf("invalid compact key flag %d", flag)
+	}
+
+	nibbles := []byte{}
+	if flag&1 == 1 {
+		nibbles = append(nibbles, compact[0]&0x0f)
+	}
+	for _, b := range compact[1:] {
+		nibbles = append(nibbles, b>>4, b&0x0f)
+	}
+	if flag&2 == 2 {
+		nibbles = append(nibbles, terminator)
+	}
+	return nibbles, nil
+}
+
+// VerifyAccountProof verifies the account and the storage proofs returned by
+// eth_getProof against the state root of a block.
+func VerifyAccountProof(stateRoot ethgo.Hash, proof *ethgo.AccountProof) error {
+	val, err := VerifyProof(stateRoot, ethgo.Keccak256(proof.Address[:]), proof.AccountProof)
+	if err != nil {
+		return fmt.Errorf("invalid account proof: %v", err)
+	}
+
+	balance := proof.Balance
+	if balance == nil {
+		balance = new(big.Int)
+	}
+
+	if val == nil {
+		// the account does not exist. Clients either return empty
+		// or zero values for the storage and code hashes.
+		if proof.Nonce != 0 || balance.Sign() != 0 {
+			return fmt.Errorf("account %s not found in the state", proof.Address)
+		}
+		if proof.StorageHash != EmptyRoot && proof.StorageHash != (ethgo.Hash{}) {
+			return fmt.Errorf("storage hash %s for an account not in the state", proof.StorageHash)
+		}
+		if proof.CodeHash != emptyCodeHash && proof.CodeHash != (ethgo.Hash{}) {
+			return fmt.Errorf("code hash %s for an account not in the state", proof.CodeHash)
+		}
+	} else {
+		a := &fastrlp.Arena{}
+		v := a.NewArray()
+		v.Set(a.NewUint(proof.Nonce))
+		v.Set(a.NewBigInt(balance))
+		v.Set(a.NewBytes(proof.StorageHash[:]))
+		v.Set(a.NewBytes(proof.CodeHash[:]))
+
+		if !bytes.Equal(v.MarshalTo(nil), val) {
+			return fmt.Errorf("account %s does not match the proof", proof.Address)
+		}
+	}
+
+	for _, s := range proof.StorageProof {
+		if err := verifyStorageProof(proof.StorageHash, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func verifyStorageProof(storageRoot ethgo.Hash, proof *ethgo.StorageProof) error {
+	value := proof.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	if storageRoot == (ethgo.Hash{}) {
+		// account not in the state
+		storageRoot = EmptyRoot
+	}
+	val, err := VerifyProof(storageRoot, ethgo.Keccak256(proof.Key[:]), proof.Proof)
+	if err != nil {
+		return fmt.Errorf("invalid storage proof for slot %s: %v", proof.Key, err)
+	}
+
+	found := new(big.Int)
+	if val != nil {
+		p := &fastrlp.Parser{}
+		v, err := p.Parse(val)
+		if err != nil {
+			return err
+		}
+		if err := v.GetBigInt(found); err != nil {
+			return err
+		}
+	}
+	if found.Cmp(value) != 0 {
+		return fmt.Errorf("storage slot %s does not match the proof, expected %s but found %s", proof.Key, value, found)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package trie
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+)
+
+func TestVerifyProof(t *testing.T) {
+	tt := NewTrie()
+	for i := 0; i < 200; i++ {
+		tt.Put([]byte(fmt.Sprintf("key-%d", i)), []byte(fmt.Sprintf("val-%d", i)))
+	}
+	root := tt.Hash()
+
+	for i := 0; i < 200; i++ {
+		key := []byte(fmt.Sprintf("key-%d", i))
+		val, err := VerifyProof(root, key, tt.Prove(key))
+		require.NoError(t, err)
+		require.Equal(t, []byte(fmt.Sprintf("val-%d", i)), val)
+	}
+
+	// absence
+	for _, key := range []string{"key-", "key-1000", "unknown"} {
+		val, err := VerifyProof(root, []byte(key), tt.Prove([]byte(key)))
+		require.NoError(t, err)
+		require.Nil(t, val)
+	}
+
+	// missing nodes
+	key := []byte("key-10")
+	proof := tt.Prove(key)
+	_, err := VerifyProof(root, key, proof[:len(proof)-1])
+	require.Error(t, err)
+
+	// wrong root
+	_, err = VerifyProof(ethgo.Hash{0x1}, key, proof)
+	require.Error(t, err)
+}
+
+func TestVerifyProof_EmbeddedNodes(t *testing.T) {
+	// small keys and values are embedded in their parents
+	tt := NewTrie()
+	tt.Put([]byte("doe"), []byte("reindeer"))
+	tt.Put([]byte("dog"), []byte("puppy"))
+	tt.Put([]byte("dogglesworth"), []byte("cat"))
+
+	val, err := VerifyProof(tt.Hash(), []byte("dog"), tt.Prove([]byte("dog")))
+	require.NoError(t, err)
+	require.Equal(t, []byte("puppy"), val)
+}
+
+func readProofFixtures(t *testing.T) (ethgo.Hash, []*ethgo.AccountProof) {
+	data, err := ioutil.ReadFile("./fixtures/proof.json")
+	require.NoError(t, err)
+
+	var fixture struct {
+		StateRoot ethgo.Hash            `json:"stateRoot"`
+		Proofs    []*ethgo.AccountProof `json:"proofs"`
+	}
+	require.NoError(t, json.Unmarshal(data, &fixture))
+	return fixture.StateRoot, fixture.Proofs
+}
+
+func TestVerifyAccountProof(t *testing.T) {
+	root, proofs := readProofFixtures(t)
+	for _, proof := range proofs {
+		require.NoError(t, VerifyAccountProof(root, proof))
+	}
+}
+
+func TestVerifyAccountProof_Invalid(t *testing.T) {
+	cases := []func(p *ethgo.AccountProof){
+		func(p *ethgo.AccountProof) {
+			p.Balance = new(big.Int).Add(p.Balance, big.NewInt(1))
+		},
+		func(p *ethgo.AccountProof) {
+			p.Nonce++
+		},
+		func(p *ethgo.AccountProof) {
+			p.CodeHash = emptyCodeHash
+		},
+		func(p *ethgo.AccountProof) {
+			p.StorageProof[1].Value = big.NewInt(1)
+		},
+		func(p *ethgo.AccountProof) {
+			// a slot not in the storage must be zero
+			p.StorageProof[2].Value = big.NewInt(1)
+		},
+		func(p *ethgo.AccountProof) {
+			p.StorageProof[0].Key = ethgo.Hash{0x1}
+		},
+		func(p *ethgo.AccountProof) {
+			p.AccountProof = p.AccountProof[:1]
+		},
+	}
+	for _, c := range cases {
+		root, proofs := readProofFixtures(t)
+
+		proof := proofs[0]
+		c(proof)
+		require.Error(t, VerifyAccountProof(root, proof))
+	}
+}
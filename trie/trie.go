@@ -0,0 +1,235 @@
+package trie
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/fastrlp"
+)
+
+// EmptyRoot is the root hash of an empty trie
+var EmptyRoot = ethgo.HexToHash("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
+
+// Trie is an in-memory Merkle-Patricia trie
+type Trie struct {
+	root node
+}
+
+// NewTrie creates an empty trie
+func NewTrie() *Trie {
+	return &Trie{}
+}
+
+type node interface{}
+
+type (
+	// shortNode is either an extension or a leaf node. The key is in nibbles
+	// and it ends with the terminator for leaf nodes.
+	shortNode struct {
+		key []byte
+		val node
+	}
+	// fullNode is a branch node with 16 children and a value
+	fullNode struct {
+		children [17]node
+	}
+	valueNode []byte
+)
+
+// terminator is the nibble that marks the end of a key
+const terminator = 16
+
+// Put inserts or updates a key-value pair in the trie
+func (t *Trie) Put(key, value []byte) {
+	t.root = insert(t.root, keyToNibbles(key), valueNode(append([]byte{}, value...)))
+}
+
+// Get returns the value of a key in the trie
+func (t *Trie) Get(key []byte) ([]byte, bool) {
+	n := t.root
+	k := keyToNibbles(key)
+	for {
+		switch nn := n.(type) {
+		case nil:
+			return nil, false
+		case valueNode:
+			if len(k) != 0 {
+				return nil, false
+			}
+			return nn, true
+		case *shortNode:
+			if len(k) < len(nn.key) || !bytes.Equal(nn.key, k[:len(nn.key)]) {
+				return nil, false
+			}
+			n, k = nn.val, k[len(nn.key):]
+		case *fullNode:
+			if len(k) == 0 {
+				return nil, false
+			}
+			n, k = nn.children[k[0]], k[1:]
+		default:
+			panic(fmt.Sprintf("BUG: unknown node type %T", n))
+		}
+	}
+}
+
+// Hash returns the root hash of the trie
+func (t *Trie) Hash() ethgo.Hash {
+	if t.root == nil {
+		return EmptyRoot
+	}
+	a := &fastrlp.Arena{}
+	raw := encodeNode(a, t.root).MarshalTo(nil)
+	return ethgo.BytesToHash(ethgo.Keccak256(raw))
+}
+
+// Prove returns the RLP encoded nodes in the path from the root to the key.
+// Nodes that are embedded in its parent (less than 32 bytes) are not included.
+// The proof is valid both for the inclusion and for the absence of the key.
+func (t *Trie) Prove(key []byte) [][]byte {
+	a := &fastrlp.Arena{}
+
+	proof := [][]byte{}
+	n := t.root
+	k := keyToNibbles(key)
+	for n != nil {
+		if _, ok := n.(valueNode); ok {
+			break
+		}
+		raw := encodeNode(a, n).MarshalTo(nil)
+		if len(raw) >= 32 || len(proof) == 0 {
+			proof = append(proof, raw)
+		}
+
+		switch nn := n.(type) {
+		case *shortNode:
+			if len(k) < len(nn.key) || !bytes.Equal(nn.key, k[:len(nn.key)]) {
+				n = nil
+			} else {
+				n, k = nn.val, k[len(nn.key):]
+			}
+		case *fullNode:
+			n, k = nn.children[k[0]], k[1:]
+		}
+	}
+	return proof
+}
+
+func insert(n node, key []byte, value node) node {
+	if len(key) == 0 {
+		return value
+	}
+
+	switch nn := n.(type) {
+	case nil:
+		return &shortNode{key: key, val: value}
+
+	case *shortNode:
+		matchlen := prefixLen(key, nn.key)
+		if matchlen == len(nn.key) {
+			return &shortNode{key: nn.key, val: insert(nn.val, key[matchlen:], value)}
+		}
+
+		// the keys differ at matchlen, create a branch node
+		branch := &fullNode{}
+		branch.children[nn.key[matchlen]] = insert(nil, nn.key[matchlen+1:], nn.val)
+		branch.children[key[matchlen]] = insert(nil, key[matchlen+1:], value)
+
+		if matchlen == 0 {
+			return branch
+		}
+		return &shortNode{key: key[:matchlen], val: branch}
+
+	case *fullNode:
+		branch := &fullNode{children: nn.children}
+		branch.children[key[0]] = insert(nn.children[key[0]], key[1:], value)
+		return branch
+
+	default:
+		panic(fmt.Sprintf("BUG: unknown node type %T", n))
+	}
+}
+
+// encodeNode returns the RLP value of a node
+func encodeNode(a *fastrlp.Arena, n node) *fastrlp.Value {
+	switch nn := n.(type) {
+	case *shortNode:
+		v := a.NewArray()
+		v.Set(a.NewCopyBytes(nibblesToCompact(nn.key)))
+		v.Set(encodeChild(a, nn.val))
+		return v
+
+	case *fullNode:
+		v := a.NewArray()
+		for _, child := range nn.children {
+			if child == nil {
+				v.Set(a.NewNull())
+			} else {
+				v.Set(encodeChild(a, child))
+			}
+		}
+		return v
+
+	case valueNode:
+		return a.NewCopyBytes(nn)
+
+	default:
+		panic(fmt.Sprintf("BUG: unknown node type %T", n))
+	}
+}
+
+// encodeChild returns the reference of a child node in its parent. The child
+// is embedded if its encoding is less than 32 bytes, otherwise its hash is used.
+func encodeChild(a *fastrlp.Arena, n node) *fastrlp.Value {
+	if val, ok := n.(valueNode); ok {
+		return a.NewCopyBytes(val)
+	}
+	v := encodeNode(a, n)
+	raw := v.MarshalTo(nil)
+	if len(raw) < 32 {
+		return v
+	}
+	return a.NewCopyBytes(ethgo.Keccak256(raw))
+}
+
+func prefixLen(a, b []byte) int {
+	i := 0
+	for ; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			break
+		}
+	}
+	return i
+}
+
+// keyToNibbles converts a key to nibbles and appends the terminator
+func keyToNibbles(key []byte) []byte {
+	nibbles := make([]byte, len(key)*2+1)
+	for i, b := range key {
+		nibbles[i*2] = b / 16
+		nibbles[i*2+1] = b % 16
+	}
+	nibbles[len(nibbles)-1] = terminator
+	return nibbles
+}
+
+// nibblesToCompact encodes nibbles with the hex-prefix encoding
+func nibblesToCompact(nibbles []byte) []byte {
+	var flag byte
+	if len(nibbles) != 0 && nibbles[len(nibbles)-1] == terminator {
+		flag = 1
+		nibbles = nibbles[:len(nibbles)-1]
+	}
+
+	buf := make([]byte, len(nibbles)/2+1)
+	buf[0] = flag << 5
+	if len(nibbles)%2 == 1 {
+		buf[0] |= 1<<4 | nibbles[0]
+		nibbles = nibbles[1:]
+	}
+	for i := 0; i < len(nibbles); i += 2 {
+		buf[i/2+1] = nibbles[i]<<4 | nibbles[i+1]
+	}
+	return buf
+}
@@ -0,0 +1,154 @@
+package trie
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+)
+
+func TestTrie_Empty(t *testing.T) {
+	require.Equal(t, EmptyRoot, NewTrie().Hash())
+}
+
+func TestTrie_Hash(t *testing.T) {
+	cases := []struct {
+		items map[string]string
+		root  string
+	}{
+		{
+			map[string]string{
+				"doe":          "reindeer",
+				"dog":          "puppy",
+				"dogglesworth": "cat",
+			},
+			"0x8aad789dff2f538bca5d8ea56e8abe10f4c7ba3a5dea95fea4cd6e7c3a1168d3",
+		},
+		{
+			map[string]string{
+				"A": "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			},
+			"0xbc64e5d8c375124e2054b335e41c65dbc92a46b094e10826aa49a585e4c4658f",
+		},
+	}
+	for _, c := range cases {
+		tt := NewTrie()
+		for k, v := range c.items {
+			tt.Put([]byte(k), []byte(v))
+		}
+		require.Equal(t, ethgo.HexToHash(c.root), tt.Hash())
+
+		for k, v := range c.items {
+			val, ok := tt.Get([]byte(k))
+			require.True(t, ok)
+			require.Equal(t, []byte(v), val)
+		}
+		_, ok := tt.Get([]byte("unknown"))
+		require.False(t, ok)
+	}
+}
+
+func TestTrie_Update(t *testing.T) {
+	tt := NewTrie()
+	tt.Put([]byte("dog"), []byte("cat"))
+	tt.Put([]byte("dog"), []byte("puppy"))
+
+	val, ok := tt.Get([]byte("dog"))
+	require.True(t, ok)
+	require.Equal(t, []byte("puppy"), val)
+}
+
+type rootsCase struct {
+	Transactions     []string `json:"transactions"`
+	Receipts         []string `json:"receipts"`
+	TransactionsRoot string   `json:"transactionsRoot"`
+	ReceiptsRoot     string   `json:"receiptsRoot"`
+}
+
+func readRootsFixtures(t *testing.T) []*rootsCase {
+	data, err := ioutil.ReadFile("./fixtures/roots.json")
+	require.NoError(t, err)
+
+	var cases []*rootsCase
+	require.NoError(t, json.Unmarshal(data, &cases))
+	return cases
+}
+
+func decodeHex(t *testing.T, str string) []byte {
+	buf, err := hex.DecodeString(strings.TrimPrefix(str, "0x"))
+	require.NoError(t, err)
+	return buf
+}
+
+func TestTrie_TransactionsRoot(t *testing.T) {
+	for _, c := range readRootsFixtures(t) {
+		txns := []*ethgo.Transaction{}
+		for _, raw := range c.Transactions {
+			txn := new(ethgo.Transaction)
+			require.NoError(t, txn.UnmarshalRLP(decodeHex(t, raw)))
+			txns = append(txns, txn)
+		}
+
+		root, err := TransactionsRoot(txns)
+		require.NoError(t, err)
+		require.Equal(t, ethgo.HexToHash(c.TransactionsRoot), root)
+	}
+}
+
+func TestTrie_TransactionsRoot_JSON(t *testing.T) {
+	// the block includes typed transactions with a yParity of 0
+	data, err := ioutil.ReadFile("./fixtures/block.json")
+	require.NoError(t, err)
+
+	block := new(ethgo.Block)
+	require.NoError(t, block.UnmarshalJSON(data))
+
+	for _, txn := range block.Transactions {
+		hash, err := txn.GetHash()
+		require.NoError(t, err)
+		require.Equal(t, txn.Hash, hash)
+	}
+
+	root, err := TransactionsRoot(block.Transactions)
+	require.NoError(t, err)
+	require.Equal(t, block.TransactionsRoot, root)
+}
+
+func TestTrie_ReceiptsRoot(t *testing.T) {
+	for _, c := range readRootsFixtures(t) {
+		receipts := []*ethgo.Receipt{}
+		for _, raw := range c.Receipts {
+			receipt := new(ethgo.Receipt)
+			require.NoError(t, receipt.UnmarshalRLP(decodeHex(t, raw)))
+			receipts = append(receipts, receipt)
+		}
+
+		root, err := ReceiptsRoot(receipts)
+		require.NoError(t, err)
+		require.Equal(t, ethgo.HexToHash(c.ReceiptsRoot), root)
+	}
+}
+
+func TestTrie_Prove(t *testing.T) {
+	c := readRootsFixtures(t)[3]
+
+	receipts := []*ethgo.Receipt{}
+	for _, raw := range c.Receipts {
+		receipt := new(ethgo.Receipt)
+		require.NoError(t, receipt.UnmarshalRLP(decodeHex(t, raw)))
+		receipts = append(receipts, receipt)
+	}
+	tt, err := NewReceiptsTrie(receipts)
+	require.NoError(t, err)
+
+	proof := tt.Prove(IndexKey(100))
+	require.NotEmpty(t, proof)
+
+	// the first node is the root and the last one includes the receipt
+	require.Equal(t, ethgo.HexToHash(c.ReceiptsRoot), ethgo.BytesToHash(ethgo.Keccak256(proof[0])))
+	require.Contains(t, string(proof[len(proof)-1]), string(decodeHex(t, c.Receipts[100])))
+}
@@ -1,9 +1,13 @@
 package ethgo
 
-import "math/big"
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
 
 func convert(val uint64, decimals int64) *big.Int {
-	v := big.NewInt(int64(val))
+	v := new(big.Int).SetUint64(val)
 	exp := new(big.Int).Exp(big.NewInt(10), big.NewInt(decimals), nil)
 	return v.Mul(v, exp)
 }
@@ -17,3 +21,118 @@ func Ether(i uint64) *big.Int {
 func Gwei(i uint64) *big.Int {
 	return convert(i, 9)
 }
+
+// units are the decimals of the named ether denominations
+var units = map[string]uint8{
+	"wei":    0,
+	"kwei":   3,
+	"mwei":   6,
+	"gwei":   9,
+	"szabo":  12,
+	"finney": 15,
+	"ether":  18,
+}
+
+// UnitDecimals returns the decimals of a named unit (wei, kwei, mwei, gwei,
+// szabo, finney or ether)
+func UnitDecimals(unit string) (uint8, error) {
+	decimals, ok := units[strings.ToLower(unit)]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit '%s'", unit)
+	}
+	return decimals, nil
+}
+
+// ParseUnits converts a decimal string (i.e. "1.25") to its integer
+// value with the given decimals. It fails if the value has more
+// fractional digits than decimals.
+func ParseUnits(value string, decimals uint8) (*big.Int, error) {
+	str := strings.TrimSpace(value)
+
+	negative := false
+	if strings.HasPrefix(str, "-") {
+		negative = true
+		str = str[1:]
+	}
+
+	integer, fraction := str, ""
+	if indx := strings.Index(str, "."); indx != -1 {
+		integer, fraction = str[:indx], str[indx+1:]
+	}
+	if integer == "" && fraction == "" {
+		return nil, fmt.Errorf("invalid decimal value '%s'", value)
+	}
+	if !isDigits(integer) || !isDigits(fraction) {
+		return nil, fmt.Errorf("invalid decimal value '%s'", value)
+	}
+
+	// trailing zeros do not add precision
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > int(decimals) {
+		return nil, fmt.Errorf("value '%s' has more than %d decimals", value, decimals)
+	}
+	fraction += strings.Repeat("0", int(decimals)-len(fraction))
+
+	num, ok := new(big.Int).SetString(integer+fraction, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal value '%s'", value)
+	}
+	if negative {
+		num.Neg(num)
+	}
+	return num, nil
+}
+
+// FormatUnits converts an integer value with the given decimals to its
+// decimal string (i.e. "1.25"). Trailing zeros in the fraction are removed.
+// A nil value is formatted as zero.
+func FormatUnits(value *big.Int, decimals uint8) string {
+	if value == nil {
+		value = new(big.Int)
+	}
+	str := new(big.Int).Abs(value).String()
+	if len(str) <= int(decimals) {
+		str = strings.Repeat("0", int(decimals)-len(str)+1) + str
+	}
+
+	integer, fraction := str[:len(str)-int(decimals)], str[len(str)-int(decimals):]
+	fraction = strings.TrimRight(fraction, "0")
+
+	res := integer
+	if fraction != "" {
+		res += "." + fraction
+	}
+	if value.Sign() < 0 {
+		res = "-" + res
+	}
+	return res
+}
+
+// ParseEther converts a decimal string in ether to wei
+func ParseEther(value string) (*big.Int, error) {
+	return ParseUnits(value, units["ether"])
+}
+
+// FormatEther converts a value in wei to a decimal string in ether
+func FormatEther(value *big.Int) string {
+	return FormatUnits(value, units["ether"])
+}
+
+// ParseGwei converts a decimal string in gwei to wei
+func ParseGwei(value string) (*big.Int, error) {
+	return ParseUnits(value, units["gwei"])
+}
+
+// FormatGwei converts a value in wei to a decimal string in gwei
+func FormatGwei(value *big.Int) string {
+	return FormatUnits(value, units["gwei"])
+}
+
+func isDigits(str string) bool {
+	for _, c := range str {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,110 @@
+package ethgo
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnits_Convert(t *testing.T) {
+	assert.Equal(t, "1000000000000000000", Ether(1).String())
+	assert.Equal(t, "1000000000", Gwei(1).String())
+
+	// values larger than int64
+	assert.Equal(t, "18446744073709551615000000000", Gwei(18446744073709551615).String())
+}
+
+func TestUnits_ParseUnits(t *testing.T) {
+	cases := []struct {
+		value    string
+		decimals uint8
+		result   string
+	}{
+		{"1", 18, "1000000000000000000"},
+		{"1.25", 18, "1250000000000000000"},
+		{"0.000000000000000001", 18, "1"},
+		{".5", 1, "5"},
+		{"5.", 1, "50"},
+		{"1.2500", 2, "125"},
+		{"-1.5", 6, "-1500000"},
+		{" 10 ", 0, "10"},
+		{"123456789012345678901234567890.123", 3, "123456789012345678901234567890123"},
+	}
+	for _, c := range cases {
+		num, err := ParseUnits(c.value, c.decimals)
+		assert.NoError(t, err)
+		assert.Equal(t, c.result, num.String())
+	}
+}
+
+func TestUnits_ParseUnitsInvalid(t *testing.T) {
+	cases := []struct {
+		value    string
+		decimals uint8
+	}{
+		{"", 18},
+		{".", 18},
+		{"-", 18},
+		{"1.2.3", 18},
+		{"1e18", 18},
+		{"0x10", 18},
+		{"1,5", 18},
+		{"+1", 18},
+		// excess precision
+		{"1.5", 0},
+		{"0.0000000000000000001", 18},
+	}
+	for _, c := range cases {
+		_, err := ParseUnits(c.value, c.decimals)
+		assert.Error(t, err, c.value)
+	}
+}
+
+func TestUnits_FormatUnits(t *testing.T) {
+	cases := []struct {
+		value    string
+		decimals uint8
+		result   string
+	}{
+		{"1000000000000000000", 18, "1"},
+		{"1250000000000000000", 18, "1.25"},
+		{"1", 18, "0.000000000000000001"},
+		{"0", 18, "0"},
+		{"-1500000", 6, "-1.5"},
+		{"10", 0, "10"},
+		{"123456789012345678901234567890123", 3, "123456789012345678901234567890.123"},
+	}
+	for _, c := range cases {
+		num, ok := new(big.Int).SetString(c.value, 10)
+		assert.True(t, ok)
+		assert.Equal(t, c.result, FormatUnits(num, c.decimals))
+
+		// roundtrip
+		num2, err := ParseUnits(c.result, c.decimals)
+		assert.NoError(t, err)
+		assert.Equal(t, num, num2)
+	}
+
+	// nil is formatted as zero
+	assert.Equal(t, "0", FormatUnits(nil, 18))
+	assert.Equal(t, "0", FormatEther(nil))
+}
+
+func TestUnits_Named(t *testing.T) {
+	decimals, err := UnitDecimals("Gwei")
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(9), decimals)
+
+	_, err = UnitDecimals("unknown")
+	assert.Error(t, err)
+
+	num, err := ParseEther("1.5")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", FormatEther(num))
+	assert.Equal(t, "1500000000", FormatGwei(num))
+
+	num, err = ParseGwei("2.5")
+	assert.NoError(t, err)
+	assert.Equal(t, "2500000000", num.String())
+}
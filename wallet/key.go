@@ -89,14 +89,21 @@ func Ecrecover(hash, signature []byte) (ethgo.Address, error) {
 	return pubKeyToAddress(pub), nil
 }
 
+// RecoverPubkey returns the public key that created the signature of the hash.
+// The signature is either in the 65 bytes form (with V being 0, 1, 27 or 28)
+// or in the 64 bytes compact form of eip-2098.
 func RecoverPubkey(signature, hash []byte) (*ecdsa.PublicKey, error) {
-	size := len(signature)
-	term := byte(27)
-	if signature[size-1] == 1 {
-		term = 28
+	s, err := ethgo.SignatureFromBytes(signature)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := s.Bytes()
+	if err != nil {
+		return nil, err
 	}
 
-	sig := append([]byte{term}, signature[:size-1]...)
+	// btcec expects the recovery id first with an offset of 27
+	sig := append([]byte{27 + s.YParity}, raw[:64]...)
 	pub, _, err := btcecdsa.RecoverCompact(sig, hash)
 	if err != nil {
 		return nil, err
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/ethgo"
 )
 
 func TestKeySign(t *testing.T) {
@@ -18,3 +19,24 @@ func TestKeySign(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, addr, key.addr)
 }
+
+func TestKeySign_RecoverFormats(t *testing.T) {
+	key, err := GenerateKey()
+	assert.NoError(t, err)
+
+	hash := ethgo.Keccak256([]byte("hello world"))
+	signature, err := key.Sign(hash)
+	assert.NoError(t, err)
+
+	sig, err := ethgo.SignatureFromBytes(signature)
+	assert.NoError(t, err)
+
+	for _, encode := range []func() ([]byte, error){sig.Bytes, sig.BytesV27, sig.CompactBytes} {
+		raw, err := encode()
+		assert.NoError(t, err)
+
+		addr, err := Ecrecover(hash, raw)
+		assert.NoError(t, err)
+		assert.Equal(t, key.addr, addr)
+	}
+}
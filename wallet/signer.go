@@ -1,6 +1,7 @@
 package wallet
 
 import (
+	"fmt"
 	"math/big"
 
 	"github.com/umbracle/ethgo"
@@ -32,47 +33,69 @@ func (e *EIP1155Signer) RecoverSender(tx *ethgo.Transaction) (ethgo.Address, err
 			v -= 8
 		}
 	}
+	if v > 1 {
+		return ethgo.Address{}, fmt.Errorf("invalid signature v value")
+	}
 
-	sig, err := encodeSignature(tx.R, tx.S, byte(v))
+	sig := &ethgo.Signature{
+		R:       new(big.Int).SetBytes(tx.R),
+		S:       new(big.Int).SetBytes(tx.S),
+		YParity: byte(v),
+	}
+	var err error
+	if tx.Type == ethgo.TransactionLegacy {
+		// legacy transactions before homestead can have a high S value
+		err = sig.ValidateValues()
+	} else {
+		err = sig.Validate()
+	}
 	if err != nil {
 		return ethgo.Address{}, err
 	}
-	addr, err := Ecrecover(signHash(tx, e.chainID), sig)
+	raw, err := sig.Bytes()
 	if err != nil {
 		return ethgo.Address{}, err
 	}
-	return addr, nil
-}
-
-func trimBytesZeros(b []byte) []byte {
-	var i int
-	for i = 0; i < len(b); i++ {
-		if b[i] != 0x0 {
-			break
-		}
+	addr, err := Ecrecover(signHash(tx, e.chainID), raw)
+	if err != nil {
+		return ethgo.Address{}, err
 	}
-	return b[i:]
+	return addr, nil
 }
 
 func (e *EIP1155Signer) SignTx(tx *ethgo.Transaction, key ethgo.Key) (*ethgo.Transaction, error) {
 	hash := signHash(tx, e.chainID)
 
-	sig, err := key.Sign(hash)
+	sig, err := signHashWithKey(hash, key)
 	if err != nil {
 		return nil, err
 	}
 
-	vv := uint64(sig[64])
+	vv := uint64(sig.YParity)
 	if tx.Type == 0 {
 		vv = vv + 35 + e.chainID*2
 	}
 
-	tx.R = trimBytesZeros(sig[:32])
-	tx.S = trimBytesZeros(sig[32:64])
+	tx.R = sig.R.Bytes()
+	tx.S = sig.S.Bytes()
 	tx.V = new(big.Int).SetUint64(vv).Bytes()
 	return tx, nil
 }
 
+// signHashWithKey signs the hash with the key and returns the
+// signature in its low S form
+func signHashWithKey(hash []byte, key ethgo.Key) (*ethgo.Signature, error) {
+	raw, err := key.Sign(hash)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := ethgo.SignatureFromBytes(raw)
+	if err != nil {
+		return nil, err
+	}
+	return sig.Normalize(), nil
+}
+
 func signHash(tx *ethgo.Transaction, chainID uint64) []byte {
 	a := fastrlp.DefaultArenaPool.Get()
 	defer fastrlp.DefaultArenaPool.Put(a)
@@ -86,7 +109,7 @@ func signHash(tx *ethgo.Transaction, chainID uint64) []byte {
 
 	v.Set(a.NewUint(tx.Nonce))
 
-	if tx.Type == ethgo.TransactionDynamicFee {
+	if tx.Type == ethgo.TransactionDynamicFee || tx.Type == ethgo.TransactionBlob || tx.Type == ethgo.TransactionSetCode {
 		// dynamic fee uses
 		v.Set(a.NewBigInt(tx.MaxPriorityFeePerGas))
 		v.Set(a.NewBigInt(tx.MaxFeePerGas))
@@ -113,6 +136,24 @@ func signHash(tx *ethgo.Transaction, chainID uint64) []byte {
 		v.Set(accessList)
 	}
 
+	if tx.Type == ethgo.TransactionSetCode {
+		authList, err := tx.AuthorizationList.MarshalRLPWith(a)
+		if err != nil {
+			panic(err)
+		}
+		v.Set(authList)
+	}
+
+	if tx.Type == ethgo.TransactionBlob {
+		v.Set(a.NewBigInt(tx.MaxFeePerBlobGas))
+
+		hashes := a.NewArray()
+		for _, hash := range tx.BlobVersionedHashes {
+			hashes.Set(a.NewCopyBytes(hash[:]))
+		}
+		v.Set(hashes)
+	}
+
 	// EIP155
 	if chainID != 0 && tx.Type == ethgo.TransactionLegacy {
 		v.Set(a.NewUint(chainID))
@@ -129,10 +170,39 @@ func signHash(tx *ethgo.Transaction, chainID uint64) []byte {
 	return ethgo.Keccak256(dst)
 }
 
-func encodeSignature(R, S []byte, V byte) ([]byte, error) {
-	sig := make([]byte, 65)
-	copy(sig[32-len(R):32], R)
-	copy(sig[64-len(S):64], S)
-	sig[64] = V
-	return sig, nil
+// SignAuthorization signs an eip-7702 authorization with the key of the authority
+func SignAuthorization(auth *ethgo.Authorization, key ethgo.Key) (*ethgo.Authorization, error) {
+	hash := auth.SigningHash()
+
+	sig, err := signHashWithKey(hash[:], key)
+	if err != nil {
+		return nil, err
+	}
+
+	auth.R = sig.R
+	auth.S = sig.S
+	auth.YParity = sig.YParity
+	return auth, nil
+}
+
+// RecoverAuthority returns the address of the authority that signed the eip-7702 authorization
+func RecoverAuthority(auth *ethgo.Authorization) (ethgo.Address, error) {
+	if auth.R == nil || auth.S == nil {
+		return ethgo.Address{}, fmt.Errorf("authorization is not signed")
+	}
+
+	sig := &ethgo.Signature{
+		R:       auth.R,
+		S:       auth.S,
+		YParity: auth.YParity,
+	}
+	if err := sig.Validate(); err != nil {
+		return ethgo.Address{}, err
+	}
+	raw, err := sig.Bytes()
+	if err != nil {
+		return ethgo.Address{}, err
+	}
+	hash := auth.SigningHash()
+	return Ecrecover(hash[:], raw)
 }
@@ -20,12 +20,12 @@ func TestSigner_SignAndRecover(t *testing.T) {
 			txn.To = &to
 		}
 
-		txType := rapid.IntRange(0, 2).Draw(t, "tx type")
+		txType := rapid.IntRange(0, 4).Draw(t, "tx type")
 
 		// fill in specific fields depending on the type
 		// of the transaction.
 		txn.Type = ethgo.TransactionType(txType)
-		if txn.Type == ethgo.TransactionDynamicFee {
+		if txn.Type == ethgo.TransactionDynamicFee || txn.Type == ethgo.TransactionBlob || txn.Type == ethgo.TransactionSetCode {
 			maxFeePerGas := rapid.Int64Range(1, 1000000000).Draw(t, "maxFeePerGas")
 			txn.MaxFeePerGas = big.NewInt(maxFeePerGas)
 			maxPriorityFeePerGas := rapid.Int64Range(1, 1000000000).Draw(t, "maxPriorityFeePerGas")
@@ -34,6 +34,25 @@ func TestSigner_SignAndRecover(t *testing.T) {
 			gasPrice := rapid.Uint64Range(1, 1000000000).Draw(t, "gasPrice")
 			txn.GasPrice = gasPrice
 		}
+		if txn.Type == ethgo.TransactionBlob {
+			maxFeePerBlobGas := rapid.Int64Range(1, 1000000000).Draw(t, "maxFeePerBlobGas")
+			txn.MaxFeePerBlobGas = big.NewInt(maxFeePerBlobGas)
+			txn.BlobVersionedHashes = []ethgo.Hash{
+				ethgo.BytesToHash(rapid.SliceOfN(rapid.Byte(), 32, 32).Draw(t, "blob_hash")),
+			}
+		}
+		if txn.Type == ethgo.TransactionSetCode {
+			txn.AuthorizationList = ethgo.AuthorizationList{
+				{
+					ChainID: big.NewInt(rapid.Int64Range(0, 1000).Draw(t, "auth chainId")),
+					Address: ethgo.BytesToAddress(rapid.SliceOf(rapid.Byte()).Draw(t, "auth_addr")),
+					Nonce:   rapid.Uint64().Draw(t, "auth nonce"),
+					YParity: 1,
+					R:       big.NewInt(1),
+					S:       big.NewInt(1),
+				},
+			}
+		}
 
 		// signer is from a random chain
 		chainId := rapid.Uint64().Draw(t, "chainId")
@@ -73,8 +92,85 @@ func TestSigner_EIP1155(t *testing.T) {
 	assert.Equal(t, from, key.addr)
 }
 
-func TestTrimBytesZeros(t *testing.T) {
-	assert.Equal(t, trimBytesZeros([]byte{0x1, 0x2}), []byte{0x1, 0x2})
-	assert.Equal(t, trimBytesZeros([]byte{0x0, 0x1}), []byte{0x1})
-	assert.Equal(t, trimBytesZeros([]byte{0x0, 0x0}), []byte{})
+func TestSigner_Authorization(t *testing.T) {
+	key, err := GenerateKey()
+	require.NoError(t, err)
+
+	auth := &ethgo.Authorization{
+		ChainID: big.NewInt(1),
+		Address: ethgo.Address{0x1},
+		Nonce:   10,
+	}
+	auth, err = SignAuthorization(auth, key)
+	require.NoError(t, err)
+
+	authority, err := RecoverAuthority(auth)
+	require.NoError(t, err)
+	require.Equal(t, key.Address(), authority)
+
+	// a different nonce recovers a different authority
+	auth.Nonce = 11
+	authority, err = RecoverAuthority(auth)
+	require.NoError(t, err)
+	require.NotEqual(t, key.Address(), authority)
+}
+
+func TestSigner_TrimSignatureValues(t *testing.T) {
+	signer := NewEIP155Signer(1337)
+
+	key, err := GenerateKey()
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		txn, err := signer.SignTx(&ethgo.Transaction{Nonce: uint64(i)}, key)
+		require.NoError(t, err)
+
+		// R and S are encoded without leading zeros
+		require.NotEqual(t, byte(0), txn.R[0])
+		require.NotEqual(t, byte(0), txn.S[0])
+	}
+}
+
+func TestSigner_RecoverMalleable(t *testing.T) {
+	signer := NewEIP155Signer(1337)
+
+	key, err := GenerateKey()
+	require.NoError(t, err)
+
+	// the equivalent signature with a high S value and the other y parity
+	malleable := func(txn *ethgo.Transaction, offset uint64) {
+		n, _ := new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+		txn.S = new(big.Int).Sub(n, new(big.Int).SetBytes(txn.S)).Bytes()
+
+		yParity := new(big.Int).SetBytes(txn.V).Uint64() - offset
+		txn.V = new(big.Int).SetUint64(offset + (yParity ^ 1)).Bytes()
+	}
+
+	// legacy transactions accept high S values (pre homestead)
+	txn, err := signer.SignTx(&ethgo.Transaction{}, key)
+	require.NoError(t, err)
+	malleable(txn, 35+1337*2)
+
+	from, err := signer.RecoverSender(txn)
+	require.NoError(t, err)
+	require.Equal(t, key.Address(), from)
+
+	// typed transactions do not
+	txn, err = signer.SignTx(&ethgo.Transaction{Type: ethgo.TransactionDynamicFee, MaxFeePerGas: big.NewInt(1), MaxPriorityFeePerGas: big.NewInt(1)}, key)
+	require.NoError(t, err)
+	malleable(txn, 0)
+
+	_, err = signer.RecoverSender(txn)
+	require.Error(t, err)
+
+	// nor the authorizations
+	auth, err := SignAuthorization(&ethgo.Authorization{ChainID: big.NewInt(1)}, key)
+	require.NoError(t, err)
+
+	n, _ := new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+	auth.S = new(big.Int).Sub(n, auth.S)
+	auth.YParity ^= 1
+
+	_, err = RecoverAuthority(auth)
+	require.Error(t, err)
 }